@@ -0,0 +1,41 @@
+// Package api содержит описание HTTP API в формате OpenAPI 3.1 и страницу Swagger UI.
+// Спецификация пишется вручную, а не генерируется из обработчиков: ее соответствие
+// маршрутам и ответам проверяют тесты internal/server/openapi_test.go.
+package api
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"sync"
+
+	swaggerFiles "github.com/swaggo/files/v2"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed openapi.yaml
+var specYAML []byte
+
+//go:embed swagger.html
+var SwaggerHTML []byte
+
+// SwaggerAssets - скрипты и стили Swagger UI, встроенные в бинарник: страница /docs
+// не обращается к внешним CDN
+var SwaggerAssets fs.FS = swaggerFiles.FS
+
+// OpenAPIJSON возвращает документ OpenAPI в JSON. Документ хранится в YAML,
+// чтобы его было удобно править, и преобразуется один раз.
+var OpenAPIJSON = sync.OnceValues(func() ([]byte, error) {
+	var doc any
+	if err := yaml.Unmarshal(specYAML, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse openapi spec: %w", err)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode openapi spec: %w", err)
+	}
+
+	return data, nil
+})
@@ -0,0 +1,3301 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: prmanager/v1/pr_manager.proto
+
+// gRPC API сервиса. Операции повторяют HTTP API v1 и выполняются теми же сервисами,
+// ошибки предметной области возвращаются кодами gRPC (см. README).
+
+package prmanagerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PullRequestStatus int32
+
+const (
+	PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED PullRequestStatus = 0
+	PullRequestStatus_PULL_REQUEST_STATUS_OPEN        PullRequestStatus = 1
+	PullRequestStatus_PULL_REQUEST_STATUS_MERGED      PullRequestStatus = 2
+)
+
+// Enum value maps for PullRequestStatus.
+var (
+	PullRequestStatus_name = map[int32]string{
+		0: "PULL_REQUEST_STATUS_UNSPECIFIED",
+		1: "PULL_REQUEST_STATUS_OPEN",
+		2: "PULL_REQUEST_STATUS_MERGED",
+	}
+	PullRequestStatus_value = map[string]int32{
+		"PULL_REQUEST_STATUS_UNSPECIFIED": 0,
+		"PULL_REQUEST_STATUS_OPEN":        1,
+		"PULL_REQUEST_STATUS_MERGED":      2,
+	}
+)
+
+func (x PullRequestStatus) Enum() *PullRequestStatus {
+	p := new(PullRequestStatus)
+	*p = x
+	return p
+}
+
+func (x PullRequestStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PullRequestStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_prmanager_v1_pr_manager_proto_enumTypes[0].Descriptor()
+}
+
+func (PullRequestStatus) Type() protoreflect.EnumType {
+	return &file_prmanager_v1_pr_manager_proto_enumTypes[0]
+}
+
+func (x PullRequestStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PullRequestStatus.Descriptor instead.
+func (PullRequestStatus) EnumDescriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{0}
+}
+
+type ReviewState int32
+
+const (
+	ReviewState_REVIEW_STATE_UNSPECIFIED       ReviewState = 0
+	ReviewState_REVIEW_STATE_PENDING           ReviewState = 1
+	ReviewState_REVIEW_STATE_APPROVED          ReviewState = 2
+	ReviewState_REVIEW_STATE_CHANGES_REQUESTED ReviewState = 3
+)
+
+// Enum value maps for ReviewState.
+var (
+	ReviewState_name = map[int32]string{
+		0: "REVIEW_STATE_UNSPECIFIED",
+		1: "REVIEW_STATE_PENDING",
+		2: "REVIEW_STATE_APPROVED",
+		3: "REVIEW_STATE_CHANGES_REQUESTED",
+	}
+	ReviewState_value = map[string]int32{
+		"REVIEW_STATE_UNSPECIFIED":       0,
+		"REVIEW_STATE_PENDING":           1,
+		"REVIEW_STATE_APPROVED":          2,
+		"REVIEW_STATE_CHANGES_REQUESTED": 3,
+	}
+)
+
+func (x ReviewState) Enum() *ReviewState {
+	p := new(ReviewState)
+	*p = x
+	return p
+}
+
+func (x ReviewState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReviewState) Descriptor() protoreflect.EnumDescriptor {
+	return file_prmanager_v1_pr_manager_proto_enumTypes[1].Descriptor()
+}
+
+func (ReviewState) Type() protoreflect.EnumType {
+	return &file_prmanager_v1_pr_manager_proto_enumTypes[1]
+}
+
+func (x ReviewState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReviewState.Descriptor instead.
+func (ReviewState) EnumDescriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{1}
+}
+
+type SortOrder int32
+
+const (
+	// По умолчанию - от новых к старым
+	SortOrder_SORT_ORDER_UNSPECIFIED SortOrder = 0
+	SortOrder_SORT_ORDER_DESC        SortOrder = 1
+	SortOrder_SORT_ORDER_ASC         SortOrder = 2
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "SORT_ORDER_UNSPECIFIED",
+		1: "SORT_ORDER_DESC",
+		2: "SORT_ORDER_ASC",
+	}
+	SortOrder_value = map[string]int32{
+		"SORT_ORDER_UNSPECIFIED": 0,
+		"SORT_ORDER_DESC":        1,
+		"SORT_ORDER_ASC":         2,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_prmanager_v1_pr_manager_proto_enumTypes[2].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_prmanager_v1_pr_manager_proto_enumTypes[2]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{2}
+}
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	TeamName      string                 `protobuf:"bytes,3,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	IsActive      bool                   `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *User) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *User) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Team struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Members       []*User                `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Team) Reset() {
+	*x = Team{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Team) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Team) ProtoMessage() {}
+
+func (x *Team) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Team.ProtoReflect.Descriptor instead.
+func (*Team) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Team) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *Team) GetMembers() []*User {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *Team) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Team) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type TeamMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	IsActive      bool                   `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamMember) Reset() {
+	*x = TeamMember{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamMember) ProtoMessage() {}
+
+func (x *TeamMember) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamMember.ProtoReflect.Descriptor instead.
+func (*TeamMember) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TeamMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TeamMember) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *TeamMember) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type PullRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId     string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName   string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId          string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status            PullRequestStatus      `protobuf:"varint,4,opt,name=status,proto3,enum=prmanager.v1.PullRequestStatus" json:"status,omitempty"`
+	AssignedReviewers []string               `protobuf:"bytes,5,rep,name=assigned_reviewers,json=assignedReviewers,proto3" json:"assigned_reviewers,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	MergedAt          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=merged_at,json=mergedAt,proto3" json:"merged_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Увеличивается при каждом изменении PR
+	Version       int32 `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullRequest) Reset() {
+	*x = PullRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequest) ProtoMessage() {}
+
+func (x *PullRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequest.ProtoReflect.Descriptor instead.
+func (*PullRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PullRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *PullRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *PullRequest) GetAssignedReviewers() []string {
+	if x != nil {
+		return x.AssignedReviewers
+	}
+	return nil
+}
+
+func (x *PullRequest) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetMergedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type Reviewer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	IsActive      bool                   `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reviewer) Reset() {
+	*x = Reviewer{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reviewer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reviewer) ProtoMessage() {}
+
+func (x *Reviewer) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reviewer.ProtoReflect.Descriptor instead.
+func (*Reviewer) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Reviewer) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Reviewer) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Reviewer) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type PullRequestDetails struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest    *PullRequest           `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	AuthorUsername string                 `protobuf:"bytes,2,opt,name=author_username,json=authorUsername,proto3" json:"author_username,omitempty"`
+	Reviewers      []*Reviewer            `protobuf:"bytes,3,rep,name=reviewers,proto3" json:"reviewers,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PullRequestDetails) Reset() {
+	*x = PullRequestDetails{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequestDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequestDetails) ProtoMessage() {}
+
+func (x *PullRequestDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequestDetails.ProtoReflect.Descriptor instead.
+func (*PullRequestDetails) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PullRequestDetails) GetPullRequest() *PullRequest {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+func (x *PullRequestDetails) GetAuthorUsername() string {
+	if x != nil {
+		return x.AuthorUsername
+	}
+	return ""
+}
+
+func (x *PullRequestDetails) GetReviewers() []*Reviewer {
+	if x != nil {
+		return x.Reviewers
+	}
+	return nil
+}
+
+type Review struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	State         ReviewState            `protobuf:"varint,3,opt,name=state,proto3,enum=prmanager.v1.ReviewState" json:"state,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Review) Reset() {
+	*x = Review{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Review) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Review) ProtoMessage() {}
+
+func (x *Review) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Review.ProtoReflect.Descriptor instead.
+func (*Review) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Review) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *Review) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Review) GetState() ReviewState {
+	if x != nil {
+		return x.State
+	}
+	return ReviewState_REVIEW_STATE_UNSPECIFIED
+}
+
+func (x *Review) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ReviewPullRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status          PullRequestStatus      `protobuf:"varint,4,opt,name=status,proto3,enum=prmanager.v1.PullRequestStatus" json:"status,omitempty"`
+	ReviewState     ReviewState            `protobuf:"varint,5,opt,name=review_state,json=reviewState,proto3,enum=prmanager.v1.ReviewState" json:"review_state,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReviewPullRequest) Reset() {
+	*x = ReviewPullRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewPullRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewPullRequest) ProtoMessage() {}
+
+func (x *ReviewPullRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewPullRequest.ProtoReflect.Descriptor instead.
+func (*ReviewPullRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReviewPullRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ReviewPullRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *ReviewPullRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ReviewPullRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *ReviewPullRequest) GetReviewState() ReviewState {
+	if x != nil {
+		return x.ReviewState
+	}
+	return ReviewState_REVIEW_STATE_UNSPECIFIED
+}
+
+type ReviewCounts struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	NeedsReview      int32                  `protobuf:"varint,1,opt,name=needs_review,json=needsReview,proto3" json:"needs_review,omitempty"`
+	Approved         int32                  `protobuf:"varint,2,opt,name=approved,proto3" json:"approved,omitempty"`
+	ChangesRequested int32                  `protobuf:"varint,3,opt,name=changes_requested,json=changesRequested,proto3" json:"changes_requested,omitempty"`
+	Total            int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ReviewCounts) Reset() {
+	*x = ReviewCounts{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewCounts) ProtoMessage() {}
+
+func (x *ReviewCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewCounts.ProtoReflect.Descriptor instead.
+func (*ReviewCounts) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReviewCounts) GetNeedsReview() int32 {
+	if x != nil {
+		return x.NeedsReview
+	}
+	return 0
+}
+
+func (x *ReviewCounts) GetApproved() int32 {
+	if x != nil {
+		return x.Approved
+	}
+	return 0
+}
+
+func (x *ReviewCounts) GetChangesRequested() int32 {
+	if x != nil {
+		return x.ChangesRequested
+	}
+	return 0
+}
+
+func (x *ReviewCounts) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type ReviewQueue struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	NeedsReview      []*ReviewPullRequest   `protobuf:"bytes,1,rep,name=needs_review,json=needsReview,proto3" json:"needs_review,omitempty"`
+	Approved         []*ReviewPullRequest   `protobuf:"bytes,2,rep,name=approved,proto3" json:"approved,omitempty"`
+	ChangesRequested []*ReviewPullRequest   `protobuf:"bytes,3,rep,name=changes_requested,json=changesRequested,proto3" json:"changes_requested,omitempty"`
+	// Счетчики по всем PR ревьювера, а не по странице
+	Counts *ReviewCounts `protobuf:"bytes,4,opt,name=counts,proto3" json:"counts,omitempty"`
+	// Пуст на последней странице
+	NextCursor    string `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewQueue) Reset() {
+	*x = ReviewQueue{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewQueue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewQueue) ProtoMessage() {}
+
+func (x *ReviewQueue) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewQueue.ProtoReflect.Descriptor instead.
+func (*ReviewQueue) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReviewQueue) GetNeedsReview() []*ReviewPullRequest {
+	if x != nil {
+		return x.NeedsReview
+	}
+	return nil
+}
+
+func (x *ReviewQueue) GetApproved() []*ReviewPullRequest {
+	if x != nil {
+		return x.Approved
+	}
+	return nil
+}
+
+func (x *ReviewQueue) GetChangesRequested() []*ReviewPullRequest {
+	if x != nil {
+		return x.ChangesRequested
+	}
+	return nil
+}
+
+func (x *ReviewQueue) GetCounts() *ReviewCounts {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+func (x *ReviewQueue) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type PageRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 0 - размер страницы по умолчанию
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_cursor предыдущей страницы
+	Cursor        string    `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Order         SortOrder `protobuf:"varint,3,opt,name=order,proto3,enum=prmanager.v1.SortOrder" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageRequest) Reset() {
+	*x = PageRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageRequest) ProtoMessage() {}
+
+func (x *PageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageRequest.ProtoReflect.Descriptor instead.
+func (*PageRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PageRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *PageRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *PageRequest) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+type CreateTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Members       []*TeamMember          `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTeamRequest) Reset() {
+	*x = CreateTeamRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTeamRequest) ProtoMessage() {}
+
+func (x *CreateTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTeamRequest.ProtoReflect.Descriptor instead.
+func (*CreateTeamRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *CreateTeamRequest) GetMembers() []*TeamMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type GetTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamRequest) Reset() {
+	*x = GetTeamRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamRequest) ProtoMessage() {}
+
+func (x *GetTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamRequest.ProtoReflect.Descriptor instead.
+func (*GetTeamRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type ListTeamsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTeamsRequest) Reset() {
+	*x = ListTeamsRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTeamsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTeamsRequest) ProtoMessage() {}
+
+func (x *ListTeamsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTeamsRequest.ProtoReflect.Descriptor instead.
+func (*ListTeamsRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{13}
+}
+
+type ListTeamsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Teams         []*Team                `protobuf:"bytes,1,rep,name=teams,proto3" json:"teams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTeamsResponse) Reset() {
+	*x = ListTeamsResponse{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTeamsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTeamsResponse) ProtoMessage() {}
+
+func (x *ListTeamsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTeamsResponse.ProtoReflect.Descriptor instead.
+func (*ListTeamsResponse) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListTeamsResponse) GetTeams() []*Team {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+type DeleteTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTeamRequest) Reset() {
+	*x = DeleteTeamRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTeamRequest) ProtoMessage() {}
+
+func (x *DeleteTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTeamRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTeamRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type DeleteTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTeamResponse) Reset() {
+	*x = DeleteTeamResponse{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTeamResponse) ProtoMessage() {}
+
+func (x *DeleteTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTeamResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTeamResponse) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{16}
+}
+
+type CreateUserRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	TeamName string                 `protobuf:"bytes,3,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	// По умолчанию пользователь активен
+	IsActive      *bool `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserRequest) Reset() {
+	*x = CreateUserRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserRequest) ProtoMessage() {}
+
+func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetIsActive() bool {
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
+	}
+	return false
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{19}
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type UpdateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      *string                `protobuf:"bytes,2,opt,name=username,proto3,oneof" json:"username,omitempty"`
+	TeamName      *string                `protobuf:"bytes,3,opt,name=team_name,json=teamName,proto3,oneof" json:"team_name,omitempty"`
+	IsActive      *bool                  `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserRequest) ProtoMessage() {}
+
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetTeamName() string {
+	if x != nil && x.TeamName != nil {
+		return *x.TeamName
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetIsActive() bool {
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
+	}
+	return false
+}
+
+type GetUserReviewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        PullRequestStatus      `protobuf:"varint,2,opt,name=status,proto3,enum=prmanager.v1.PullRequestStatus" json:"status,omitempty"`
+	Page          *PageRequest           `protobuf:"bytes,3,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserReviewsRequest) Reset() {
+	*x = GetUserReviewsRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserReviewsRequest) ProtoMessage() {}
+
+func (x *GetUserReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserReviewsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetUserReviewsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUserReviewsRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *GetUserReviewsRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type CreatePullRequestRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreatePullRequestRequest) Reset() {
+	*x = CreatePullRequestRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePullRequestRequest) ProtoMessage() {}
+
+func (x *CreatePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*CreatePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+type GetPullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPullRequestRequest) Reset() {
+	*x = GetPullRequestRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPullRequestRequest) ProtoMessage() {}
+
+func (x *GetPullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPullRequestRequest.ProtoReflect.Descriptor instead.
+func (*GetPullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetPullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+type ListPullRequestsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId   string                 `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	ReviewerId string                 `protobuf:"bytes,2,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	// Команда автора
+	TeamName      string                 `protobuf:"bytes,3,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Status        PullRequestStatus      `protobuf:"varint,4,opt,name=status,proto3,enum=prmanager.v1.PullRequestStatus" json:"status,omitempty"`
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	Page          *PageRequest           `protobuf:"bytes,7,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPullRequestsRequest) Reset() {
+	*x = ListPullRequestsRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPullRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPullRequestsRequest) ProtoMessage() {}
+
+func (x *ListPullRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPullRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListPullRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListPullRequestsRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *ListPullRequestsRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *ListPullRequestsRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListPullRequestsRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListPullRequestsRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type ListPullRequestsResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	PullRequests []*PullRequest         `protobuf:"bytes,1,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	// Пуст на последней странице
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPullRequestsResponse) Reset() {
+	*x = ListPullRequestsResponse{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPullRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPullRequestsResponse) ProtoMessage() {}
+
+func (x *ListPullRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPullRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListPullRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListPullRequestsResponse) GetPullRequests() []*PullRequest {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+func (x *ListPullRequestsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type SearchPullRequestsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Синтаксис websearch_to_tsquery
+	Query         string            `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	ReviewerId    string            `protobuf:"bytes,2,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	TeamName      string            `protobuf:"bytes,3,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Status        PullRequestStatus `protobuf:"varint,4,opt,name=status,proto3,enum=prmanager.v1.PullRequestStatus" json:"status,omitempty"`
+	Limit         int32             `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchPullRequestsRequest) Reset() {
+	*x = SearchPullRequestsRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPullRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPullRequestsRequest) ProtoMessage() {}
+
+func (x *SearchPullRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPullRequestsRequest.ProtoReflect.Descriptor instead.
+func (*SearchPullRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SearchPullRequestsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchPullRequestsRequest) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *SearchPullRequestsRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *SearchPullRequestsRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *SearchPullRequestsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchPullRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequests  []*PullRequest         `protobuf:"bytes,1,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchPullRequestsResponse) Reset() {
+	*x = SearchPullRequestsResponse{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPullRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPullRequestsResponse) ProtoMessage() {}
+
+func (x *SearchPullRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPullRequestsResponse.ProtoReflect.Descriptor instead.
+func (*SearchPullRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SearchPullRequestsResponse) GetPullRequests() []*PullRequest {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+type MergePullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	// Ожидаемая версия PR; если PR изменился, вызов завершается с ABORTED. 0 - без проверки
+	ExpectedVersion int32 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MergePullRequestRequest) Reset() {
+	*x = MergePullRequestRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergePullRequestRequest) ProtoMessage() {}
+
+func (x *MergePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*MergePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *MergePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *MergePullRequestRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type ReassignReviewerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	OldUserId     string                 `protobuf:"bytes,2,opt,name=old_user_id,json=oldUserId,proto3" json:"old_user_id,omitempty"`
+	// Ожидаемая версия PR; если PR изменился, вызов завершается с ABORTED. 0 - без проверки
+	ExpectedVersion int32 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReassignReviewerRequest) Reset() {
+	*x = ReassignReviewerRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignReviewerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignReviewerRequest) ProtoMessage() {}
+
+func (x *ReassignReviewerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignReviewerRequest.ProtoReflect.Descriptor instead.
+func (*ReassignReviewerRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ReassignReviewerRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ReassignReviewerRequest) GetOldUserId() string {
+	if x != nil {
+		return x.OldUserId
+	}
+	return ""
+}
+
+func (x *ReassignReviewerRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type ReassignReviewerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest   *PullRequest           `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	ReplacedBy    string                 `protobuf:"bytes,2,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignReviewerResponse) Reset() {
+	*x = ReassignReviewerResponse{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignReviewerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignReviewerResponse) ProtoMessage() {}
+
+func (x *ReassignReviewerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignReviewerResponse.ProtoReflect.Descriptor instead.
+func (*ReassignReviewerResponse) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ReassignReviewerResponse) GetPullRequest() *PullRequest {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+func (x *ReassignReviewerResponse) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+type SubmitReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// APPROVED или CHANGES_REQUESTED
+	State         ReviewState `protobuf:"varint,3,opt,name=state,proto3,enum=prmanager.v1.ReviewState" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitReviewRequest) Reset() {
+	*x = SubmitReviewRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitReviewRequest) ProtoMessage() {}
+
+func (x *SubmitReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitReviewRequest.ProtoReflect.Descriptor instead.
+func (*SubmitReviewRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SubmitReviewRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *SubmitReviewRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SubmitReviewRequest) GetState() ReviewState {
+	if x != nil {
+		return x.State
+	}
+	return ReviewState_REVIEW_STATE_UNSPECIFIED
+}
+
+type UserAssignmentStats struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username        string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	TeamName        string                 `protobuf:"bytes,3,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	IsActive        bool                   `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	AssignmentCount int32                  `protobuf:"varint,5,opt,name=assignment_count,json=assignmentCount,proto3" json:"assignment_count,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UserAssignmentStats) Reset() {
+	*x = UserAssignmentStats{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserAssignmentStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserAssignmentStats) ProtoMessage() {}
+
+func (x *UserAssignmentStats) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserAssignmentStats.ProtoReflect.Descriptor instead.
+func (*UserAssignmentStats) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UserAssignmentStats) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserAssignmentStats) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserAssignmentStats) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *UserAssignmentStats) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *UserAssignmentStats) GetAssignmentCount() int32 {
+	if x != nil {
+		return x.AssignmentCount
+	}
+	return 0
+}
+
+type PullRequestAssignmentStats struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	TotalPrs            int32                  `protobuf:"varint,1,opt,name=total_prs,json=totalPrs,proto3" json:"total_prs,omitempty"`
+	OpenPrs             int32                  `protobuf:"varint,2,opt,name=open_prs,json=openPrs,proto3" json:"open_prs,omitempty"`
+	MergedPrs           int32                  `protobuf:"varint,3,opt,name=merged_prs,json=mergedPrs,proto3" json:"merged_prs,omitempty"`
+	AvgReviewersPerPr   float64                `protobuf:"fixed64,4,opt,name=avg_reviewers_per_pr,json=avgReviewersPerPr,proto3" json:"avg_reviewers_per_pr,omitempty"`
+	PrsWithNoReviewers  int32                  `protobuf:"varint,5,opt,name=prs_with_no_reviewers,json=prsWithNoReviewers,proto3" json:"prs_with_no_reviewers,omitempty"`
+	PrsWithOneReviewer  int32                  `protobuf:"varint,6,opt,name=prs_with_one_reviewer,json=prsWithOneReviewer,proto3" json:"prs_with_one_reviewer,omitempty"`
+	PrsWithTwoReviewers int32                  `protobuf:"varint,7,opt,name=prs_with_two_reviewers,json=prsWithTwoReviewers,proto3" json:"prs_with_two_reviewers,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *PullRequestAssignmentStats) Reset() {
+	*x = PullRequestAssignmentStats{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequestAssignmentStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequestAssignmentStats) ProtoMessage() {}
+
+func (x *PullRequestAssignmentStats) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequestAssignmentStats.ProtoReflect.Descriptor instead.
+func (*PullRequestAssignmentStats) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PullRequestAssignmentStats) GetTotalPrs() int32 {
+	if x != nil {
+		return x.TotalPrs
+	}
+	return 0
+}
+
+func (x *PullRequestAssignmentStats) GetOpenPrs() int32 {
+	if x != nil {
+		return x.OpenPrs
+	}
+	return 0
+}
+
+func (x *PullRequestAssignmentStats) GetMergedPrs() int32 {
+	if x != nil {
+		return x.MergedPrs
+	}
+	return 0
+}
+
+func (x *PullRequestAssignmentStats) GetAvgReviewersPerPr() float64 {
+	if x != nil {
+		return x.AvgReviewersPerPr
+	}
+	return 0
+}
+
+func (x *PullRequestAssignmentStats) GetPrsWithNoReviewers() int32 {
+	if x != nil {
+		return x.PrsWithNoReviewers
+	}
+	return 0
+}
+
+func (x *PullRequestAssignmentStats) GetPrsWithOneReviewer() int32 {
+	if x != nil {
+		return x.PrsWithOneReviewer
+	}
+	return 0
+}
+
+func (x *PullRequestAssignmentStats) GetPrsWithTwoReviewers() int32 {
+	if x != nil {
+		return x.PrsWithTwoReviewers
+	}
+	return 0
+}
+
+type StatsSummary struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TotalUsers       int32                  `protobuf:"varint,1,opt,name=total_users,json=totalUsers,proto3" json:"total_users,omitempty"`
+	ActiveUsers      int32                  `protobuf:"varint,2,opt,name=active_users,json=activeUsers,proto3" json:"active_users,omitempty"`
+	TotalAssignments int32                  `protobuf:"varint,3,opt,name=total_assignments,json=totalAssignments,proto3" json:"total_assignments,omitempty"`
+	MostAssignedUser string                 `protobuf:"bytes,4,opt,name=most_assigned_user,json=mostAssignedUser,proto3" json:"most_assigned_user,omitempty"`
+	MostAssignments  int32                  `protobuf:"varint,5,opt,name=most_assignments,json=mostAssignments,proto3" json:"most_assignments,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *StatsSummary) Reset() {
+	*x = StatsSummary{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsSummary) ProtoMessage() {}
+
+func (x *StatsSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsSummary.ProtoReflect.Descriptor instead.
+func (*StatsSummary) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *StatsSummary) GetTotalUsers() int32 {
+	if x != nil {
+		return x.TotalUsers
+	}
+	return 0
+}
+
+func (x *StatsSummary) GetActiveUsers() int32 {
+	if x != nil {
+		return x.ActiveUsers
+	}
+	return 0
+}
+
+func (x *StatsSummary) GetTotalAssignments() int32 {
+	if x != nil {
+		return x.TotalAssignments
+	}
+	return 0
+}
+
+func (x *StatsSummary) GetMostAssignedUser() string {
+	if x != nil {
+		return x.MostAssignedUser
+	}
+	return ""
+}
+
+func (x *StatsSummary) GetMostAssignments() int32 {
+	if x != nil {
+		return x.MostAssignments
+	}
+	return 0
+}
+
+type GetAssignmentStatsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TeamName string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	// assignments или username
+	Sort          string       `protobuf:"bytes,2,opt,name=sort,proto3" json:"sort,omitempty"`
+	Page          *PageRequest `protobuf:"bytes,3,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAssignmentStatsRequest) Reset() {
+	*x = GetAssignmentStatsRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssignmentStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssignmentStatsRequest) ProtoMessage() {}
+
+func (x *GetAssignmentStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssignmentStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetAssignmentStatsRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetAssignmentStatsRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *GetAssignmentStatsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *GetAssignmentStatsRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type AssignmentStats struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	UserStats     []*UserAssignmentStats      `protobuf:"bytes,1,rep,name=user_stats,json=userStats,proto3" json:"user_stats,omitempty"`
+	PrStats       *PullRequestAssignmentStats `protobuf:"bytes,2,opt,name=pr_stats,json=prStats,proto3" json:"pr_stats,omitempty"`
+	Summary       *StatsSummary               `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
+	NextCursor    string                      `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignmentStats) Reset() {
+	*x = AssignmentStats{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignmentStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignmentStats) ProtoMessage() {}
+
+func (x *AssignmentStats) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignmentStats.ProtoReflect.Descriptor instead.
+func (*AssignmentStats) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *AssignmentStats) GetUserStats() []*UserAssignmentStats {
+	if x != nil {
+		return x.UserStats
+	}
+	return nil
+}
+
+func (x *AssignmentStats) GetPrStats() *PullRequestAssignmentStats {
+	if x != nil {
+		return x.PrStats
+	}
+	return nil
+}
+
+func (x *AssignmentStats) GetSummary() *StatsSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *AssignmentStats) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type GetUserStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserStatsRequest) Reset() {
+	*x = GetUserStatsRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserStatsRequest) ProtoMessage() {}
+
+func (x *GetUserStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserStatsRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetUserStatsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetFairnessReportRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Порог коэффициента Джини; не задан - значение по умолчанию
+	GiniThreshold *float64 `protobuf:"fixed64,1,opt,name=gini_threshold,json=giniThreshold,proto3,oneof" json:"gini_threshold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFairnessReportRequest) Reset() {
+	*x = GetFairnessReportRequest{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFairnessReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFairnessReportRequest) ProtoMessage() {}
+
+func (x *GetFairnessReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFairnessReportRequest.ProtoReflect.Descriptor instead.
+func (*GetFairnessReportRequest) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetFairnessReportRequest) GetGiniThreshold() float64 {
+	if x != nil && x.GiniThreshold != nil {
+		return *x.GiniThreshold
+	}
+	return 0
+}
+
+type MemberFairness struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	UserId            string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username          string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	AssignmentCount   int32                  `protobuf:"varint,3,opt,name=assignment_count,json=assignmentCount,proto3" json:"assignment_count,omitempty"`
+	DaysActive        float64                `protobuf:"fixed64,4,opt,name=days_active,json=daysActive,proto3" json:"days_active,omitempty"`
+	AssignmentsPerDay float64                `protobuf:"fixed64,5,opt,name=assignments_per_day,json=assignmentsPerDay,proto3" json:"assignments_per_day,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *MemberFairness) Reset() {
+	*x = MemberFairness{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemberFairness) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemberFairness) ProtoMessage() {}
+
+func (x *MemberFairness) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemberFairness.ProtoReflect.Descriptor instead.
+func (*MemberFairness) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *MemberFairness) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MemberFairness) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *MemberFairness) GetAssignmentCount() int32 {
+	if x != nil {
+		return x.AssignmentCount
+	}
+	return 0
+}
+
+func (x *MemberFairness) GetDaysActive() float64 {
+	if x != nil {
+		return x.DaysActive
+	}
+	return 0
+}
+
+func (x *MemberFairness) GetAssignmentsPerDay() float64 {
+	if x != nil {
+		return x.AssignmentsPerDay
+	}
+	return 0
+}
+
+type TeamFairness struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	TeamName              string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	ActiveMembers         int32                  `protobuf:"varint,2,opt,name=active_members,json=activeMembers,proto3" json:"active_members,omitempty"`
+	TotalAssignments      int32                  `protobuf:"varint,3,opt,name=total_assignments,json=totalAssignments,proto3" json:"total_assignments,omitempty"`
+	MeanAssignmentsPerDay float64                `protobuf:"fixed64,4,opt,name=mean_assignments_per_day,json=meanAssignmentsPerDay,proto3" json:"mean_assignments_per_day,omitempty"`
+	StdDev                float64                `protobuf:"fixed64,5,opt,name=std_dev,json=stdDev,proto3" json:"std_dev,omitempty"`
+	Gini                  float64                `protobuf:"fixed64,6,opt,name=gini,proto3" json:"gini,omitempty"`
+	Imbalanced            bool                   `protobuf:"varint,7,opt,name=imbalanced,proto3" json:"imbalanced,omitempty"`
+	Overloaded            []*MemberFairness      `protobuf:"bytes,8,rep,name=overloaded,proto3" json:"overloaded,omitempty"`
+	Underloaded           []*MemberFairness      `protobuf:"bytes,9,rep,name=underloaded,proto3" json:"underloaded,omitempty"`
+	Members               []*MemberFairness      `protobuf:"bytes,10,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *TeamFairness) Reset() {
+	*x = TeamFairness{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamFairness) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamFairness) ProtoMessage() {}
+
+func (x *TeamFairness) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamFairness.ProtoReflect.Descriptor instead.
+func (*TeamFairness) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *TeamFairness) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *TeamFairness) GetActiveMembers() int32 {
+	if x != nil {
+		return x.ActiveMembers
+	}
+	return 0
+}
+
+func (x *TeamFairness) GetTotalAssignments() int32 {
+	if x != nil {
+		return x.TotalAssignments
+	}
+	return 0
+}
+
+func (x *TeamFairness) GetMeanAssignmentsPerDay() float64 {
+	if x != nil {
+		return x.MeanAssignmentsPerDay
+	}
+	return 0
+}
+
+func (x *TeamFairness) GetStdDev() float64 {
+	if x != nil {
+		return x.StdDev
+	}
+	return 0
+}
+
+func (x *TeamFairness) GetGini() float64 {
+	if x != nil {
+		return x.Gini
+	}
+	return 0
+}
+
+func (x *TeamFairness) GetImbalanced() bool {
+	if x != nil {
+		return x.Imbalanced
+	}
+	return false
+}
+
+func (x *TeamFairness) GetOverloaded() []*MemberFairness {
+	if x != nil {
+		return x.Overloaded
+	}
+	return nil
+}
+
+func (x *TeamFairness) GetUnderloaded() []*MemberFairness {
+	if x != nil {
+		return x.Underloaded
+	}
+	return nil
+}
+
+func (x *TeamFairness) GetMembers() []*MemberFairness {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type FairnessReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GiniThreshold float64                `protobuf:"fixed64,1,opt,name=gini_threshold,json=giniThreshold,proto3" json:"gini_threshold,omitempty"`
+	Imbalanced    bool                   `protobuf:"varint,2,opt,name=imbalanced,proto3" json:"imbalanced,omitempty"`
+	Teams         []*TeamFairness        `protobuf:"bytes,3,rep,name=teams,proto3" json:"teams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FairnessReport) Reset() {
+	*x = FairnessReport{}
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FairnessReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FairnessReport) ProtoMessage() {}
+
+func (x *FairnessReport) ProtoReflect() protoreflect.Message {
+	mi := &file_prmanager_v1_pr_manager_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FairnessReport.ProtoReflect.Descriptor instead.
+func (*FairnessReport) Descriptor() ([]byte, []int) {
+	return file_prmanager_v1_pr_manager_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *FairnessReport) GetGiniThreshold() float64 {
+	if x != nil {
+		return x.GiniThreshold
+	}
+	return 0
+}
+
+func (x *FairnessReport) GetImbalanced() bool {
+	if x != nil {
+		return x.Imbalanced
+	}
+	return false
+}
+
+func (x *FairnessReport) GetTeams() []*TeamFairness {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+var File_prmanager_v1_pr_manager_proto protoreflect.FileDescriptor
+
+const file_prmanager_v1_pr_manager_proto_rawDesc = "" +
+	"\n" +
+	"\x1dprmanager/v1/pr_manager.proto\x12\fprmanager.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xeb\x01\n" +
+	"\x04User\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tteam_name\x18\x03 \x01(\tR\bteamName\x12\x1b\n" +
+	"\tis_active\x18\x04 \x01(\bR\bisActive\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xc7\x01\n" +
+	"\x04Team\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12,\n" +
+	"\amembers\x18\x02 \x03(\v2\x12.prmanager.v1.UserR\amembers\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"^\n" +
+	"\n" +
+	"TeamMember\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tis_active\x18\x03 \x01(\bR\bisActive\"\xaf\x03\n" +
+	"\vPullRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x127\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1f.prmanager.v1.PullRequestStatusR\x06status\x12-\n" +
+	"\x12assigned_reviewers\x18\x05 \x03(\tR\x11assignedReviewers\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x127\n" +
+	"\tmerged_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bmergedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\t \x01(\x05R\aversion\"\\\n" +
+	"\bReviewer\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tis_active\x18\x03 \x01(\bR\bisActive\"\xb1\x01\n" +
+	"\x12PullRequestDetails\x12<\n" +
+	"\fpull_request\x18\x01 \x01(\v2\x19.prmanager.v1.PullRequestR\vpullRequest\x12'\n" +
+	"\x0fauthor_username\x18\x02 \x01(\tR\x0eauthorUsername\x124\n" +
+	"\treviewers\x18\x03 \x03(\v2\x16.prmanager.v1.ReviewerR\treviewers\"\xb5\x01\n" +
+	"\x06Review\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12/\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x19.prmanager.v1.ReviewStateR\x05state\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xfb\x01\n" +
+	"\x11ReviewPullRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x127\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1f.prmanager.v1.PullRequestStatusR\x06status\x12<\n" +
+	"\freview_state\x18\x05 \x01(\x0e2\x19.prmanager.v1.ReviewStateR\vreviewState\"\x90\x01\n" +
+	"\fReviewCounts\x12!\n" +
+	"\fneeds_review\x18\x01 \x01(\x05R\vneedsReview\x12\x1a\n" +
+	"\bapproved\x18\x02 \x01(\x05R\bapproved\x12+\n" +
+	"\x11changes_requested\x18\x03 \x01(\x05R\x10changesRequested\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\"\xb1\x02\n" +
+	"\vReviewQueue\x12B\n" +
+	"\fneeds_review\x18\x01 \x03(\v2\x1f.prmanager.v1.ReviewPullRequestR\vneedsReview\x12;\n" +
+	"\bapproved\x18\x02 \x03(\v2\x1f.prmanager.v1.ReviewPullRequestR\bapproved\x12L\n" +
+	"\x11changes_requested\x18\x03 \x03(\v2\x1f.prmanager.v1.ReviewPullRequestR\x10changesRequested\x122\n" +
+	"\x06counts\x18\x04 \x01(\v2\x1a.prmanager.v1.ReviewCountsR\x06counts\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\"j\n" +
+	"\vPageRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12-\n" +
+	"\x05order\x18\x03 \x01(\x0e2\x17.prmanager.v1.SortOrderR\x05order\"d\n" +
+	"\x11CreateTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x122\n" +
+	"\amembers\x18\x02 \x03(\v2\x18.prmanager.v1.TeamMemberR\amembers\"-\n" +
+	"\x0eGetTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"\x12\n" +
+	"\x10ListTeamsRequest\"=\n" +
+	"\x11ListTeamsResponse\x12(\n" +
+	"\x05teams\x18\x01 \x03(\v2\x12.prmanager.v1.TeamR\x05teams\"0\n" +
+	"\x11DeleteTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"\x14\n" +
+	"\x12DeleteTeamResponse\"\x95\x01\n" +
+	"\x11CreateUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tteam_name\x18\x03 \x01(\tR\bteamName\x12 \n" +
+	"\tis_active\x18\x04 \x01(\bH\x00R\bisActive\x88\x01\x01B\f\n" +
+	"\n" +
+	"_is_active\")\n" +
+	"\x0eGetUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x12\n" +
+	"\x10ListUsersRequest\"=\n" +
+	"\x11ListUsersResponse\x12(\n" +
+	"\x05users\x18\x01 \x03(\v2\x12.prmanager.v1.UserR\x05users\"\xba\x01\n" +
+	"\x11UpdateUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\busername\x18\x02 \x01(\tH\x00R\busername\x88\x01\x01\x12 \n" +
+	"\tteam_name\x18\x03 \x01(\tH\x01R\bteamName\x88\x01\x01\x12 \n" +
+	"\tis_active\x18\x04 \x01(\bH\x02R\bisActive\x88\x01\x01B\v\n" +
+	"\t_usernameB\f\n" +
+	"\n" +
+	"_team_nameB\f\n" +
+	"\n" +
+	"_is_active\"\x98\x01\n" +
+	"\x15GetUserReviewsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x127\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1f.prmanager.v1.PullRequestStatusR\x06status\x12-\n" +
+	"\x04page\x18\x03 \x01(\v2\x19.prmanager.v1.PageRequestR\x04page\"\x8b\x01\n" +
+	"\x18CreatePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\"?\n" +
+	"\x15GetPullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\"\xd6\x02\n" +
+	"\x17ListPullRequestsRequest\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\tR\bauthorId\x12\x1f\n" +
+	"\vreviewer_id\x18\x02 \x01(\tR\n" +
+	"reviewerId\x12\x1b\n" +
+	"\tteam_name\x18\x03 \x01(\tR\bteamName\x127\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1f.prmanager.v1.PullRequestStatusR\x06status\x12=\n" +
+	"\fcreated_from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12-\n" +
+	"\x04page\x18\a \x01(\v2\x19.prmanager.v1.PageRequestR\x04page\"{\n" +
+	"\x18ListPullRequestsResponse\x12>\n" +
+	"\rpull_requests\x18\x01 \x03(\v2\x19.prmanager.v1.PullRequestR\fpullRequests\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xbe\x01\n" +
+	"\x19SearchPullRequestsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1f\n" +
+	"\vreviewer_id\x18\x02 \x01(\tR\n" +
+	"reviewerId\x12\x1b\n" +
+	"\tteam_name\x18\x03 \x01(\tR\bteamName\x127\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1f.prmanager.v1.PullRequestStatusR\x06status\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"\\\n" +
+	"\x1aSearchPullRequestsResponse\x12>\n" +
+	"\rpull_requests\x18\x01 \x03(\v2\x19.prmanager.v1.PullRequestR\fpullRequests\"l\n" +
+	"\x17MergePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x05R\x0fexpectedVersion\"\x8c\x01\n" +
+	"\x17ReassignReviewerRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x1e\n" +
+	"\vold_user_id\x18\x02 \x01(\tR\toldUserId\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\x05R\x0fexpectedVersion\"y\n" +
+	"\x18ReassignReviewerResponse\x12<\n" +
+	"\fpull_request\x18\x01 \x01(\v2\x19.prmanager.v1.PullRequestR\vpullRequest\x12\x1f\n" +
+	"\vreplaced_by\x18\x02 \x01(\tR\n" +
+	"replacedBy\"\x87\x01\n" +
+	"\x13SubmitReviewRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12/\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x19.prmanager.v1.ReviewStateR\x05state\"\xaf\x01\n" +
+	"\x13UserAssignmentStats\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tteam_name\x18\x03 \x01(\tR\bteamName\x12\x1b\n" +
+	"\tis_active\x18\x04 \x01(\bR\bisActive\x12)\n" +
+	"\x10assignment_count\x18\x05 \x01(\x05R\x0fassignmentCount\"\xbf\x02\n" +
+	"\x1aPullRequestAssignmentStats\x12\x1b\n" +
+	"\ttotal_prs\x18\x01 \x01(\x05R\btotalPrs\x12\x19\n" +
+	"\bopen_prs\x18\x02 \x01(\x05R\aopenPrs\x12\x1d\n" +
+	"\n" +
+	"merged_prs\x18\x03 \x01(\x05R\tmergedPrs\x12/\n" +
+	"\x14avg_reviewers_per_pr\x18\x04 \x01(\x01R\x11avgReviewersPerPr\x121\n" +
+	"\x15prs_with_no_reviewers\x18\x05 \x01(\x05R\x12prsWithNoReviewers\x121\n" +
+	"\x15prs_with_one_reviewer\x18\x06 \x01(\x05R\x12prsWithOneReviewer\x123\n" +
+	"\x16prs_with_two_reviewers\x18\a \x01(\x05R\x13prsWithTwoReviewers\"\xd8\x01\n" +
+	"\fStatsSummary\x12\x1f\n" +
+	"\vtotal_users\x18\x01 \x01(\x05R\n" +
+	"totalUsers\x12!\n" +
+	"\factive_users\x18\x02 \x01(\x05R\vactiveUsers\x12+\n" +
+	"\x11total_assignments\x18\x03 \x01(\x05R\x10totalAssignments\x12,\n" +
+	"\x12most_assigned_user\x18\x04 \x01(\tR\x10mostAssignedUser\x12)\n" +
+	"\x10most_assignments\x18\x05 \x01(\x05R\x0fmostAssignments\"{\n" +
+	"\x19GetAssignmentStatsRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x12\n" +
+	"\x04sort\x18\x02 \x01(\tR\x04sort\x12-\n" +
+	"\x04page\x18\x03 \x01(\v2\x19.prmanager.v1.PageRequestR\x04page\"\xef\x01\n" +
+	"\x0fAssignmentStats\x12@\n" +
+	"\n" +
+	"user_stats\x18\x01 \x03(\v2!.prmanager.v1.UserAssignmentStatsR\tuserStats\x12C\n" +
+	"\bpr_stats\x18\x02 \x01(\v2(.prmanager.v1.PullRequestAssignmentStatsR\aprStats\x124\n" +
+	"\asummary\x18\x03 \x01(\v2\x1a.prmanager.v1.StatsSummaryR\asummary\x12\x1f\n" +
+	"\vnext_cursor\x18\x04 \x01(\tR\n" +
+	"nextCursor\".\n" +
+	"\x13GetUserStatsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"Y\n" +
+	"\x18GetFairnessReportRequest\x12*\n" +
+	"\x0egini_threshold\x18\x01 \x01(\x01H\x00R\rginiThreshold\x88\x01\x01B\x11\n" +
+	"\x0f_gini_threshold\"\xc1\x01\n" +
+	"\x0eMemberFairness\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12)\n" +
+	"\x10assignment_count\x18\x03 \x01(\x05R\x0fassignmentCount\x12\x1f\n" +
+	"\vdays_active\x18\x04 \x01(\x01R\n" +
+	"daysActive\x12.\n" +
+	"\x13assignments_per_day\x18\x05 \x01(\x01R\x11assignmentsPerDay\"\xbb\x03\n" +
+	"\fTeamFairness\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12%\n" +
+	"\x0eactive_members\x18\x02 \x01(\x05R\ractiveMembers\x12+\n" +
+	"\x11total_assignments\x18\x03 \x01(\x05R\x10totalAssignments\x127\n" +
+	"\x18mean_assignments_per_day\x18\x04 \x01(\x01R\x15meanAssignmentsPerDay\x12\x17\n" +
+	"\astd_dev\x18\x05 \x01(\x01R\x06stdDev\x12\x12\n" +
+	"\x04gini\x18\x06 \x01(\x01R\x04gini\x12\x1e\n" +
+	"\n" +
+	"imbalanced\x18\a \x01(\bR\n" +
+	"imbalanced\x12<\n" +
+	"\n" +
+	"overloaded\x18\b \x03(\v2\x1c.prmanager.v1.MemberFairnessR\n" +
+	"overloaded\x12>\n" +
+	"\vunderloaded\x18\t \x03(\v2\x1c.prmanager.v1.MemberFairnessR\vunderloaded\x126\n" +
+	"\amembers\x18\n" +
+	" \x03(\v2\x1c.prmanager.v1.MemberFairnessR\amembers\"\x89\x01\n" +
+	"\x0eFairnessReport\x12%\n" +
+	"\x0egini_threshold\x18\x01 \x01(\x01R\rginiThreshold\x12\x1e\n" +
+	"\n" +
+	"imbalanced\x18\x02 \x01(\bR\n" +
+	"imbalanced\x120\n" +
+	"\x05teams\x18\x03 \x03(\v2\x1a.prmanager.v1.TeamFairnessR\x05teams*v\n" +
+	"\x11PullRequestStatus\x12#\n" +
+	"\x1fPULL_REQUEST_STATUS_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18PULL_REQUEST_STATUS_OPEN\x10\x01\x12\x1e\n" +
+	"\x1aPULL_REQUEST_STATUS_MERGED\x10\x02*\x84\x01\n" +
+	"\vReviewState\x12\x1c\n" +
+	"\x18REVIEW_STATE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14REVIEW_STATE_PENDING\x10\x01\x12\x19\n" +
+	"\x15REVIEW_STATE_APPROVED\x10\x02\x12\"\n" +
+	"\x1eREVIEW_STATE_CHANGES_REQUESTED\x10\x03*P\n" +
+	"\tSortOrder\x12\x1a\n" +
+	"\x16SORT_ORDER_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fSORT_ORDER_DESC\x10\x01\x12\x12\n" +
+	"\x0eSORT_ORDER_ASC\x10\x022\xac\x02\n" +
+	"\vTeamService\x12A\n" +
+	"\n" +
+	"CreateTeam\x12\x1f.prmanager.v1.CreateTeamRequest\x1a\x12.prmanager.v1.Team\x12;\n" +
+	"\aGetTeam\x12\x1c.prmanager.v1.GetTeamRequest\x1a\x12.prmanager.v1.Team\x12L\n" +
+	"\tListTeams\x12\x1e.prmanager.v1.ListTeamsRequest\x1a\x1f.prmanager.v1.ListTeamsResponse\x12O\n" +
+	"\n" +
+	"DeleteTeam\x12\x1f.prmanager.v1.DeleteTeamRequest\x1a .prmanager.v1.DeleteTeamResponse2\xf0\x02\n" +
+	"\vUserService\x12A\n" +
+	"\n" +
+	"CreateUser\x12\x1f.prmanager.v1.CreateUserRequest\x1a\x12.prmanager.v1.User\x12;\n" +
+	"\aGetUser\x12\x1c.prmanager.v1.GetUserRequest\x1a\x12.prmanager.v1.User\x12L\n" +
+	"\tListUsers\x12\x1e.prmanager.v1.ListUsersRequest\x1a\x1f.prmanager.v1.ListUsersResponse\x12A\n" +
+	"\n" +
+	"UpdateUser\x12\x1f.prmanager.v1.UpdateUserRequest\x1a\x12.prmanager.v1.User\x12P\n" +
+	"\x0eGetUserReviews\x12#.prmanager.v1.GetUserReviewsRequest\x1a\x19.prmanager.v1.ReviewQueue2\x93\x05\n" +
+	"\x12PullRequestService\x12V\n" +
+	"\x11CreatePullRequest\x12&.prmanager.v1.CreatePullRequestRequest\x1a\x19.prmanager.v1.PullRequest\x12W\n" +
+	"\x0eGetPullRequest\x12#.prmanager.v1.GetPullRequestRequest\x1a .prmanager.v1.PullRequestDetails\x12a\n" +
+	"\x10ListPullRequests\x12%.prmanager.v1.ListPullRequestsRequest\x1a&.prmanager.v1.ListPullRequestsResponse\x12g\n" +
+	"\x12SearchPullRequests\x12'.prmanager.v1.SearchPullRequestsRequest\x1a(.prmanager.v1.SearchPullRequestsResponse\x12T\n" +
+	"\x10MergePullRequest\x12%.prmanager.v1.MergePullRequestRequest\x1a\x19.prmanager.v1.PullRequest\x12a\n" +
+	"\x10ReassignReviewer\x12%.prmanager.v1.ReassignReviewerRequest\x1a&.prmanager.v1.ReassignReviewerResponse\x12G\n" +
+	"\fSubmitReview\x12!.prmanager.v1.SubmitReviewRequest\x1a\x14.prmanager.v1.Review2\x9d\x02\n" +
+	"\fStatsService\x12\\\n" +
+	"\x12GetAssignmentStats\x12'.prmanager.v1.GetAssignmentStatsRequest\x1a\x1d.prmanager.v1.AssignmentStats\x12T\n" +
+	"\fGetUserStats\x12!.prmanager.v1.GetUserStatsRequest\x1a!.prmanager.v1.UserAssignmentStats\x12Y\n" +
+	"\x11GetFairnessReport\x12&.prmanager.v1.GetFairnessReportRequest\x1a\x1c.prmanager.v1.FairnessReportB;Z9github.com/vnchk1/pr-manager/api/prmanager/v1;prmanagerv1b\x06proto3"
+
+var (
+	file_prmanager_v1_pr_manager_proto_rawDescOnce sync.Once
+	file_prmanager_v1_pr_manager_proto_rawDescData []byte
+)
+
+func file_prmanager_v1_pr_manager_proto_rawDescGZIP() []byte {
+	file_prmanager_v1_pr_manager_proto_rawDescOnce.Do(func() {
+		file_prmanager_v1_pr_manager_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_prmanager_v1_pr_manager_proto_rawDesc), len(file_prmanager_v1_pr_manager_proto_rawDesc)))
+	})
+	return file_prmanager_v1_pr_manager_proto_rawDescData
+}
+
+var file_prmanager_v1_pr_manager_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_prmanager_v1_pr_manager_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
+var file_prmanager_v1_pr_manager_proto_goTypes = []any{
+	(PullRequestStatus)(0),             // 0: prmanager.v1.PullRequestStatus
+	(ReviewState)(0),                   // 1: prmanager.v1.ReviewState
+	(SortOrder)(0),                     // 2: prmanager.v1.SortOrder
+	(*User)(nil),                       // 3: prmanager.v1.User
+	(*Team)(nil),                       // 4: prmanager.v1.Team
+	(*TeamMember)(nil),                 // 5: prmanager.v1.TeamMember
+	(*PullRequest)(nil),                // 6: prmanager.v1.PullRequest
+	(*Reviewer)(nil),                   // 7: prmanager.v1.Reviewer
+	(*PullRequestDetails)(nil),         // 8: prmanager.v1.PullRequestDetails
+	(*Review)(nil),                     // 9: prmanager.v1.Review
+	(*ReviewPullRequest)(nil),          // 10: prmanager.v1.ReviewPullRequest
+	(*ReviewCounts)(nil),               // 11: prmanager.v1.ReviewCounts
+	(*ReviewQueue)(nil),                // 12: prmanager.v1.ReviewQueue
+	(*PageRequest)(nil),                // 13: prmanager.v1.PageRequest
+	(*CreateTeamRequest)(nil),          // 14: prmanager.v1.CreateTeamRequest
+	(*GetTeamRequest)(nil),             // 15: prmanager.v1.GetTeamRequest
+	(*ListTeamsRequest)(nil),           // 16: prmanager.v1.ListTeamsRequest
+	(*ListTeamsResponse)(nil),          // 17: prmanager.v1.ListTeamsResponse
+	(*DeleteTeamRequest)(nil),          // 18: prmanager.v1.DeleteTeamRequest
+	(*DeleteTeamResponse)(nil),         // 19: prmanager.v1.DeleteTeamResponse
+	(*CreateUserRequest)(nil),          // 20: prmanager.v1.CreateUserRequest
+	(*GetUserRequest)(nil),             // 21: prmanager.v1.GetUserRequest
+	(*ListUsersRequest)(nil),           // 22: prmanager.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),          // 23: prmanager.v1.ListUsersResponse
+	(*UpdateUserRequest)(nil),          // 24: prmanager.v1.UpdateUserRequest
+	(*GetUserReviewsRequest)(nil),      // 25: prmanager.v1.GetUserReviewsRequest
+	(*CreatePullRequestRequest)(nil),   // 26: prmanager.v1.CreatePullRequestRequest
+	(*GetPullRequestRequest)(nil),      // 27: prmanager.v1.GetPullRequestRequest
+	(*ListPullRequestsRequest)(nil),    // 28: prmanager.v1.ListPullRequestsRequest
+	(*ListPullRequestsResponse)(nil),   // 29: prmanager.v1.ListPullRequestsResponse
+	(*SearchPullRequestsRequest)(nil),  // 30: prmanager.v1.SearchPullRequestsRequest
+	(*SearchPullRequestsResponse)(nil), // 31: prmanager.v1.SearchPullRequestsResponse
+	(*MergePullRequestRequest)(nil),    // 32: prmanager.v1.MergePullRequestRequest
+	(*ReassignReviewerRequest)(nil),    // 33: prmanager.v1.ReassignReviewerRequest
+	(*ReassignReviewerResponse)(nil),   // 34: prmanager.v1.ReassignReviewerResponse
+	(*SubmitReviewRequest)(nil),        // 35: prmanager.v1.SubmitReviewRequest
+	(*UserAssignmentStats)(nil),        // 36: prmanager.v1.UserAssignmentStats
+	(*PullRequestAssignmentStats)(nil), // 37: prmanager.v1.PullRequestAssignmentStats
+	(*StatsSummary)(nil),               // 38: prmanager.v1.StatsSummary
+	(*GetAssignmentStatsRequest)(nil),  // 39: prmanager.v1.GetAssignmentStatsRequest
+	(*AssignmentStats)(nil),            // 40: prmanager.v1.AssignmentStats
+	(*GetUserStatsRequest)(nil),        // 41: prmanager.v1.GetUserStatsRequest
+	(*GetFairnessReportRequest)(nil),   // 42: prmanager.v1.GetFairnessReportRequest
+	(*MemberFairness)(nil),             // 43: prmanager.v1.MemberFairness
+	(*TeamFairness)(nil),               // 44: prmanager.v1.TeamFairness
+	(*FairnessReport)(nil),             // 45: prmanager.v1.FairnessReport
+	(*timestamppb.Timestamp)(nil),      // 46: google.protobuf.Timestamp
+}
+var file_prmanager_v1_pr_manager_proto_depIdxs = []int32{
+	46, // 0: prmanager.v1.User.created_at:type_name -> google.protobuf.Timestamp
+	46, // 1: prmanager.v1.User.updated_at:type_name -> google.protobuf.Timestamp
+	3,  // 2: prmanager.v1.Team.members:type_name -> prmanager.v1.User
+	46, // 3: prmanager.v1.Team.created_at:type_name -> google.protobuf.Timestamp
+	46, // 4: prmanager.v1.Team.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: prmanager.v1.PullRequest.status:type_name -> prmanager.v1.PullRequestStatus
+	46, // 6: prmanager.v1.PullRequest.created_at:type_name -> google.protobuf.Timestamp
+	46, // 7: prmanager.v1.PullRequest.merged_at:type_name -> google.protobuf.Timestamp
+	46, // 8: prmanager.v1.PullRequest.updated_at:type_name -> google.protobuf.Timestamp
+	6,  // 9: prmanager.v1.PullRequestDetails.pull_request:type_name -> prmanager.v1.PullRequest
+	7,  // 10: prmanager.v1.PullRequestDetails.reviewers:type_name -> prmanager.v1.Reviewer
+	1,  // 11: prmanager.v1.Review.state:type_name -> prmanager.v1.ReviewState
+	46, // 12: prmanager.v1.Review.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 13: prmanager.v1.ReviewPullRequest.status:type_name -> prmanager.v1.PullRequestStatus
+	1,  // 14: prmanager.v1.ReviewPullRequest.review_state:type_name -> prmanager.v1.ReviewState
+	10, // 15: prmanager.v1.ReviewQueue.needs_review:type_name -> prmanager.v1.ReviewPullRequest
+	10, // 16: prmanager.v1.ReviewQueue.approved:type_name -> prmanager.v1.ReviewPullRequest
+	10, // 17: prmanager.v1.ReviewQueue.changes_requested:type_name -> prmanager.v1.ReviewPullRequest
+	11, // 18: prmanager.v1.ReviewQueue.counts:type_name -> prmanager.v1.ReviewCounts
+	2,  // 19: prmanager.v1.PageRequest.order:type_name -> prmanager.v1.SortOrder
+	5,  // 20: prmanager.v1.CreateTeamRequest.members:type_name -> prmanager.v1.TeamMember
+	4,  // 21: prmanager.v1.ListTeamsResponse.teams:type_name -> prmanager.v1.Team
+	3,  // 22: prmanager.v1.ListUsersResponse.users:type_name -> prmanager.v1.User
+	0,  // 23: prmanager.v1.GetUserReviewsRequest.status:type_name -> prmanager.v1.PullRequestStatus
+	13, // 24: prmanager.v1.GetUserReviewsRequest.page:type_name -> prmanager.v1.PageRequest
+	0,  // 25: prmanager.v1.ListPullRequestsRequest.status:type_name -> prmanager.v1.PullRequestStatus
+	46, // 26: prmanager.v1.ListPullRequestsRequest.created_from:type_name -> google.protobuf.Timestamp
+	46, // 27: prmanager.v1.ListPullRequestsRequest.created_to:type_name -> google.protobuf.Timestamp
+	13, // 28: prmanager.v1.ListPullRequestsRequest.page:type_name -> prmanager.v1.PageRequest
+	6,  // 29: prmanager.v1.ListPullRequestsResponse.pull_requests:type_name -> prmanager.v1.PullRequest
+	0,  // 30: prmanager.v1.SearchPullRequestsRequest.status:type_name -> prmanager.v1.PullRequestStatus
+	6,  // 31: prmanager.v1.SearchPullRequestsResponse.pull_requests:type_name -> prmanager.v1.PullRequest
+	6,  // 32: prmanager.v1.ReassignReviewerResponse.pull_request:type_name -> prmanager.v1.PullRequest
+	1,  // 33: prmanager.v1.SubmitReviewRequest.state:type_name -> prmanager.v1.ReviewState
+	13, // 34: prmanager.v1.GetAssignmentStatsRequest.page:type_name -> prmanager.v1.PageRequest
+	36, // 35: prmanager.v1.AssignmentStats.user_stats:type_name -> prmanager.v1.UserAssignmentStats
+	37, // 36: prmanager.v1.AssignmentStats.pr_stats:type_name -> prmanager.v1.PullRequestAssignmentStats
+	38, // 37: prmanager.v1.AssignmentStats.summary:type_name -> prmanager.v1.StatsSummary
+	43, // 38: prmanager.v1.TeamFairness.overloaded:type_name -> prmanager.v1.MemberFairness
+	43, // 39: prmanager.v1.TeamFairness.underloaded:type_name -> prmanager.v1.MemberFairness
+	43, // 40: prmanager.v1.TeamFairness.members:type_name -> prmanager.v1.MemberFairness
+	44, // 41: prmanager.v1.FairnessReport.teams:type_name -> prmanager.v1.TeamFairness
+	14, // 42: prmanager.v1.TeamService.CreateTeam:input_type -> prmanager.v1.CreateTeamRequest
+	15, // 43: prmanager.v1.TeamService.GetTeam:input_type -> prmanager.v1.GetTeamRequest
+	16, // 44: prmanager.v1.TeamService.ListTeams:input_type -> prmanager.v1.ListTeamsRequest
+	18, // 45: prmanager.v1.TeamService.DeleteTeam:input_type -> prmanager.v1.DeleteTeamRequest
+	20, // 46: prmanager.v1.UserService.CreateUser:input_type -> prmanager.v1.CreateUserRequest
+	21, // 47: prmanager.v1.UserService.GetUser:input_type -> prmanager.v1.GetUserRequest
+	22, // 48: prmanager.v1.UserService.ListUsers:input_type -> prmanager.v1.ListUsersRequest
+	24, // 49: prmanager.v1.UserService.UpdateUser:input_type -> prmanager.v1.UpdateUserRequest
+	25, // 50: prmanager.v1.UserService.GetUserReviews:input_type -> prmanager.v1.GetUserReviewsRequest
+	26, // 51: prmanager.v1.PullRequestService.CreatePullRequest:input_type -> prmanager.v1.CreatePullRequestRequest
+	27, // 52: prmanager.v1.PullRequestService.GetPullRequest:input_type -> prmanager.v1.GetPullRequestRequest
+	28, // 53: prmanager.v1.PullRequestService.ListPullRequests:input_type -> prmanager.v1.ListPullRequestsRequest
+	30, // 54: prmanager.v1.PullRequestService.SearchPullRequests:input_type -> prmanager.v1.SearchPullRequestsRequest
+	32, // 55: prmanager.v1.PullRequestService.MergePullRequest:input_type -> prmanager.v1.MergePullRequestRequest
+	33, // 56: prmanager.v1.PullRequestService.ReassignReviewer:input_type -> prmanager.v1.ReassignReviewerRequest
+	35, // 57: prmanager.v1.PullRequestService.SubmitReview:input_type -> prmanager.v1.SubmitReviewRequest
+	39, // 58: prmanager.v1.StatsService.GetAssignmentStats:input_type -> prmanager.v1.GetAssignmentStatsRequest
+	41, // 59: prmanager.v1.StatsService.GetUserStats:input_type -> prmanager.v1.GetUserStatsRequest
+	42, // 60: prmanager.v1.StatsService.GetFairnessReport:input_type -> prmanager.v1.GetFairnessReportRequest
+	4,  // 61: prmanager.v1.TeamService.CreateTeam:output_type -> prmanager.v1.Team
+	4,  // 62: prmanager.v1.TeamService.GetTeam:output_type -> prmanager.v1.Team
+	17, // 63: prmanager.v1.TeamService.ListTeams:output_type -> prmanager.v1.ListTeamsResponse
+	19, // 64: prmanager.v1.TeamService.DeleteTeam:output_type -> prmanager.v1.DeleteTeamResponse
+	3,  // 65: prmanager.v1.UserService.CreateUser:output_type -> prmanager.v1.User
+	3,  // 66: prmanager.v1.UserService.GetUser:output_type -> prmanager.v1.User
+	23, // 67: prmanager.v1.UserService.ListUsers:output_type -> prmanager.v1.ListUsersResponse
+	3,  // 68: prmanager.v1.UserService.UpdateUser:output_type -> prmanager.v1.User
+	12, // 69: prmanager.v1.UserService.GetUserReviews:output_type -> prmanager.v1.ReviewQueue
+	6,  // 70: prmanager.v1.PullRequestService.CreatePullRequest:output_type -> prmanager.v1.PullRequest
+	8,  // 71: prmanager.v1.PullRequestService.GetPullRequest:output_type -> prmanager.v1.PullRequestDetails
+	29, // 72: prmanager.v1.PullRequestService.ListPullRequests:output_type -> prmanager.v1.ListPullRequestsResponse
+	31, // 73: prmanager.v1.PullRequestService.SearchPullRequests:output_type -> prmanager.v1.SearchPullRequestsResponse
+	6,  // 74: prmanager.v1.PullRequestService.MergePullRequest:output_type -> prmanager.v1.PullRequest
+	34, // 75: prmanager.v1.PullRequestService.ReassignReviewer:output_type -> prmanager.v1.ReassignReviewerResponse
+	9,  // 76: prmanager.v1.PullRequestService.SubmitReview:output_type -> prmanager.v1.Review
+	40, // 77: prmanager.v1.StatsService.GetAssignmentStats:output_type -> prmanager.v1.AssignmentStats
+	36, // 78: prmanager.v1.StatsService.GetUserStats:output_type -> prmanager.v1.UserAssignmentStats
+	45, // 79: prmanager.v1.StatsService.GetFairnessReport:output_type -> prmanager.v1.FairnessReport
+	61, // [61:80] is the sub-list for method output_type
+	42, // [42:61] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
+}
+
+func init() { file_prmanager_v1_pr_manager_proto_init() }
+func file_prmanager_v1_pr_manager_proto_init() {
+	if File_prmanager_v1_pr_manager_proto != nil {
+		return
+	}
+	file_prmanager_v1_pr_manager_proto_msgTypes[17].OneofWrappers = []any{}
+	file_prmanager_v1_pr_manager_proto_msgTypes[21].OneofWrappers = []any{}
+	file_prmanager_v1_pr_manager_proto_msgTypes[39].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_prmanager_v1_pr_manager_proto_rawDesc), len(file_prmanager_v1_pr_manager_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   43,
+			NumExtensions: 0,
+			NumServices:   4,
+		},
+		GoTypes:           file_prmanager_v1_pr_manager_proto_goTypes,
+		DependencyIndexes: file_prmanager_v1_pr_manager_proto_depIdxs,
+		EnumInfos:         file_prmanager_v1_pr_manager_proto_enumTypes,
+		MessageInfos:      file_prmanager_v1_pr_manager_proto_msgTypes,
+	}.Build()
+	File_prmanager_v1_pr_manager_proto = out.File
+	file_prmanager_v1_pr_manager_proto_goTypes = nil
+	file_prmanager_v1_pr_manager_proto_depIdxs = nil
+}
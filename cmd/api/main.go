@@ -1,13 +1,28 @@
 package main
 
 import (
+	"context"
 	"github.com/vnchk1/pr-manager/internal/config"
 	"github.com/vnchk1/pr-manager/internal/db"
+	"github.com/vnchk1/pr-manager/internal/events"
+	"github.com/vnchk1/pr-manager/internal/grpcserver"
+	"github.com/vnchk1/pr-manager/internal/health"
+	"github.com/vnchk1/pr-manager/internal/idempotency"
+	"github.com/vnchk1/pr-manager/internal/ldapsync"
 	logpkg "github.com/vnchk1/pr-manager/internal/logger"
+	"github.com/vnchk1/pr-manager/internal/metrics"
 	"github.com/vnchk1/pr-manager/internal/migration"
+	"github.com/vnchk1/pr-manager/internal/notify"
 	"github.com/vnchk1/pr-manager/internal/server"
 	"github.com/vnchk1/pr-manager/internal/service"
+	"github.com/vnchk1/pr-manager/internal/tracing"
 	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+	// часовые пояса из настроек уведомлений; в образе alpine нет tzdata
+	_ "time/tzdata"
 )
 
 func main() {
@@ -16,7 +31,27 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err = runMigrate(cfg.Database, os.Args[2:]); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
 	logger := logpkg.NewLogger(cfg.LogLevel)
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatalf("Failed to init tracing: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Warn("failed to flush traces", "error", err)
+		}
+	}()
 
 	postgres, err := db.NewPostgresDB(cfg.Database)
 	if err != nil {
@@ -25,16 +60,100 @@ func main() {
 	logger.Debug("Connected to database")
 	defer postgres.Close()
 
-	if err = migration.RunMigrations(cfg.Database); err != nil {
-		log.Fatalf("Failed to run migrations: %v", err)
+	if cfg.AutoMigrate {
+		if err = migration.RunMigrations(cfg.Database); err != nil {
+			log.Fatalf("Failed to run migrations: %v", err)
+		}
+		logger.Debug("Migrations run")
 	}
-	logger.Debug("Migrations run")
 
-	services := service.New(postgres.Repo)
+	eventBus := events.NewBus(postgres.Repo.Event)
+	services := service.New(postgres.Repo, eventBus)
 	logger.Debug("Services initialized successfully")
 
-	srv := server.NewServer(cfg.AppPort, services, logger)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go eventBus.Run(ctx, cfg.EventRetention, cfg.EventCleanupInterval, logger)
+
+	appMetrics := metrics.New()
+	if err = appMetrics.Register(metrics.NewPoolCollector(postgres.Pool)); err != nil {
+		log.Fatalf("Failed to register pool metrics: %v", err)
+	}
+	go appMetrics.RunRefresher(ctx, postgres.Repo.Stats, cfg.Metrics.RefreshInterval, logger)
+
+	if cfg.LDAP.Enabled() {
+		go ldapsync.New(cfg.LDAP, services.Roster, logger).Run(ctx)
+	}
+
+	var sinks []notify.Sink
+	if cfg.Notify.UserWebhooks {
+		sinks = append(sinks, notify.NewWebhook(notify.NewWebhookClient(cfg.Notify.Timeout)))
+	} else {
+		logger.Info("personal webhooks disabled: NOTIFY_USER_WEBHOOKS is not set")
+	}
+	if cfg.Notify.SlackConfigPath != "" {
+		slackCfg, err := notify.LoadSlackConfig(cfg.Notify.SlackConfigPath)
+		if err != nil {
+			log.Fatalf("Failed to load Slack config: %v", err)
+		}
+		slack, err := notify.NewSlack(slackCfg, &http.Client{Timeout: cfg.Notify.Timeout})
+		if err != nil {
+			log.Fatalf("Invalid Slack config: %v", err)
+		}
+		sinks = append(sinks, slack)
+	}
+	if cfg.Notify.SMTP.Enabled() {
+		email, err := notify.NewEmail(cfg.Notify.SMTP, cfg.Notify.Timeout)
+		if err != nil {
+			log.Fatalf("Failed to init email notifications: %v", err)
+		}
+		sinks = append(sinks, email)
+		go notify.NewDigest(email, cfg.Notify.OverdueAfter, postgres.Repo.User, postgres.Repo.PullRequest,
+			postgres.Repo.Preferences, postgres.Repo.Preferences, logger).Run(ctx)
+	}
+	if cfg.Notify.Enabled() {
+		go notify.New(cfg.Notify, postgres.Repo.PullRequest, postgres.Repo.User, postgres.Repo.Preferences, logger, sinks...).Run(ctx, eventBus)
+	}
+
+	idempotencyKeys := idempotency.New(postgres.Repo.Idempotency, cfg.Idempotency.TTL, cfg.Idempotency.Lease, logger)
+	go idempotencyKeys.Run(ctx, cfg.Idempotency.CleanupInterval)
+
+	if cfg.SCIM.Token == "" {
+		logger.Info("SCIM endpoints disabled: SCIM_TOKEN is not set")
+	}
+	if cfg.Admin.Token == "" {
+		logger.Info("admin endpoints disabled: ADMIN_TOKEN is not set")
+	}
+
+	schemaVersion, err := migration.NewVersionChecker(postgres.Pool)
+	if err != nil {
+		log.Fatalf("Failed to init migration version check: %v", err)
+	}
+	defer schemaVersion.Close()
+
+	serverOpts := []server.Option{
+		server.WithMetrics(appMetrics),
+		server.WithIdempotency(idempotencyKeys),
+		server.WithTracing(cfg.Tracing.ServiceName),
+		server.WithSCIMToken(cfg.SCIM.Token),
+		server.WithAdminToken(cfg.Admin.Token),
+		server.WithDefaultLanguage(cfg.DefaultLanguage),
+		server.WithEventStream(eventBus, cfg.EventHeartbeat),
+		server.WithHealthCheckers(cfg.HealthCheckTimeout,
+			health.NewChecker("postgres", postgres.Pool.Ping),
+			health.NewChecker("migrations", schemaVersion.Check),
+		),
+	}
+	if cfg.GRPCPort != 0 {
+		grpcServer := grpcserver.New(cfg.GRPCPort, services, logger,
+			grpcserver.WithTracing(),
+			grpcserver.WithMetrics(appMetrics),
+		)
+		serverOpts = append(serverOpts, server.WithGRPCServer(grpcServer))
+	}
 
+	srv := server.NewServer(cfg.AppPort, services, logger, serverOpts...)
 	if err = srv.GracefulStart(logger); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
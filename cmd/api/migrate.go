@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/config"
+	"github.com/vnchk1/pr-manager/internal/migration"
+)
+
+const migrateUsage = "usage: pr-manager migrate up|down|status|version"
+
+// runMigrate выполняет подкоманду migrate над встроенными миграциями
+func runMigrate(cfg config.DatabaseConfig, args []string) error {
+	if len(args) != 1 {
+		return errors.New(migrateUsage)
+	}
+
+	db, err := migration.Open(cfg)
+	if err != nil {
+		return err
+	}
+
+	provider, err := migration.NewProvider(db)
+	if err != nil {
+		db.Close()
+		return err
+	}
+	defer provider.Close()
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		results, err := provider.Up(ctx)
+		for _, result := range results {
+			fmt.Println(result)
+		}
+		if err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			fmt.Println("no migrations to apply")
+		}
+	case "down":
+		result, err := provider.Down(ctx)
+		if result != nil {
+			fmt.Println(result)
+		}
+		if err != nil {
+			return err
+		}
+	case "status":
+		statuses, err := provider.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tSTATE\tAPPLIED AT\tSOURCE")
+		for _, status := range statuses {
+			appliedAt := "-"
+			if !status.AppliedAt.IsZero() {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Source.Version, status.State, appliedAt, status.Source.Path)
+		}
+		return w.Flush()
+	case "version":
+		version, err := provider.GetDBVersion(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Println(version)
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	return nil
+}
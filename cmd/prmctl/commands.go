@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+)
+
+// memberFlags - повторяемый флаг -member id=username[:inactive]
+type memberFlags []*models.User
+
+func (m *memberFlags) String() string {
+	return fmt.Sprint(len(*m), " members")
+}
+
+func (m *memberFlags) Set(value string) error {
+	id, username, ok := strings.Cut(value, "=")
+	if !ok || id == "" || username == "" {
+		return fmt.Errorf("member must be in form id=username[:inactive], got %q", value)
+	}
+
+	isActive := true
+	if name, flagValue, found := strings.Cut(username, ":"); found {
+		if flagValue != "inactive" {
+			return fmt.Errorf("unknown member modifier %q", flagValue)
+		}
+		username, isActive = name, false
+	}
+
+	*m = append(*m, &models.User{ID: id, Username: username, IsActive: isActive})
+	return nil
+}
+
+func (a *app) team(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: team add|get")
+	}
+
+	switch args[0] {
+	case "add":
+		fs := flag.NewFlagSet("team add", flag.ContinueOnError)
+		name := fs.String("name", "", "team name")
+		var members memberFlags
+		fs.Var(&members, "member", "team member as id=username[:inactive], repeatable")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *name == "" {
+			return errors.New("-name is required")
+		}
+
+		team, err := a.client.CreateTeam(ctx, &models.Team{Name: *name, Members: members})
+		if err != nil {
+			return err
+		}
+		return a.out.team(team)
+	case "get":
+		if len(args) != 2 {
+			return errors.New("usage: team get <team>")
+		}
+
+		team, err := a.client.GetTeam(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		return a.out.team(team)
+	default:
+		return fmt.Errorf("unknown team command %q", args[0])
+	}
+}
+
+func (a *app) user(ctx context.Context, args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: user activate|deactivate|reviews <user_id>")
+	}
+
+	switch args[0] {
+	case "activate", "deactivate":
+		user, err := a.client.SetUserActive(ctx, args[1], args[0] == "activate")
+		if err != nil {
+			return err
+		}
+		return a.out.users([]*models.User{user})
+	case "reviews":
+		queue, err := a.client.GetUserReviews(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		return a.out.reviewQueue(queue)
+	default:
+		return fmt.Errorf("unknown user command %q", args[0])
+	}
+}
+
+func (a *app) pr(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: pr create|merge|reassign|review|get|search")
+	}
+
+	switch args[0] {
+	case "create":
+		fs := flag.NewFlagSet("pr create", flag.ContinueOnError)
+		id := fs.String("id", "", "pull request id")
+		name := fs.String("name", "", "pull request title")
+		author := fs.String("author", "", "author user id")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *id == "" || *name == "" || *author == "" {
+			return errors.New("-id, -name and -author are required")
+		}
+
+		pr, err := a.client.CreatePR(ctx, &models.PRCreateRequest{ID: *id, Name: *name, AuthorID: *author})
+		if err != nil {
+			return err
+		}
+		return a.out.pullRequest(pr)
+	case "merge":
+		if len(args) != 2 {
+			return errors.New("usage: pr merge <pr_id>")
+		}
+
+		pr, err := a.client.MergePR(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		return a.out.pullRequest(pr)
+	case "reassign":
+		if len(args) != 3 {
+			return errors.New("usage: pr reassign <pr_id> <old_reviewer_id>")
+		}
+
+		pr, replacedBy, err := a.client.ReassignReviewer(ctx, &models.PRReassignRequest{ID: args[1], OldReviewer: args[2]})
+		if err != nil {
+			return err
+		}
+		if a.out.format == formatTable {
+			fmt.Fprintf(a.out.w, "%s replaced by %s\n", args[2], replacedBy)
+		}
+		return a.out.pullRequest(pr)
+	case "review":
+		if len(args) != 4 {
+			return errors.New("usage: pr review <pr_id> <reviewer_id> approve|request-changes")
+		}
+
+		states := map[string]models.ReviewState{
+			"approve":         models.ReviewApproved,
+			"request-changes": models.ReviewChangesRequested,
+		}
+		state, ok := states[args[3]]
+		if !ok {
+			return fmt.Errorf("unknown review state %q", args[3])
+		}
+
+		review, err := a.client.SubmitReview(ctx, &models.PRReviewRequest{ID: args[1], ReviewerID: args[2], State: state})
+		if err != nil {
+			return err
+		}
+		if a.out.format == formatJSON {
+			return a.out.json(review)
+		}
+		fmt.Fprintf(a.out.w, "%s: %s by %s\n", review.PullRequestID, review.State, review.ReviewerID)
+		return nil
+	case "get":
+		if len(args) != 2 {
+			return errors.New("usage: pr get <pr_id>")
+		}
+
+		pr, err := a.client.GetPR(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		return a.out.pullRequestDetails(pr)
+	case "search":
+		fs := flag.NewFlagSet("pr search", flag.ContinueOnError)
+		team := fs.String("team", "", "author team")
+		reviewer := fs.String("reviewer", "", "reviewer user id")
+		status := fs.String("status", "", "OPEN or MERGED")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() == 0 {
+			return errors.New("usage: pr search [-team <team>] [-reviewer <user_id>] [-status OPEN|MERGED] <text>")
+		}
+
+		prs, err := a.client.SearchPRs(ctx, strings.Join(fs.Args(), " "), models.PRFilter{
+			TeamName:   *team,
+			ReviewerID: *reviewer,
+			Status:     models.PullRequestStatus(*status),
+		})
+		if err != nil {
+			return err
+		}
+		return a.out.pullRequests(prs)
+	default:
+		return fmt.Errorf("unknown pr command %q", args[0])
+	}
+}
+
+func (a *app) stats(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: stats assignments|user|fairness")
+	}
+
+	switch args[0] {
+	case "assignments":
+		stats, err := a.client.AssignmentStats(ctx)
+		if err != nil {
+			return err
+		}
+		return a.out.assignmentStats(stats)
+	case "user":
+		if len(args) != 2 {
+			return errors.New("usage: stats user <user_id>")
+		}
+
+		stats, err := a.client.UserStats(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		return a.out.assignmentStats(&models.AssignmentStatsResponse{UserStats: []*models.UserAssignmentStats{stats}})
+	case "fairness":
+		fs := flag.NewFlagSet("stats fairness", flag.ContinueOnError)
+		threshold := fs.Float64("threshold", models.DefaultGiniThreshold, "Gini coefficient threshold")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		report, err := a.client.FairnessReport(ctx, *threshold)
+		if err != nil {
+			return err
+		}
+		return a.out.fairness(report)
+	default:
+		return fmt.Errorf("unknown stats command %q", args[0])
+	}
+}
+
+func (a *app) health(ctx context.Context) error {
+	report, err := a.client.Health(ctx)
+	if report != nil && report.Status != "" {
+		if printErr := a.out.health(report); printErr != nil {
+			return printErr
+		}
+	}
+	return err
+}
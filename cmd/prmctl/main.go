@@ -0,0 +1,119 @@
+// prmctl - консольный клиент к API pr-manager для дежурных
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/client"
+)
+
+const usage = `usage: prmctl [flags] <command> [args]
+
+Commands:
+  team add -name <team> -member <id>=<username>[:inactive] ...
+  team get <team>
+  user activate <user_id>
+  user deactivate <user_id>
+  user reviews <user_id>
+  pr create -id <pr_id> -name <title> -author <user_id>
+  pr merge <pr_id>
+  pr reassign <pr_id> <old_reviewer_id>
+  pr review <pr_id> <reviewer_id> approve|request-changes
+  pr get <pr_id>
+  pr search [-team <team>] [-reviewer <user_id>] [-status OPEN|MERGED] <text>
+  stats assignments
+  stats user <user_id>
+  stats fairness [-threshold 0.3]
+  health
+  seed <fixture.yaml>
+  import [-dry-run] <roster.json|yaml|csv>
+  export [-format json|yaml|csv]
+  reconcile [-dry-run] <roster.json|yaml|csv>
+
+Flags:
+`
+
+type app struct {
+	client *client.Client
+	out    *printer
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("prmctl", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+
+	baseURL := fs.String("url", getEnv("PRMCTL_URL", "http://localhost:8080"), "API base URL (env PRMCTL_URL)")
+	token := fs.String("token", os.Getenv("PRMCTL_TOKEN"), "bearer token (env PRMCTL_TOKEN)")
+	output := fs.String("o", getEnv("PRMCTL_OUTPUT", formatTable), "output format: table or json (env PRMCTL_OUTPUT)")
+	timeout := fs.Duration("timeout", 30*time.Second, "request timeout")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *output != formatTable && *output != formatJSON {
+		return fmt.Errorf("unknown output format %q", *output)
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("command is required")
+	}
+
+	a := &app{
+		client: client.New(*baseURL, *token, &http.Client{Timeout: *timeout}),
+		out:    newPrinter(os.Stdout, *output),
+	}
+
+	return a.dispatch(context.Background(), fs.Args())
+}
+
+func (a *app) dispatch(ctx context.Context, args []string) error {
+	command, rest := args[0], args[1:]
+
+	switch command {
+	case "team":
+		return a.team(ctx, rest)
+	case "user":
+		return a.user(ctx, rest)
+	case "pr":
+		return a.pr(ctx, rest)
+	case "stats":
+		return a.stats(ctx, rest)
+	case "health":
+		return a.health(ctx)
+	case "seed":
+		return a.seed(ctx, rest)
+	case "import":
+		return a.importRoster(ctx, rest)
+	case "export":
+		return a.exportRoster(ctx, rest)
+	case "reconcile":
+		return a.reconcileRoster(ctx, rest)
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/vnchk1/pr-manager/internal/client"
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeed(t *testing.T) {
+	var mu sync.Mutex
+	created := map[string]*models.Team{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/team/add", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		var team models.Team
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&team))
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if _, ok := created[team.Name]; ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(map[string]any{"success": false, "error": models.ErrTeamExists.Error()})
+			return
+		}
+		created[team.Name] = &team
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{"success": true, "team": team})
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	a := &app{client: client.New(srv.URL, "secret", srv.Client()), out: newPrinter(&out, formatTable)}
+
+	require.NoError(t, a.seed(context.Background(), []string{"testdata/seed.yaml"}))
+	assert.Contains(t, out.String(), "team backend: created with 3 members")
+	require.Contains(t, created, "backend")
+	require.Len(t, created["backend"].Members, 3)
+	assert.False(t, created["backend"].Members[2].IsActive)
+	assert.True(t, created["frontend"].Members[0].IsActive)
+
+	// Повторный seed не падает на первой ошибке, а сообщает о всех
+	err := a.seed(context.Background(), []string{"testdata/seed.yaml"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 of 2 teams failed")
+}
+
+func TestRun_TeamGetJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/team/get", r.URL.Path)
+		assert.Equal(t, "backend", r.URL.Query().Get("team_name"))
+		_, _ = w.Write([]byte(`{"success":true,"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true}]}`))
+	}))
+	defer srv.Close()
+
+	require.NoError(t, run([]string{"-url", srv.URL, "-o", "json", "team", "get", "backend"}))
+	require.Error(t, run([]string{"-url", srv.URL, "-o", "yaml", "team", "get", "backend"}))
+	require.Error(t, run([]string{"-url", srv.URL, "unknown"}))
+}
+
+func TestMemberFlags(t *testing.T) {
+	var members memberFlags
+	require.NoError(t, members.Set("u1=Alice"))
+	require.NoError(t, members.Set("u2=Bob:inactive"))
+	require.Error(t, members.Set("u3"))
+	require.Error(t, members.Set("u4=Dave:away"))
+
+	require.Len(t, members, 2)
+	assert.True(t, members[0].IsActive)
+	assert.Equal(t, "Bob", members[1].Username)
+	assert.False(t, members[1].IsActive)
+}
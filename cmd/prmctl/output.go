@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/vnchk1/pr-manager/internal/health"
+	"github.com/vnchk1/pr-manager/internal/models"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func newPrinter(w io.Writer, format string) *printer {
+	return &printer{w: w, format: format}
+}
+
+func (p *printer) json(v any) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// table печатает строки через tabwriter; первая строка - заголовок
+func (p *printer) table(rows [][]string) error {
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func (p *printer) team(team *models.Team) error {
+	if p.format == formatJSON {
+		return p.json(team)
+	}
+
+	fmt.Fprintf(p.w, "Team: %s\n", team.Name)
+	return p.users(team.Members)
+}
+
+func (p *printer) users(users []*models.User) error {
+	if p.format == formatJSON {
+		return p.json(users)
+	}
+
+	rows := [][]string{{"USER ID", "USERNAME", "TEAM", "ACTIVE"}}
+	for _, user := range users {
+		rows = append(rows, []string{user.ID, user.Username, user.TeamName, fmt.Sprint(user.IsActive)})
+	}
+	return p.table(rows)
+}
+
+func (p *printer) reviewQueue(queue *models.ReviewQueue) error {
+	if p.format == formatJSON {
+		return p.json(queue)
+	}
+
+	rows := [][]string{{"PR ID", "NAME", "AUTHOR", "STATUS", "REVIEW"}}
+	for _, pr := range queue.All() {
+		rows = append(rows, []string{pr.ID, pr.Name, pr.AuthorID, string(pr.Status), string(pr.ReviewState)})
+	}
+	return p.table(rows)
+}
+
+func (p *printer) pullRequest(pr *models.PullRequest) error {
+	if p.format == formatJSON {
+		return p.json(pr)
+	}
+
+	return p.table([][]string{
+		{"PR ID", "NAME", "AUTHOR", "STATUS", "REVIEWERS"},
+		{pr.ID, pr.Name, pr.AuthorID, string(pr.Status), strings.Join(pr.AssignedReviewers, ",")},
+	})
+}
+
+func (p *printer) pullRequests(prs []*models.PullRequest) error {
+	if p.format == formatJSON {
+		return p.json(prs)
+	}
+
+	rows := [][]string{{"PR ID", "NAME", "AUTHOR", "STATUS", "REVIEWERS"}}
+	for _, pr := range prs {
+		rows = append(rows, []string{pr.ID, pr.Name, pr.AuthorID, string(pr.Status), strings.Join(pr.AssignedReviewers, ",")})
+	}
+	return p.table(rows)
+}
+
+func (p *printer) pullRequestDetails(pr *models.PullRequestDetails) error {
+	if p.format == formatJSON {
+		return p.json(pr)
+	}
+
+	reviewers := make([]string, 0, len(pr.Reviewers))
+	for _, reviewer := range pr.Reviewers {
+		name := reviewer.UserID
+		if reviewer.Username != "" {
+			name += "=" + reviewer.Username
+		}
+		reviewers = append(reviewers, name)
+	}
+
+	return p.table([][]string{
+		{"PR ID", "NAME", "AUTHOR", "STATUS", "REVIEWERS"},
+		{pr.ID, pr.Name, pr.AuthorID + "=" + pr.AuthorUsername, string(pr.Status), strings.Join(reviewers, ",")},
+	})
+}
+
+func (p *printer) assignmentStats(stats *models.AssignmentStatsResponse) error {
+	if p.format == formatJSON {
+		return p.json(stats)
+	}
+
+	rows := [][]string{{"USER ID", "USERNAME", "TEAM", "ACTIVE", "ASSIGNMENTS"}}
+	for _, stat := range stats.UserStats {
+		rows = append(rows, []string{
+			stat.UserID, stat.Username, stat.TeamName, fmt.Sprint(stat.IsActive), fmt.Sprint(stat.AssignmentCount),
+		})
+	}
+	if err := p.table(rows); err != nil {
+		return err
+	}
+
+	if stats.PRStats != nil {
+		fmt.Fprintf(p.w, "\nPRs: %d total, %d open, %d merged, %.2f reviewers per PR\n",
+			stats.PRStats.TotalPRs, stats.PRStats.OpenPRs, stats.PRStats.MergedPRs, stats.PRStats.AvgReviewersPerPR)
+	}
+	return nil
+}
+
+func (p *printer) fairness(report *models.FairnessReport) error {
+	if p.format == formatJSON {
+		return p.json(report)
+	}
+
+	rows := [][]string{{"TEAM", "MEMBERS", "ASSIGNMENTS", "MEAN/DAY", "STDDEV", "GINI", "IMBALANCED", "OVERLOADED", "UNDERLOADED"}}
+	for _, team := range report.Teams {
+		rows = append(rows, []string{
+			team.TeamName,
+			fmt.Sprint(team.ActiveMembers),
+			fmt.Sprint(team.TotalAssignments),
+			fmt.Sprintf("%.3f", team.MeanPerDay),
+			fmt.Sprintf("%.3f", team.StdDev),
+			fmt.Sprintf("%.3f", team.Gini),
+			fmt.Sprint(team.Imbalanced),
+			memberNames(team.Overloaded),
+			memberNames(team.Underloaded),
+		})
+	}
+	return p.table(rows)
+}
+
+func (p *printer) health(report *health.Report) error {
+	if p.format == formatJSON {
+		return p.json(report)
+	}
+
+	fmt.Fprintf(p.w, "Status: %s\n", report.Status)
+	rows := [][]string{{"DEPENDENCY", "STATUS", "LATENCY MS", "ERROR"}}
+	for name, check := range report.Checks {
+		rows = append(rows, []string{name, string(check.Status), fmt.Sprintf("%.1f", check.LatencyMs), check.Error})
+	}
+	return p.table(rows)
+}
+
+func memberNames(members []*models.MemberFairness) string {
+	names := make([]string, len(members))
+	for i, member := range members {
+		names[i] = member.Username
+	}
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/roster"
+)
+
+func (a *app) importRoster(ctx context.Context, args []string) error {
+	file, format, dryRun, err := openRoster("import", args)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	diff, err := a.client.ImportRoster(ctx, format, file, dryRun)
+	if err != nil {
+		return err
+	}
+	return a.out.rosterDiff(diff)
+}
+
+func (a *app) reconcileRoster(ctx context.Context, args []string) error {
+	file, format, dryRun, err := openRoster("reconcile", args)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	plan, err := a.client.ReconcileRoster(ctx, format, file, dryRun)
+	if err != nil {
+		return err
+	}
+	return a.out.reconcilePlan(plan)
+}
+
+// openRoster разбирает флаги import/reconcile и открывает файл с составом команд
+func openRoster(command string, args []string) (*os.File, string, bool, error) {
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "only show the changes")
+	format := fs.String("format", "", "roster format, detected from file extension by default")
+	if err := fs.Parse(args); err != nil {
+		return nil, "", false, err
+	}
+	if fs.NArg() != 1 {
+		return nil, "", false, fmt.Errorf("usage: %s [-dry-run] <roster.json|yaml|csv>", command)
+	}
+
+	path := fs.Arg(0)
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(path), ".")
+		if *format == "yml" {
+			*format = roster.FormatYAML
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to open roster: %w", err)
+	}
+
+	return file, *format, *dryRun, nil
+}
+
+func (a *app) exportRoster(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", roster.FormatYAML, "roster format: json, yaml or csv")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	data, err := a.client.ExportRoster(ctx, *format)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.out.w.Write(data)
+	return err
+}
+
+func (p *printer) rosterDiff(diff *models.RosterDiff) error {
+	if p.format == formatJSON {
+		return p.json(diff)
+	}
+
+	rows := [][]string{{"CHANGE", "USER ID", "USERNAME", "TEAM", "ACTIVE"}}
+	for _, team := range diff.TeamsCreated {
+		rows = append(rows, []string{"create team", "-", "-", team, "-"})
+	}
+	for _, change := range diff.UsersCreated {
+		rows = append(rows, []string{"create user", change.UserID, change.After.Username, change.After.TeamName, fmt.Sprint(change.After.IsActive)})
+	}
+	for _, change := range diff.UsersUpdated {
+		rows = append(rows, []string{"update user", change.UserID, change.After.Username, change.After.TeamName, fmt.Sprint(change.After.IsActive)})
+	}
+	if err := p.table(rows); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(p.w, "\n%d users unchanged\n", diff.UsersUnchanged)
+	return nil
+}
+
+func (p *printer) reconcilePlan(plan *models.ReconcilePlan) error {
+	if p.format == formatJSON {
+		return p.json(plan)
+	}
+
+	rows := [][]string{{"CHANGE", "USER ID", "USERNAME", "TEAM", "ACTIVE"}}
+	for _, team := range plan.TeamsCreated {
+		rows = append(rows, []string{"create team", "-", "-", team, "-"})
+	}
+	for _, team := range plan.TeamsDeleted {
+		rows = append(rows, []string{"delete team", "-", "-", team, "-"})
+	}
+	userChanges := []struct {
+		label   string
+		changes []*models.RosterUserChange
+	}{
+		{"create user", plan.UsersCreated},
+		{"update user", plan.UsersUpdated},
+		{"move user", plan.UsersMoved},
+		{"deactivate user", plan.UsersDeactivated},
+	}
+	for _, group := range userChanges {
+		for _, change := range group.changes {
+			rows = append(rows, []string{group.label, change.UserID, change.After.Username, change.After.TeamName, fmt.Sprint(change.After.IsActive)})
+		}
+	}
+	if err := p.table(rows); err != nil {
+		return err
+	}
+
+	if len(plan.Reassignments) > 0 {
+		fmt.Fprintln(p.w)
+		rows = [][]string{{"PR ID", "OLD REVIEWER", "NEW REVIEWER"}}
+		for _, change := range plan.Reassignments {
+			for _, oldReviewer := range slices.Sorted(maps.Keys(change.Replaced)) {
+				newReviewer := change.Replaced[oldReviewer]
+				if newReviewer == "" {
+					newReviewer = "-"
+				}
+				rows = append(rows, []string{change.PullRequestID, oldReviewer, newReviewer})
+			}
+		}
+		if err := p.table(rows); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(p.w, "\n%d users unchanged\n", plan.UsersUnchanged)
+	return nil
+}
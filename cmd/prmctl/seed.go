@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+// seedFixture - YAML-описание команд и участников для команды seed
+type seedFixture struct {
+	Teams []struct {
+		Name    string `yaml:"name"`
+		Members []struct {
+			UserID   string `yaml:"user_id"`
+			Username string `yaml:"username"`
+			IsActive *bool  `yaml:"is_active"`
+		} `yaml:"members"`
+	} `yaml:"teams"`
+}
+
+func loadFixture(path string) ([]*models.Team, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	var fixture seedFixture
+	if err = yaml.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture: %w", err)
+	}
+
+	teams := make([]*models.Team, 0, len(fixture.Teams))
+	for _, fixtureTeam := range fixture.Teams {
+		team := &models.Team{Name: fixtureTeam.Name}
+		for _, member := range fixtureTeam.Members {
+			isActive := true
+			if member.IsActive != nil {
+				isActive = *member.IsActive
+			}
+			team.Members = append(team.Members, &models.User{
+				ID:       member.UserID,
+				Username: member.Username,
+				TeamName: fixtureTeam.Name,
+				IsActive: isActive,
+			})
+		}
+
+		if err = team.Validate(); err != nil {
+			return nil, fmt.Errorf("team %q: %w", fixtureTeam.Name, err)
+		}
+		teams = append(teams, team)
+	}
+
+	return teams, nil
+}
+
+func (a *app) seed(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: seed <fixture.yaml>")
+	}
+
+	teams, err := loadFixture(args[0])
+	if err != nil {
+		return err
+	}
+
+	// Продолжаем после ошибки, чтобы одна существующая команда не блокировала остальные
+	failed := 0
+	for _, team := range teams {
+		if _, err := a.client.CreateTeam(ctx, team); err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "team %s: %v\n", team.Name, err)
+			continue
+		}
+		fmt.Fprintf(a.out.w, "team %s: created with %d members\n", team.Name, len(team.Members))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d teams failed", failed, len(teams))
+	}
+	return nil
+}
@@ -0,0 +1,311 @@
+// Package client - HTTP-клиент к API pr-manager, используемый prmctl
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/vnchk1/pr-manager/internal/health"
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/roster"
+)
+
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+func New(baseURL, token string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+// APIError - ответ сервера со статусом 4xx/5xx
+type APIError struct {
+	StatusCode int
+	Message    string `json:"message"`
+	Detail     string `json:"error"`
+	RequestID  string `json:"request_id"`
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("api error %d", e.StatusCode)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Detail != "" {
+		msg += " (" + e.Detail + ")"
+	}
+	if e.RequestID != "" {
+		msg += " [request_id=" + e.RequestID + "]"
+	}
+	return msg
+}
+
+func (c *Client) CreateTeam(ctx context.Context, team *models.Team) (*models.Team, error) {
+	var resp struct {
+		Team *models.Team `json:"team"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/team/add", nil, team, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Team, nil
+}
+
+func (c *Client) GetTeam(ctx context.Context, teamName string) (*models.Team, error) {
+	var team models.Team
+	query := url.Values{"team_name": {teamName}}
+	if err := c.do(ctx, http.MethodGet, "/team/get", query, nil, &team); err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (c *Client) SetUserActive(ctx context.Context, userID string, isActive bool) (*models.User, error) {
+	req := map[string]any{"user_id": userID, "is_active": isActive}
+
+	var resp struct {
+		User *models.User `json:"user"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/users/setIsActive", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.User, nil
+}
+
+func (c *Client) GetUserReviews(ctx context.Context, userID string) (*models.ReviewQueue, error) {
+	var resp struct {
+		Review *models.ReviewQueue `json:"review"`
+	}
+	query := url.Values{"user_id": {userID}}
+	if err := c.do(ctx, http.MethodGet, "/users/getReview", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Review, nil
+}
+
+func (c *Client) CreatePR(ctx context.Context, req *models.PRCreateRequest) (*models.PullRequest, error) {
+	var resp struct {
+		PR *models.PullRequest `json:"pr"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/create", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.PR, nil
+}
+
+func (c *Client) MergePR(ctx context.Context, prID string) (*models.PullRequest, error) {
+	var resp struct {
+		PR *models.PullRequest `json:"pr"`
+	}
+	req := &models.PRMergeRequest{ID: prID}
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/merge", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.PR, nil
+}
+
+func (c *Client) ReassignReviewer(ctx context.Context, req *models.PRReassignRequest) (*models.PullRequest, string, error) {
+	var resp struct {
+		PR         *models.PullRequest `json:"pr"`
+		ReplacedBy string              `json:"replaced_by"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/reassign", nil, req, &resp); err != nil {
+		return nil, "", err
+	}
+	return resp.PR, resp.ReplacedBy, nil
+}
+
+func (c *Client) SubmitReview(ctx context.Context, req *models.PRReviewRequest) (*models.PRReview, error) {
+	var resp struct {
+		Review *models.PRReview `json:"review"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/review", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Review, nil
+}
+
+func (c *Client) GetPR(ctx context.Context, prID string) (*models.PullRequestDetails, error) {
+	var resp struct {
+		PR *models.PullRequestDetails `json:"pr"`
+	}
+	query := url.Values{"pull_request_id": {prID}}
+	if err := c.do(ctx, http.MethodGet, "/pullRequest/get", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.PR, nil
+}
+
+// SearchPRs ищет PR по названию; пустые поля filter не передаются
+func (c *Client) SearchPRs(ctx context.Context, text string, filter models.PRFilter) ([]*models.PullRequest, error) {
+	var resp struct {
+		PullRequests []*models.PullRequest `json:"pull_requests"`
+	}
+	query := url.Values{"q": {text}}
+	if filter.TeamName != "" {
+		query.Set("team_name", filter.TeamName)
+	}
+	if filter.ReviewerID != "" {
+		query.Set("reviewer_id", filter.ReviewerID)
+	}
+	if filter.Status != "" {
+		query.Set("status", string(filter.Status))
+	}
+	if err := c.do(ctx, http.MethodGet, "/pullRequest/search", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.PullRequests, nil
+}
+
+func (c *Client) AssignmentStats(ctx context.Context) (*models.AssignmentStatsResponse, error) {
+	var resp models.AssignmentStatsResponse
+	if err := c.do(ctx, http.MethodGet, "/stats/assignments", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) UserStats(ctx context.Context, userID string) (*models.UserAssignmentStats, error) {
+	var resp models.UserAssignmentStats
+	query := url.Values{"user_id": {userID}}
+	if err := c.do(ctx, http.MethodGet, "/stats/user", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) FairnessReport(ctx context.Context, giniThreshold float64) (*models.FairnessReport, error) {
+	var resp models.FairnessReport
+	query := url.Values{"gini_threshold": {strconv.FormatFloat(giniThreshold, 'f', -1, 64)}}
+	if err := c.do(ctx, http.MethodGet, "/stats/fairness", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Health возвращает отчет /health/ready; при 503 отчет тоже возвращается вместе с ошибкой
+func (c *Client) Health(ctx context.Context) (*health.Report, error) {
+	var report health.Report
+	err := c.do(ctx, http.MethodGet, "/health/ready", nil, nil, &report)
+	return &report, err
+}
+
+// ImportRoster отправляет состав команд в исходном формате (json, yaml или csv)
+func (c *Client) ImportRoster(ctx context.Context, format string, body io.Reader, dryRun bool) (*models.RosterDiff, error) {
+	var resp struct {
+		Diff *models.RosterDiff `json:"diff"`
+	}
+	query := url.Values{"format": {format}, "dry_run": {strconv.FormatBool(dryRun)}}
+	if err := c.send(ctx, http.MethodPost, "/admin/import", query, roster.ContentType(format), body, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Diff, nil
+}
+
+// ReconcileRoster приводит состав команд к переданному и возвращает план изменений
+func (c *Client) ReconcileRoster(ctx context.Context, format string, body io.Reader, dryRun bool) (*models.ReconcilePlan, error) {
+	var resp struct {
+		Plan *models.ReconcilePlan `json:"plan"`
+	}
+	query := url.Values{"format": {format}, "dry_run": {strconv.FormatBool(dryRun)}}
+	if err := c.send(ctx, http.MethodPost, "/admin/reconcile", query, roster.ContentType(format), body, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Plan, nil
+}
+
+// ExportRoster возвращает выгрузку состава команд в указанном формате как есть
+func (c *Client) ExportRoster(ctx context.Context, format string) ([]byte, error) {
+	var raw rawBody
+	if err := c.send(ctx, http.MethodGet, "/admin/export", url.Values{"format": {format}}, "", nil, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// rawBody - приемник для ответов, которые не нужно разбирать как JSON
+type rawBody []byte
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	return c.send(ctx, method, path, query, "application/json", reqBody, out)
+}
+
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, contentType string, body io.Reader, out any) error {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		_ = json.Unmarshal(data, apiErr)
+		// Тело ответа с ошибкой все равно отдаем, если вызывающему оно нужно (например, отчет health)
+		if out != nil {
+			_ = json.Unmarshal(data, out)
+		}
+		return apiErr
+	}
+
+	if raw, ok := out.(*rawBody); ok {
+		*raw = data
+		return nil
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+
+	if err = json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
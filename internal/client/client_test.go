@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_APIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"success":false,"message":"Не удалось выполнить merge pull request","error":"resource not found","request_id":"req-1"}`))
+	}))
+	defer srv.Close()
+
+	_, err := New(srv.URL+"/", "", nil).MergePR(context.Background(), "pr-404")
+	require.Error(t, err)
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	assert.Equal(t, "resource not found", apiErr.Detail)
+	assert.Equal(t, "req-1", apiErr.RequestID)
+}
+
+func TestClient_ReassignReviewer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/pullRequest/reassign", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		_, _ = w.Write([]byte(`{"success":true,"pr":{"pull_request_id":"pr-1","assigned_reviewers":["u3"]},"replaced_by":"u3"}`))
+	}))
+	defer srv.Close()
+
+	pr, replacedBy, err := New(srv.URL, "", nil).ReassignReviewer(context.Background(), &models.PRReassignRequest{
+		ID:          "pr-1",
+		OldReviewer: "u2",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "u3", replacedBy)
+	assert.Equal(t, []string{"u3"}, pr.AssignedReviewers)
+}
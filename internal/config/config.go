@@ -1,14 +1,134 @@
 package config
 
 import (
+	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"strconv"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/i18n"
 )
 
 type Config struct {
 	LogLevel string
 	AppPort  int
+	// GRPCPort - порт gRPC API, 0 отключает gRPC
+	GRPCPort int
 	Database DatabaseConfig
+	// AutoMigrate - применять миграции при старте сервера
+	AutoMigrate bool
+	Metrics     MetricsConfig
+	Tracing     TracingConfig
+	SCIM        SCIMConfig
+	Admin       AdminConfig
+	LDAP        LDAPConfig
+	Notify      NotifyConfig
+	Idempotency IdempotencyConfig
+	// DefaultLanguage - язык сообщений API, если Accept-Language не указан или не поддерживается
+	DefaultLanguage i18n.Lang
+
+	HealthCheckTimeout time.Duration
+	// EventHeartbeat - период комментариев-пульсов в SSE-потоке /events/stream
+	EventHeartbeat time.Duration
+	// EventRetention - сколько хранится журнал событий; старые события удаляются
+	// раз в EventCleanupInterval
+	EventRetention       time.Duration
+	EventCleanupInterval time.Duration
+}
+
+// IdempotencyConfig описывает хранение ответов на запросы с Idempotency-Key
+type IdempotencyConfig struct {
+	// TTL - сколько хранится ответ; после этого ключ можно использовать заново
+	TTL time.Duration
+	// Lease - на сколько ключ занимается под выполняемый запрос; если ответ не сохранен
+	// (процесс упал), ключ освобождается по ее истечении
+	Lease           time.Duration
+	CleanupInterval time.Duration
+}
+
+type SCIMConfig struct {
+	// Token - bearer-токен для /scim/v2, без него эндпоинты не подключаются
+	Token string
+}
+
+type AdminConfig struct {
+	// Token - bearer-токен для /admin, без него эндпоинты не подключаются
+	Token string
+}
+
+type MetricsConfig struct {
+	RefreshInterval time.Duration
+}
+
+type TracingConfig struct {
+	Exporter    string
+	Endpoint    string
+	Insecure    bool
+	ServiceName string
+	SampleRatio float64
+}
+
+// LDAPConfig описывает синхронизацию команд из групп LDAP. Пустой URL отключает синхронизацию.
+type LDAPConfig struct {
+	URL          string
+	BindDN       string
+	BindPassword string
+	StartTLS     bool
+	Timeout      time.Duration
+	SyncInterval time.Duration
+
+	GroupBaseDN   string
+	GroupFilter   string
+	GroupNameAttr string
+	MemberAttr    string
+
+	UserIDAttr   string
+	UsernameAttr string
+	// DisabledAttr - атрибут отключенной учетной записи. Для userAccountControl
+	// проверяется флаг ACCOUNTDISABLE, для остальных атрибутов - значение true.
+	DisabledAttr string
+}
+
+func (c LDAPConfig) Enabled() bool {
+	return c.URL != ""
+}
+
+// NotifyConfig описывает уведомления ревьюверов. Пустой SlackConfigPath отключает уведомления в чат.
+type NotifyConfig struct {
+	// SlackConfigPath - YAML-файл с вебхуками команд, упоминаниями и шаблонами сообщений
+	SlackConfigPath string
+	Timeout         time.Duration
+	// OverdueAfter - через сколько после создания открытый PR считается просроченным
+	OverdueAfter         time.Duration
+	OverdueCheckInterval time.Duration
+	// UserWebhooks включает личные вебхуки из настроек пользователей
+	UserWebhooks bool
+	SMTP         SMTPConfig
+}
+
+// SMTPConfig описывает почтовые уведомления. Пустой Host отключает почту.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	// AddressDomain - домен адреса user_id@domain для пользователей, не указавших адрес
+	AddressDomain string
+	// DigestAt - время ежедневной сводки как смещение от полуночи в DigestLocation
+	DigestAt       time.Duration
+	DigestLocation *time.Location
+}
+
+// Enabled сообщает, включен ли хотя бы один канал уведомлений
+func (c NotifyConfig) Enabled() bool {
+	return c.SlackConfigPath != "" || c.SMTP.Enabled() || c.UserWebhooks
+}
+
+func (c SMTPConfig) Enabled() bool {
+	return c.Host != ""
 }
 
 type DatabaseConfig struct {
@@ -20,10 +140,37 @@ type DatabaseConfig struct {
 	SSLMode  string
 }
 
+func (c DatabaseConfig) DSN() string {
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     net.JoinHostPort(c.Host, strconv.Itoa(c.Port)),
+		Path:     "/" + c.DBName,
+		RawQuery: url.Values{"sslmode": {c.SSLMode}}.Encode(),
+	}
+
+	return dsn.String()
+}
+
 func Load() (*Config, error) {
+	defaultLanguage, err := i18n.ParseLang(getEnv("DEFAULT_LANGUAGE", string(i18n.Russian)))
+	if err != nil {
+		return nil, fmt.Errorf("DEFAULT_LANGUAGE: %w", err)
+	}
+
+	digestTime, err := time.Parse("15:04", getEnv("DIGEST_TIME", "09:00"))
+	if err != nil {
+		return nil, fmt.Errorf("DIGEST_TIME: %w", err)
+	}
+	digestLocation, err := time.LoadLocation(getEnv("DIGEST_TIMEZONE", "UTC"))
+	if err != nil {
+		return nil, fmt.Errorf("DIGEST_TIMEZONE: %w", err)
+	}
+
 	cfg := &Config{
 		LogLevel: getEnv("LOG_LEVEL", "info"),
 		AppPort:  getEnvInt("APP_PORT", 8080),
+		GRPCPort: getEnvInt("GRPC_PORT", 9090),
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
 			Port:     getEnvInt("DB_PORT", 5432),
@@ -32,11 +179,102 @@ func Load() (*Config, error) {
 			DBName:   getEnv("DB_NAME", "pr_manager"),
 			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
 		},
+		AutoMigrate:          getEnvBool("DB_AUTO_MIGRATE", true),
+		HealthCheckTimeout:   getEnvDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+		EventHeartbeat:       getEnvDuration("EVENTS_HEARTBEAT", 15*time.Second),
+		EventRetention:       getEnvDuration("EVENTS_RETENTION", 7*24*time.Hour),
+		EventCleanupInterval: getEnvDuration("EVENTS_CLEANUP_INTERVAL", time.Hour),
+		DefaultLanguage:      defaultLanguage,
+		Metrics: MetricsConfig{
+			RefreshInterval: getEnvDuration("METRICS_REFRESH_INTERVAL", 30*time.Second),
+		},
+		Tracing: TracingConfig{
+			Exporter:    getEnv("TRACING_EXPORTER", "none"),
+			Endpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318"),
+			Insecure:    getEnvBool("OTEL_EXPORTER_OTLP_INSECURE", true),
+			ServiceName: getEnv("OTEL_SERVICE_NAME", "pr-manager"),
+			SampleRatio: getEnvFloat("TRACING_SAMPLE_RATIO", 1.0),
+		},
+		SCIM: SCIMConfig{
+			Token: getEnv("SCIM_TOKEN", ""),
+		},
+		Admin: AdminConfig{
+			Token: getEnv("ADMIN_TOKEN", ""),
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:             getEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+			Lease:           getEnvDuration("IDEMPOTENCY_LEASE", time.Minute),
+			CleanupInterval: getEnvDuration("IDEMPOTENCY_CLEANUP_INTERVAL", time.Hour),
+		},
+		LDAP: LDAPConfig{
+			URL:           getEnv("LDAP_URL", ""),
+			BindDN:        getEnv("LDAP_BIND_DN", ""),
+			BindPassword:  getEnv("LDAP_BIND_PASSWORD", ""),
+			StartTLS:      getEnvBool("LDAP_START_TLS", false),
+			Timeout:       getEnvDuration("LDAP_TIMEOUT", 10*time.Second),
+			SyncInterval:  getEnvDuration("LDAP_SYNC_INTERVAL", 15*time.Minute),
+			GroupBaseDN:   getEnv("LDAP_GROUP_BASE_DN", ""),
+			GroupFilter:   getEnv("LDAP_GROUP_FILTER", "(objectClass=groupOfNames)"),
+			GroupNameAttr: getEnv("LDAP_GROUP_NAME_ATTR", "cn"),
+			MemberAttr:    getEnv("LDAP_MEMBER_ATTR", "member"),
+			UserIDAttr:    getEnv("LDAP_USER_ID_ATTR", "uid"),
+			UsernameAttr:  getEnv("LDAP_USERNAME_ATTR", "cn"),
+			DisabledAttr:  getEnv("LDAP_DISABLED_ATTR", "userAccountControl"),
+		},
+		Notify: NotifyConfig{
+			SlackConfigPath:      getEnv("SLACK_CONFIG", ""),
+			Timeout:              getEnvDuration("NOTIFY_TIMEOUT", 10*time.Second),
+			OverdueAfter:         getEnvDuration("PR_OVERDUE_AFTER", 48*time.Hour),
+			OverdueCheckInterval: getEnvDuration("PR_OVERDUE_CHECK_INTERVAL", 15*time.Minute),
+			UserWebhooks:         getEnvBool("NOTIFY_USER_WEBHOOKS", false),
+			SMTP: SMTPConfig{
+				Host:           getEnv("SMTP_HOST", ""),
+				Port:           getEnvInt("SMTP_PORT", 587),
+				Username:       getEnv("SMTP_USERNAME", ""),
+				Password:       getEnv("SMTP_PASSWORD", ""),
+				From:           getEnv("SMTP_FROM", "pr-manager@localhost"),
+				AddressDomain:  getEnv("EMAIL_ADDRESS_DOMAIN", ""),
+				DigestAt:       time.Duration(digestTime.Hour())*time.Hour + time.Duration(digestTime.Minute())*time.Minute,
+				DigestLocation: digestLocation,
+			},
+		},
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
 	}
 
 	return cfg, nil
 }
 
+// validate проверяет периоды фоновых задач: time.NewTicker паникует на нуле и
+// отрицательных значениях. Периоды выключенных задач не проверяются.
+func (c *Config) validate() error {
+	intervals := []struct {
+		env     string
+		value   time.Duration
+		enabled bool
+	}{
+		{"METRICS_REFRESH_INTERVAL", c.Metrics.RefreshInterval, true},
+		{"EVENTS_HEARTBEAT", c.EventHeartbeat, true},
+		{"EVENTS_RETENTION", c.EventRetention, true},
+		{"EVENTS_CLEANUP_INTERVAL", c.EventCleanupInterval, true},
+		{"IDEMPOTENCY_TTL", c.Idempotency.TTL, true},
+		{"IDEMPOTENCY_LEASE", c.Idempotency.Lease, true},
+		{"IDEMPOTENCY_CLEANUP_INTERVAL", c.Idempotency.CleanupInterval, true},
+		{"LDAP_SYNC_INTERVAL", c.LDAP.SyncInterval, c.LDAP.Enabled()},
+		{"PR_OVERDUE_CHECK_INTERVAL", c.Notify.OverdueCheckInterval, c.Notify.Enabled()},
+	}
+
+	for _, interval := range intervals {
+		if interval.enabled && interval.value <= 0 {
+			return fmt.Errorf("%s: must be positive, got %s", interval.env, interval.value)
+		}
+	}
+
+	return nil
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -52,3 +290,30 @@ func getEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
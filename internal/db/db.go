@@ -3,6 +3,7 @@ package db
 import (
 	"github.com/vnchk1/pr-manager/internal/config"
 	"github.com/vnchk1/pr-manager/internal/repository"
+	"github.com/vnchk1/pr-manager/internal/tracing"
 	"context"
 	"fmt"
 	"time"
@@ -16,17 +17,7 @@ type DB struct {
 }
 
 func NewPostgresDB(cfg config.DatabaseConfig) (*DB, error) {
-	connString := fmt.Sprintf(
-		"postgres://%s:%s@%s:%d/%s?sslmode=%s",
-		cfg.User,
-		cfg.Password,
-		cfg.Host,
-		cfg.Port,
-		cfg.DBName,
-		cfg.SSLMode,
-	)
-
-	poolConfig, err := pgxpool.ParseConfig(connString)
+	poolConfig, err := pgxpool.ParseConfig(cfg.DSN())
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse database config: %w", err)
 	}
@@ -36,6 +27,7 @@ func NewPostgresDB(cfg config.DatabaseConfig) (*DB, error) {
 	poolConfig.MaxConnLifetime = time.Hour
 	poolConfig.MaxConnIdleTime = 30 * time.Minute
 	poolConfig.HealthCheckPeriod = time.Minute
+	poolConfig.ConnConfig.Tracer = multiQueryTracer{tracing.NewQueryTracer(), queryLogger{}}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -54,6 +46,10 @@ func NewPostgresDB(cfg config.DatabaseConfig) (*DB, error) {
 		Team:        repository.NewTeamRepository(pool),
 		PullRequest: repository.NewPullRequestRepository(pool),
 		Stats:       repository.NewStatsRepository(pool),
+		Roster:      repository.NewRosterRepository(pool),
+		Event:       repository.NewEventRepository(pool),
+		Preferences: repository.NewPreferencesRepository(pool),
+		Idempotency: repository.NewIdempotencyRepository(pool),
 	}
 
 	return &DB{
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	logpkg "github.com/vnchk1/pr-manager/internal/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// integrityConstraintViolation - класс SQLSTATE нарушений ограничений
+const integrityConstraintViolation = "23"
+
+type querySQLKey struct{}
+
+// queryLogger пишет ошибки запросов в логгер запроса, чтобы они были связаны с request_id.
+// Нарушения ограничений (класс 23) - ожидаемый исход гонок вроде параллельного создания
+// PR, их разбирает вызывающий код, поэтому они пишутся на уровне debug.
+type queryLogger struct{}
+
+func (queryLogger) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, querySQLKey{}, data.SQL)
+}
+
+func (queryLogger) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	if data.Err == nil || errors.Is(data.Err, pgx.ErrNoRows) {
+		return
+	}
+
+	logger := logpkg.FromContext(ctx)
+	log := logger.Error
+	var pgErr *pgconn.PgError
+	if errors.As(data.Err, &pgErr) && strings.HasPrefix(pgErr.Code, integrityConstraintViolation) {
+		log = logger.Debug
+	}
+
+	sql, _ := ctx.Value(querySQLKey{}).(string)
+	log("database query failed",
+		"error", data.Err,
+		"sql", strings.Join(strings.Fields(sql), " "),
+	)
+}
+
+// multiQueryTracer позволяет повесить на пул несколько pgx.QueryTracer
+type multiQueryTracer []pgx.QueryTracer
+
+func (m multiQueryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	for _, tracer := range m {
+		ctx = tracer.TraceQueryStart(ctx, conn, data)
+	}
+	return ctx
+}
+
+func (m multiQueryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	for i := len(m) - 1; i >= 0; i-- {
+		m[i].TraceQueryEnd(ctx, conn, data)
+	}
+}
@@ -0,0 +1,153 @@
+// Package events - внутрипроцессная шина событий об изменениях назначений.
+// Каждое событие сначала записывается в журнал, затем рассылается подписчикам,
+// поэтому подписчик может догнать пропущенное по ID последнего полученного события.
+package events
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	logpkg "github.com/vnchk1/pr-manager/internal/logger"
+	"github.com/vnchk1/pr-manager/internal/models"
+)
+
+const (
+	// subscriptionBuffer - сколько событий ждут медленного подписчика, прежде чем
+	// подписка будет закрыта
+	subscriptionBuffer = 64
+	// replayBatch - размер страницы при чтении журнала
+	replayBatch = 500
+)
+
+// Store - журнал событий
+type Store interface {
+	Append(ctx context.Context, event *models.Event) error
+	ListAfter(ctx context.Context, afterID int64, filter models.EventFilter, limit int) ([]*models.Event, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type Bus struct {
+	store Store
+
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+}
+
+func NewBus(store Store) *Bus {
+	return &Bus{store: store, subs: make(map[*Subscription]struct{})}
+}
+
+// Publish записывает событие в журнал и рассылает подходящим подписчикам. Ошибка
+// записи не прерывает операцию, вызвавшую событие: она пишется в лог, а событие
+// не рассылается, чтобы поток не расходился с журналом.
+//
+// Запись в журнал не сериализуется между вызовами, поэтому при параллельных
+// публикациях подписчик может получить события не по возрастанию ID. Строгий
+// порядок по ID дает только Replay.
+func (b *Bus) Publish(ctx context.Context, event *models.Event) {
+	if err := b.store.Append(context.WithoutCancel(ctx), event); err != nil {
+		logpkg.FromContext(ctx).Error("failed to append event", "type", event.Type, "error", err)
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs {
+		if !sub.filter.Match(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			// Подписчик не успевает читать: закрываем подписку, клиент переподключится
+			// и догонит пропущенное по журналу
+			b.remove(sub)
+		}
+	}
+}
+
+// Subscribe создает подписку на события, подходящие под фильтр. Подписку нужно
+// закрыть через Unsubscribe.
+func (b *Bus) Subscribe(filter models.EventFilter) *Subscription {
+	sub := &Subscription{filter: filter, events: make(chan *models.Event, subscriptionBuffer)}
+
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+
+	return sub
+}
+
+func (b *Bus) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.remove(sub)
+}
+
+func (b *Bus) remove(sub *Subscription) {
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.events)
+	}
+}
+
+// Replay передает fn события журнала после afterID в порядке ID и возвращает ID
+// последнего из них (afterID, если новых событий нет)
+func (b *Bus) Replay(ctx context.Context, afterID int64, filter models.EventFilter, fn func(*models.Event) error) (int64, error) {
+	for {
+		events, err := b.store.ListAfter(ctx, afterID, filter, replayBatch)
+		if err != nil {
+			return afterID, err
+		}
+
+		for _, event := range events {
+			if err := fn(event); err != nil {
+				return afterID, err
+			}
+			afterID = event.ID
+		}
+
+		if len(events) < replayBatch {
+			return afterID, nil
+		}
+	}
+}
+
+// Run раз в interval удаляет из журнала события старше retention, пока не отменен
+// контекст. Клиент, отставший больше чем на retention, догоняет журнал с самого
+// старого сохраненного события.
+func (b *Bus) Run(ctx context.Context, retention, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := b.store.DeleteBefore(ctx, time.Now().Add(-retention))
+			if err != nil {
+				logger.Error("failed to delete old events", "error", err)
+				continue
+			}
+			if deleted > 0 {
+				logger.Debug("old events deleted", "count", deleted)
+			}
+		}
+	}
+}
+
+type Subscription struct {
+	filter models.EventFilter
+	events chan *models.Event
+}
+
+// Events возвращает канал событий; канал закрывается при отписке или если
+// подписчик отстал
+func (s *Subscription) Events() <-chan *models.Event {
+	return s.events
+}
@@ -0,0 +1,214 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memStore - журнал в памяти с теми же правилами нумерации, что у таблицы events
+type memStore struct {
+	mu     sync.Mutex
+	events []*models.Event
+	fail   error
+}
+
+func (s *memStore) Append(_ context.Context, event *models.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fail != nil {
+		return s.fail
+	}
+	event.ID = int64(len(s.events) + 1)
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *memStore) ListAfter(_ context.Context, afterID int64, filter models.EventFilter, limit int) ([]*models.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []*models.Event
+	for _, event := range s.events {
+		if event.ID > afterID && filter.Match(event) && len(result) < limit {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func (s *memStore) DeleteBefore(_ context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var kept []*models.Event
+	for _, event := range s.events {
+		if !event.CreatedAt.Before(before) {
+			kept = append(kept, event)
+		}
+	}
+	deleted := int64(len(s.events) - len(kept))
+	s.events = kept
+	return deleted, nil
+}
+
+func (s *memStore) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.events)
+}
+
+// slowStore задерживает запись событий команды slow, пока не закрыт release
+type slowStore struct {
+	*memStore
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (s *slowStore) Append(ctx context.Context, event *models.Event) error {
+	if event.TeamName == "slow" {
+		close(s.entered)
+		<-s.release
+	}
+	return s.memStore.Append(ctx, event)
+}
+
+func userEvent(userID, teamName string) *models.Event {
+	return models.NewUserEvent(models.EventUserActivated, &models.User{ID: userID, TeamName: teamName})
+}
+
+func TestPublishDeliversMatchingEvents(t *testing.T) {
+	bus := NewBus(&memStore{})
+	ctx := context.Background()
+
+	all := bus.Subscribe(models.EventFilter{})
+	backend := bus.Subscribe(models.EventFilter{TeamName: "backend"})
+	bob := bus.Subscribe(models.EventFilter{UserID: "u2"})
+
+	bus.Publish(ctx, userEvent("u1", "backend"))
+	bus.Publish(ctx, userEvent("u2", "frontend"))
+
+	assert.Equal(t, int64(1), (<-all.Events()).ID)
+	assert.Equal(t, int64(2), (<-all.Events()).ID)
+	assert.Equal(t, int64(1), (<-backend.Events()).ID)
+	assert.Equal(t, int64(2), (<-bob.Events()).ID)
+	assert.Empty(t, backend.Events())
+	assert.Empty(t, bob.Events())
+}
+
+func TestSlowSubscriberIsDropped(t *testing.T) {
+	bus := NewBus(&memStore{})
+	sub := bus.Subscribe(models.EventFilter{})
+
+	for range subscriptionBuffer + 1 {
+		bus.Publish(context.Background(), userEvent("u1", "backend"))
+	}
+
+	received := 0
+	for range sub.Events() {
+		received++
+	}
+	assert.Equal(t, subscriptionBuffer, received, "channel is closed after the buffer overflows")
+
+	// Повторная отписка закрытой подписки безопасна
+	bus.Unsubscribe(sub)
+}
+
+// TestSlowAppendDoesNotBlockPublishers проверяет, что медленная запись в журнал
+// не задерживает остальные публикации
+func TestSlowAppendDoesNotBlockPublishers(t *testing.T) {
+	store := &slowStore{memStore: &memStore{}, entered: make(chan struct{}), release: make(chan struct{})}
+	bus := NewBus(store)
+	sub := bus.Subscribe(models.EventFilter{})
+
+	published := make(chan struct{})
+	go func() {
+		bus.Publish(context.Background(), userEvent("u1", "slow"))
+		close(published)
+	}()
+	<-store.entered
+
+	bus.Publish(context.Background(), userEvent("u2", "backend"))
+	select {
+	case event := <-sub.Events():
+		assert.Equal(t, "backend", event.TeamName)
+	case <-time.After(time.Second):
+		t.Fatal("publish waited for a slow append")
+	}
+
+	close(store.release)
+	<-published
+	assert.Equal(t, "slow", (<-sub.Events()).TeamName)
+}
+
+func TestRunDeletesOldEvents(t *testing.T) {
+	now := time.Now()
+	store := &memStore{events: []*models.Event{
+		{ID: 1, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: 2, CreatedAt: now.Add(-90 * time.Minute)},
+		{ID: 3, CreatedAt: now},
+	}}
+	bus := NewBus(store)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go bus.Run(ctx, time.Hour, 10*time.Millisecond, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	require.Eventually(t, func() bool { return store.len() == 1 }, time.Second, 10*time.Millisecond)
+
+	var ids []int64
+	_, err := bus.Replay(ctx, 0, models.EventFilter{}, func(event *models.Event) error {
+		ids = append(ids, event.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3}, ids)
+}
+
+func TestFailedAppendIsNotDelivered(t *testing.T) {
+	store := &memStore{fail: errors.New("connection refused")}
+	bus := NewBus(store)
+	sub := bus.Subscribe(models.EventFilter{})
+
+	bus.Publish(context.Background(), userEvent("u1", "backend"))
+	assert.Empty(t, sub.Events())
+}
+
+func TestReplayReadsAllPages(t *testing.T) {
+	store := &memStore{}
+	bus := NewBus(store)
+	for i := range replayBatch*2 + 3 {
+		team := "backend"
+		if i%2 == 1 {
+			team = "frontend"
+		}
+		bus.Publish(context.Background(), userEvent("u1", team))
+	}
+
+	var ids []int64
+	lastID, err := bus.Replay(context.Background(), 10, models.EventFilter{}, func(event *models.Event) error {
+		ids = append(ids, event.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(replayBatch*2+3), lastID)
+	assert.Len(t, ids, replayBatch*2+3-10)
+	assert.Equal(t, int64(11), ids[0])
+
+	lastID, err = bus.Replay(context.Background(), lastID, models.EventFilter{TeamName: "backend"}, func(*models.Event) error {
+		t.Fatal("no events after the last one")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(replayBatch*2+3), lastID)
+}
@@ -0,0 +1,61 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	logpkg "github.com/vnchk1/pr-manager/internal/logger"
+	"github.com/vnchk1/pr-manager/internal/models"
+)
+
+// Коды ошибок в extensions.code совпадают с кодами HTTP API v1
+const (
+	codeInvalidArgument = "INVALID_ARGUMENT"
+	codeNotFound        = "NOT_FOUND"
+	codeInternal        = "INTERNAL"
+)
+
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{models.ErrNotFound, codeNotFound},
+	{models.ErrInvalidCursor, codeInvalidArgument},
+	{models.ErrInvalidSort, codeInvalidArgument},
+}
+
+// queryError - ошибка резолвера с кодом; graphql-go переносит Extensions в ответ
+type queryError struct {
+	message string
+	code    string
+}
+
+func (e *queryError) Error() string {
+	return e.message
+}
+
+func (e *queryError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+func invalidArgument(message string) error {
+	return &queryError{message: message, code: codeInvalidArgument}
+}
+
+// resolverError переводит ошибку сервиса в ошибку GraphQL. Текст неизвестных ошибок
+// клиенту не отдается, они пишутся в лог.
+func resolverError(ctx context.Context, err error) error {
+	var qerr *queryError
+	if errors.As(err, &qerr) {
+		return qerr
+	}
+
+	for _, mapping := range errorCodes {
+		if errors.Is(err, mapping.err) {
+			return &queryError{message: mapping.err.Error(), code: mapping.code}
+		}
+	}
+
+	logpkg.FromContext(ctx).Error("graphql resolver failed", "error", err)
+	return &queryError{message: "internal error", code: codeInternal}
+}
@@ -0,0 +1,68 @@
+// Package graphql реализует GraphQL API (POST /graphql) для чтения команд, пользователей,
+// PR и статистики. Связанные объекты загружаются через dataloader, чтобы запрос
+// вида команда -> участники -> ревью -> авторы не выполнял запрос к базе на каждый узел.
+package graphql
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+
+	"github.com/vnchk1/pr-manager/internal/service"
+
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+const (
+	// maxDepth ограничивает вложенность запроса: связи пользователь -> команда -> участники
+	// позволяют строить запросы произвольной глубины
+	maxDepth = 12
+	// maxParallelism - сколько резолверов одного запроса выполняются одновременно.
+	// Ключи, не попавшие в окно, уходят следующим пакетом.
+	maxParallelism = 100
+)
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type Handler struct {
+	schema *gql.Schema
+	svc    *service.Service
+}
+
+func NewHandler(svc *service.Service) *Handler {
+	schema := gql.MustParseSchema(schemaSDL, &queryResolver{svc: svc},
+		gql.MaxDepth(maxDepth),
+		gql.MaxParallelism(maxParallelism),
+	)
+
+	return &Handler{schema: schema, svc: svc}
+}
+
+// ServeHTTP выполняет запрос со своим набором загрузчиков. Ошибки резолверов
+// возвращаются в поле errors со статусом 200, как принято в GraphQL.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ctx := withLoaders(r.Context(), newLoaders(h.svc))
+	resp := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	body, err := json.Marshal(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,304 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// calls считает обращения к сервисам, чтобы проверить пакетную загрузку
+type calls struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (c *calls) add(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[method]++
+}
+
+func (c *calls) get(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[method]
+}
+
+var (
+	testTime = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	users    = map[string]*models.User{
+		"u1": {ID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		"u2": {ID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		"u3": {ID: "u3", Username: "Carol", TeamName: "backend", IsActive: false},
+		"u4": {ID: "u4", Username: "Dave", TeamName: "frontend", IsActive: true},
+	}
+	prs = map[string]*models.PullRequest{
+		"pr-1": {ID: "pr-1", Name: "Add search", AuthorID: "u4", Status: models.StatusOpen, AssignedReviewers: []string{"u1", "u2"}, CreatedAt: testTime},
+		"pr-2": {ID: "pr-2", Name: "Fix login", AuthorID: "u1", Status: models.StatusOpen, AssignedReviewers: []string{"u2", "u9"}, CreatedAt: testTime},
+		"pr-3": {ID: "pr-3", Name: "Old change", AuthorID: "u2", Status: models.StatusMerged, AssignedReviewers: []string{"u1"}, CreatedAt: testTime},
+	}
+	reviewStates = map[[2]string]models.ReviewState{
+		{"pr-1", "u2"}: models.ReviewApproved,
+	}
+)
+
+type fakeTeamService struct {
+	service.TeamService
+	calls *calls
+}
+
+func (s fakeTeamService) GetByNames(_ context.Context, teamNames []string) ([]*models.Team, error) {
+	s.calls.add("Team.GetByNames")
+
+	var teams []*models.Team
+	for _, name := range teamNames {
+		team := &models.Team{Name: name, CreatedAt: testTime, UpdatedAt: testTime}
+		for _, id := range []string{"u1", "u2", "u3", "u4"} {
+			if users[id].TeamName == name {
+				team.Members = append(team.Members, users[id])
+			}
+		}
+		if team.Members != nil {
+			teams = append(teams, team)
+		}
+	}
+	return teams, nil
+}
+
+func (s fakeTeamService) List(ctx context.Context) ([]*models.Team, error) {
+	return s.GetByNames(ctx, []string{"backend", "frontend"})
+}
+
+type fakeUserService struct {
+	service.UserService
+	calls *calls
+}
+
+func (s fakeUserService) GetByIDs(_ context.Context, userIDs []string) ([]*models.User, error) {
+	s.calls.add("User.GetByIDs")
+
+	var found []*models.User
+	for _, id := range userIDs {
+		if user, ok := users[id]; ok {
+			found = append(found, user)
+		}
+	}
+	return found, nil
+}
+
+type fakePRService struct {
+	service.PRService
+	calls *calls
+}
+
+func (s fakePRService) GetByIDs(_ context.Context, prIDs []string) ([]*models.PullRequest, error) {
+	s.calls.add("PR.GetByIDs")
+
+	var found []*models.PullRequest
+	for _, id := range prIDs {
+		if pr, ok := prs[id]; ok {
+			found = append(found, pr)
+		}
+	}
+	return found, nil
+}
+
+func (s fakePRService) GetReviewsByReviewers(_ context.Context, reviewerIDs []string, status models.PullRequestStatus) (map[string][]*models.ReviewPR, error) {
+	s.calls.add("PR.GetReviewsByReviewers")
+
+	reviews := make(map[string][]*models.ReviewPR)
+	for _, reviewerID := range reviewerIDs {
+		for _, id := range []string{"pr-1", "pr-2", "pr-3"} {
+			pr := prs[id]
+			if status != "" && pr.Status != status {
+				continue
+			}
+			for _, assigned := range pr.AssignedReviewers {
+				if assigned != reviewerID {
+					continue
+				}
+				state, ok := reviewStates[[2]string{id, reviewerID}]
+				if !ok {
+					state = models.ReviewPending
+				}
+				reviews[reviewerID] = append(reviews[reviewerID], &models.ReviewPR{
+					PullRequestShort: models.PullRequestShort{ID: pr.ID, Name: pr.Name, AuthorID: pr.AuthorID, Status: pr.Status},
+					ReviewState:      state,
+				})
+			}
+		}
+	}
+	return reviews, nil
+}
+
+func (s fakePRService) List(_ context.Context, filter models.PRFilter, _ models.PageRequest) (*models.PRPage, error) {
+	if filter.ReviewerID == "u404" {
+		return nil, models.ErrNotFound
+	}
+	if filter.AuthorID == "broken" {
+		return nil, errors.New("connection refused")
+	}
+	return &models.PRPage{PullRequests: []*models.PullRequest{prs["pr-1"]}}, nil
+}
+
+type fakeStatsService struct {
+	service.StatsService
+}
+
+func (fakeStatsService) GetFairnessReport(_ context.Context, giniThreshold float64) (*models.FairnessReport, error) {
+	return &models.FairnessReport{GiniThreshold: giniThreshold}, nil
+}
+
+func newTestHandler() (*Handler, *calls) {
+	c := &calls{counts: make(map[string]int)}
+	svc := &service.Service{
+		Team:  fakeTeamService{calls: c},
+		User:  fakeUserService{calls: c},
+		PR:    fakePRService{calls: c},
+		Stats: fakeStatsService{},
+	}
+	return NewHandler(svc), c
+}
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string            `json:"message"`
+		Extensions map[string]string `json:"extensions"`
+	} `json:"errors"`
+}
+
+func execute(t *testing.T, h *Handler, query string, variables map[string]interface{}) response {
+	t.Helper()
+
+	body, err := json.Marshal(request{Query: query, Variables: variables})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var resp response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp
+}
+
+func TestDashboardQueryIsBatched(t *testing.T) {
+	h, c := newTestHandler()
+
+	resp := execute(t, h, `
+		query Dashboard($team: String!) {
+			team(name: $team) {
+				name
+				members {
+					username
+					reviews(status: OPEN) {
+						state
+						pullRequest {
+							name
+							author { username team { name } }
+							reviewers { id }
+						}
+					}
+				}
+			}
+		}
+	`, map[string]interface{}{"team": "backend"})
+	require.Empty(t, resp.Errors)
+
+	assert.JSONEq(t, `{"team": {
+		"name": "backend",
+		"members": [
+			{"username": "Alice", "reviews": [
+				{"state": "PENDING", "pullRequest": {"name": "Add search", "author": {"username": "Dave", "team": {"name": "frontend"}}, "reviewers": [{"id": "u1"}, {"id": "u2"}]}}
+			]},
+			{"username": "Bob", "reviews": [
+				{"state": "APPROVED", "pullRequest": {"name": "Add search", "author": {"username": "Dave", "team": {"name": "frontend"}}, "reviewers": [{"id": "u1"}, {"id": "u2"}]}},
+				{"state": "PENDING", "pullRequest": {"name": "Fix login", "author": {"username": "Alice", "team": {"name": "backend"}}, "reviewers": [{"id": "u2"}]}}
+			]},
+			{"username": "Carol", "reviews": []}
+		]
+	}}`, string(resp.Data))
+
+	// Каждый уровень запроса - одно обращение к сервису, независимо от числа узлов.
+	// Команды загружаются дважды: сама команда, затем команды авторов.
+	assert.Equal(t, 2, c.get("Team.GetByNames"))
+	assert.Equal(t, 1, c.get("PR.GetReviewsByReviewers"))
+	assert.Equal(t, 1, c.get("PR.GetByIDs"))
+	assert.Equal(t, 1, c.get("User.GetByIDs"))
+}
+
+func TestLoadersAreScopedToRequest(t *testing.T) {
+	h, c := newTestHandler()
+
+	for range 2 {
+		resp := execute(t, h, `{ user(id: "u1") { username } }`, nil)
+		require.Empty(t, resp.Errors)
+		assert.JSONEq(t, `{"user": {"username": "Alice"}}`, string(resp.Data))
+	}
+	assert.Equal(t, 2, c.get("User.GetByIDs"))
+}
+
+func TestMissingObjectsAreNull(t *testing.T) {
+	h, _ := newTestHandler()
+
+	resp := execute(t, h, `{ team(name: "mobile") { name } user(id: "u404") { id } pullRequest(id: "pr-404") { id } }`, nil)
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"team": null, "user": null, "pullRequest": null}`, string(resp.Data))
+}
+
+func TestErrors(t *testing.T) {
+	h, _ := newTestHandler()
+
+	tests := []struct {
+		name    string
+		query   string
+		code    string
+		message string
+	}{
+		{"Invalid cursor", `{ pullRequests(after: "%%%") { nextCursor } }`, codeInvalidArgument, models.ErrInvalidCursor.Error()},
+		{"Page too large", `{ pullRequests(first: 1000) { nextCursor } }`, codeInvalidArgument, "first must be between 0 and 200"},
+		{"Unknown reviewer", `{ pullRequests(reviewerId: "u404") { nextCursor } }`, codeNotFound, models.ErrNotFound.Error()},
+		{"Unknown error is hidden", `{ pullRequests(authorId: "broken") { nextCursor } }`, codeInternal, "internal error"},
+		{"Invalid gini threshold", `{ fairness(giniThreshold: 1.5) { giniThreshold } }`, codeInvalidArgument, "giniThreshold must be a number between 0 and 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := execute(t, h, tt.query, nil)
+			require.Len(t, resp.Errors, 1)
+			assert.Equal(t, tt.message, resp.Errors[0].Message)
+			assert.Equal(t, tt.code, resp.Errors[0].Extensions["code"])
+		})
+	}
+}
+
+func TestQueryDepthIsLimited(t *testing.T) {
+	h, _ := newTestHandler()
+
+	query := "{ user(id: \"u1\") { " + strings.Repeat("team { members { ", 6) + "id" + strings.Repeat(" } }", 6) + " } }"
+	resp := execute(t, h, query, nil)
+	require.NotEmpty(t, resp.Errors)
+	assert.Contains(t, resp.Errors[0].Message, "exceeds max depth")
+}
+
+func TestInvalidBody(t *testing.T) {
+	h, _ := newTestHandler()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader("{")))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
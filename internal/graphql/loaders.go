@@ -0,0 +1,116 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/service"
+
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+// batchWait - сколько загрузчик ждет остальные ключи перед запросом к сервису.
+// Резолверы элементов списка запускаются параллельно и успевают поставить
+// свои ключи в один пакет.
+const batchWait = 10 * time.Millisecond
+
+// reviewsKey - очередь ревью одного ревьювера с фильтром по статусу PR
+type reviewsKey struct {
+	reviewerID string
+	status     models.PullRequestStatus
+}
+
+// loaders собирают обращения резолверов к сервисам в пакетные запросы и кешируют
+// результат. Создаются на каждый запрос, поэтому данные между запросами не переиспользуются.
+type loaders struct {
+	users   *dataloader.Loader[string, *models.User]
+	teams   *dataloader.Loader[string, *models.Team]
+	prs     *dataloader.Loader[string, *models.PullRequest]
+	reviews *dataloader.Loader[reviewsKey, []*models.ReviewPR]
+}
+
+func newLoaders(svc *service.Service) *loaders {
+	return &loaders{
+		users: dataloader.NewBatchedLoader(
+			batchByKey(svc.User.GetByIDs, func(user *models.User) string { return user.ID }),
+			dataloader.WithWait[string, *models.User](batchWait),
+		),
+		teams: dataloader.NewBatchedLoader(
+			batchByKey(svc.Team.GetByNames, func(team *models.Team) string { return team.Name }),
+			dataloader.WithWait[string, *models.Team](batchWait),
+		),
+		prs: dataloader.NewBatchedLoader(
+			batchByKey(svc.PR.GetByIDs, func(pr *models.PullRequest) string { return pr.ID }),
+			dataloader.WithWait[string, *models.PullRequest](batchWait),
+		),
+		reviews: dataloader.NewBatchedLoader(
+			batchReviews(svc.PR),
+			dataloader.WithWait[reviewsKey, []*models.ReviewPR](batchWait),
+		),
+	}
+}
+
+// batchByKey строит пакетную функцию поверх метода, который возвращает найденные
+// записи в произвольном порядке. Для отсутствующих ключей результатом будет nil.
+func batchByKey[V any](
+	fetch func(ctx context.Context, keys []string) ([]V, error),
+	key func(V) string,
+) dataloader.BatchFunc[string, V] {
+	return func(ctx context.Context, keys []string) []*dataloader.Result[V] {
+		results := make([]*dataloader.Result[V], len(keys))
+
+		values, err := fetch(ctx, keys)
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[V]{Error: err}
+			}
+			return results
+		}
+
+		byKey := make(map[string]V, len(values))
+		for _, value := range values {
+			byKey[key(value)] = value
+		}
+		for i, k := range keys {
+			results[i] = &dataloader.Result[V]{Data: byKey[k]}
+		}
+		return results
+	}
+}
+
+// batchReviews делает по одному запросу на каждый статус, встретившийся в пакете
+func batchReviews(prs service.PRService) dataloader.BatchFunc[reviewsKey, []*models.ReviewPR] {
+	return func(ctx context.Context, keys []reviewsKey) []*dataloader.Result[[]*models.ReviewPR] {
+		reviewers := make(map[models.PullRequestStatus][]string)
+		for _, key := range keys {
+			reviewers[key.status] = append(reviewers[key.status], key.reviewerID)
+		}
+
+		reviews := make(map[models.PullRequestStatus]map[string][]*models.ReviewPR, len(reviewers))
+		errs := make(map[models.PullRequestStatus]error)
+		for status, ids := range reviewers {
+			reviews[status], errs[status] = prs.GetReviewsByReviewers(ctx, ids, status)
+		}
+
+		results := make([]*dataloader.Result[[]*models.ReviewPR], len(keys))
+		for i, key := range keys {
+			if err := errs[key.status]; err != nil {
+				results[i] = &dataloader.Result[[]*models.ReviewPR]{Error: err}
+				continue
+			}
+			results[i] = &dataloader.Result[[]*models.ReviewPR]{Data: reviews[key.status][key.reviewerID]}
+		}
+		return results
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
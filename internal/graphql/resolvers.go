@@ -0,0 +1,563 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/service"
+
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+type queryResolver struct {
+	svc *service.Service
+}
+
+func (r *queryResolver) Team(ctx context.Context, args struct{ Name string }) (*teamResolver, error) {
+	return loadTeam(ctx, args.Name)
+}
+
+func (r *queryResolver) Teams(ctx context.Context) ([]*teamResolver, error) {
+	teams, err := r.svc.Team.List(ctx)
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+
+	result := make([]*teamResolver, len(teams))
+	for i, team := range teams {
+		result[i] = &teamResolver{team: team}
+	}
+	return result, nil
+}
+
+func (r *queryResolver) User(ctx context.Context, args struct{ ID gql.ID }) (*userResolver, error) {
+	return loadUser(ctx, string(args.ID))
+}
+
+func (r *queryResolver) PullRequest(ctx context.Context, args struct{ ID gql.ID }) (*pullRequestResolver, error) {
+	return loadPullRequest(ctx, string(args.ID))
+}
+
+type pullRequestsArgs struct {
+	AuthorID    *gql.ID
+	ReviewerID  *gql.ID
+	TeamName    *string
+	Status      *string
+	CreatedFrom *gql.Time
+	CreatedTo   *gql.Time
+	First       *int32
+	After       *string
+	Order       *string
+}
+
+func (r *queryResolver) PullRequests(ctx context.Context, args pullRequestsArgs) (*pullRequestConnectionResolver, error) {
+	page, err := pageRequest(args.First, args.After, args.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := models.PRFilter{
+		AuthorID:   idValue(args.AuthorID),
+		ReviewerID: idValue(args.ReviewerID),
+		TeamName:   stringValue(args.TeamName),
+		Status:     models.PullRequestStatus(stringValue(args.Status)),
+	}
+	if args.CreatedFrom != nil {
+		filter.CreatedFrom = &args.CreatedFrom.Time
+	}
+	if args.CreatedTo != nil {
+		filter.CreatedTo = &args.CreatedTo.Time
+	}
+
+	result, err := r.svc.PR.List(ctx, filter, page)
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+
+	return &pullRequestConnectionResolver{page: result}, nil
+}
+
+type assignmentStatsArgs struct {
+	TeamName *string
+	Sort     *string
+	First    *int32
+	After    *string
+}
+
+func (r *queryResolver) AssignmentStats(ctx context.Context, args assignmentStatsArgs) (*assignmentStatsResolver, error) {
+	page, err := pageRequest(args.First, args.After, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := r.svc.Stats.GetAssignmentStats(ctx, models.AssignmentStatsFilter{
+		TeamName: stringValue(args.TeamName),
+		Sort:     strings.ToLower(stringValue(args.Sort)),
+	}, page)
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+
+	return &assignmentStatsResolver{stats: stats}, nil
+}
+
+func (r *queryResolver) Fairness(ctx context.Context, args struct{ GiniThreshold *float64 }) (*fairnessReportResolver, error) {
+	giniThreshold := models.DefaultGiniThreshold
+	if args.GiniThreshold != nil {
+		giniThreshold = *args.GiniThreshold
+		if giniThreshold < 0 || giniThreshold > 1 {
+			return nil, invalidArgument("giniThreshold must be a number between 0 and 1")
+		}
+	}
+
+	report, err := r.svc.Stats.GetFairnessReport(ctx, giniThreshold)
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+
+	return &fairnessReportResolver{report: report}, nil
+}
+
+// pageRequest проверяет параметры страницы так же, как HTTP API
+func pageRequest(first *int32, after, order *string) (models.PageRequest, error) {
+	var page models.PageRequest
+
+	if first != nil {
+		if *first < 0 || *first > models.MaxPageLimit {
+			return page, invalidArgument("first must be between 0 and " + strconv.Itoa(models.MaxPageLimit))
+		}
+		page.Limit = int(*first)
+	}
+
+	if after != nil && *after != "" {
+		cursor, err := models.DecodeCursor(*after)
+		if err != nil {
+			return page, invalidArgument(err.Error())
+		}
+		page.Cursor = cursor
+	}
+
+	if order != nil {
+		page.Order = models.SortOrder(strings.ToLower(*order))
+	}
+
+	return page, nil
+}
+
+func loadTeam(ctx context.Context, name string) (*teamResolver, error) {
+	team, err := loadersFrom(ctx).teams.Load(ctx, name)()
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	if team == nil {
+		return nil, nil
+	}
+	return &teamResolver{team: team}, nil
+}
+
+func loadUser(ctx context.Context, id string) (*userResolver, error) {
+	user, err := loadersFrom(ctx).users.Load(ctx, id)()
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	if user == nil {
+		return nil, nil
+	}
+	return &userResolver{user: user}, nil
+}
+
+func loadPullRequest(ctx context.Context, id string) (*pullRequestResolver, error) {
+	pr, err := loadersFrom(ctx).prs.Load(ctx, id)()
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	if pr == nil {
+		return nil, nil
+	}
+	return &pullRequestResolver{pr: pr}, nil
+}
+
+type teamResolver struct {
+	team *models.Team
+}
+
+func (r *teamResolver) Name() string {
+	return r.team.Name
+}
+
+func (r *teamResolver) Members() []*userResolver {
+	result := make([]*userResolver, len(r.team.Members))
+	for i, member := range r.team.Members {
+		result[i] = &userResolver{user: member}
+	}
+	return result
+}
+
+func (r *teamResolver) CreatedAt() gql.Time {
+	return gql.Time{Time: r.team.CreatedAt}
+}
+
+func (r *teamResolver) UpdatedAt() gql.Time {
+	return gql.Time{Time: r.team.UpdatedAt}
+}
+
+type userResolver struct {
+	user *models.User
+}
+
+func (r *userResolver) ID() gql.ID {
+	return gql.ID(r.user.ID)
+}
+
+func (r *userResolver) Username() string {
+	return r.user.Username
+}
+
+func (r *userResolver) TeamName() string {
+	return r.user.TeamName
+}
+
+func (r *userResolver) IsActive() bool {
+	return r.user.IsActive
+}
+
+func (r *userResolver) Team(ctx context.Context) (*teamResolver, error) {
+	if r.user.TeamName == "" {
+		return nil, nil
+	}
+	return loadTeam(ctx, r.user.TeamName)
+}
+
+func (r *userResolver) Reviews(ctx context.Context, args struct{ Status *string }) ([]*reviewResolver, error) {
+	key := reviewsKey{reviewerID: r.user.ID, status: models.PullRequestStatus(stringValue(args.Status))}
+	reviews, err := loadersFrom(ctx).reviews.Load(ctx, key)()
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+
+	result := make([]*reviewResolver, len(reviews))
+	for i, review := range reviews {
+		result[i] = &reviewResolver{review: review}
+	}
+	return result, nil
+}
+
+type reviewResolver struct {
+	review *models.ReviewPR
+}
+
+func (r *reviewResolver) State() string {
+	return string(r.review.ReviewState)
+}
+
+func (r *reviewResolver) PullRequest(ctx context.Context) (*pullRequestResolver, error) {
+	pr, err := loadPullRequest(ctx, r.review.ID)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil {
+		return nil, resolverError(ctx, models.ErrNotFound)
+	}
+	return pr, nil
+}
+
+type pullRequestResolver struct {
+	pr *models.PullRequest
+}
+
+func (r *pullRequestResolver) ID() gql.ID {
+	return gql.ID(r.pr.ID)
+}
+
+func (r *pullRequestResolver) Name() string {
+	return r.pr.Name
+}
+
+func (r *pullRequestResolver) Status() string {
+	return string(r.pr.Status)
+}
+
+func (r *pullRequestResolver) Author(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, r.pr.AuthorID)
+}
+
+// Reviewers пропускает ревьюверов, которых уже нет в базе
+func (r *pullRequestResolver) Reviewers(ctx context.Context) ([]*userResolver, error) {
+	users, errs := loadersFrom(ctx).users.LoadMany(ctx, r.pr.AssignedReviewers)()
+	if err := errors.Join(errs...); err != nil {
+		return nil, resolverError(ctx, err)
+	}
+
+	result := make([]*userResolver, 0, len(users))
+	for _, user := range users {
+		if user != nil {
+			result = append(result, &userResolver{user: user})
+		}
+	}
+	return result, nil
+}
+
+func (r *pullRequestResolver) CreatedAt() gql.Time {
+	return gql.Time{Time: r.pr.CreatedAt}
+}
+
+func (r *pullRequestResolver) MergedAt() *gql.Time {
+	if r.pr.MergedAt == nil {
+		return nil
+	}
+	return &gql.Time{Time: *r.pr.MergedAt}
+}
+
+func (r *pullRequestResolver) UpdatedAt() gql.Time {
+	return gql.Time{Time: r.pr.UpdatedAt}
+}
+
+type pullRequestConnectionResolver struct {
+	page *models.PRPage
+}
+
+func (r *pullRequestConnectionResolver) Nodes() []*pullRequestResolver {
+	result := make([]*pullRequestResolver, len(r.page.PullRequests))
+	for i, pr := range r.page.PullRequests {
+		result[i] = &pullRequestResolver{pr: pr}
+	}
+	return result
+}
+
+func (r *pullRequestConnectionResolver) NextCursor() *string {
+	return optionalString(r.page.NextCursor)
+}
+
+type userStatsResolver struct {
+	stats *models.UserAssignmentStats
+}
+
+func (r *userStatsResolver) User(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, r.stats.UserID)
+}
+
+func (r *userStatsResolver) UserID() gql.ID {
+	return gql.ID(r.stats.UserID)
+}
+
+func (r *userStatsResolver) Username() string {
+	return r.stats.Username
+}
+
+func (r *userStatsResolver) TeamName() string {
+	return r.stats.TeamName
+}
+
+func (r *userStatsResolver) IsActive() bool {
+	return r.stats.IsActive
+}
+
+func (r *userStatsResolver) AssignmentCount() int32 {
+	return int32(r.stats.AssignmentCount)
+}
+
+type prStatsResolver struct {
+	stats *models.PRAssignmentStats
+}
+
+func (r *prStatsResolver) TotalPrs() int32 {
+	return int32(r.stats.TotalPRs)
+}
+
+func (r *prStatsResolver) OpenPrs() int32 {
+	return int32(r.stats.OpenPRs)
+}
+
+func (r *prStatsResolver) MergedPrs() int32 {
+	return int32(r.stats.MergedPRs)
+}
+
+func (r *prStatsResolver) AvgReviewersPerPr() float64 {
+	return r.stats.AvgReviewersPerPR
+}
+
+func (r *prStatsResolver) PrsWithNoReviewers() int32 {
+	return int32(r.stats.PRsWithNoReviewers)
+}
+
+func (r *prStatsResolver) PrsWithOneReviewer() int32 {
+	return int32(r.stats.PRsWithOneReviewer)
+}
+
+func (r *prStatsResolver) PrsWithTwoReviewers() int32 {
+	return int32(r.stats.PRsWithTwoReviewers)
+}
+
+type summaryResolver struct {
+	summary *models.StatsSummary
+}
+
+func (r *summaryResolver) TotalUsers() int32 {
+	return int32(r.summary.TotalUsers)
+}
+
+func (r *summaryResolver) ActiveUsers() int32 {
+	return int32(r.summary.ActiveUsers)
+}
+
+func (r *summaryResolver) TotalAssignments() int32 {
+	return int32(r.summary.TotalAssignments)
+}
+
+func (r *summaryResolver) MostAssignedUser() *string {
+	return optionalString(r.summary.MostAssignedUser)
+}
+
+func (r *summaryResolver) MostAssignments() int32 {
+	return int32(r.summary.MostAssignments)
+}
+
+type assignmentStatsResolver struct {
+	stats *models.AssignmentStatsResponse
+}
+
+func (r *assignmentStatsResolver) Users() []*userStatsResolver {
+	result := make([]*userStatsResolver, len(r.stats.UserStats))
+	for i, stats := range r.stats.UserStats {
+		result[i] = &userStatsResolver{stats: stats}
+	}
+	return result
+}
+
+func (r *assignmentStatsResolver) PrStats() *prStatsResolver {
+	if r.stats.PRStats == nil {
+		return nil
+	}
+	return &prStatsResolver{stats: r.stats.PRStats}
+}
+
+func (r *assignmentStatsResolver) Summary() *summaryResolver {
+	if r.stats.Summary == nil {
+		return nil
+	}
+	return &summaryResolver{summary: r.stats.Summary}
+}
+
+func (r *assignmentStatsResolver) NextCursor() *string {
+	return optionalString(r.stats.NextCursor)
+}
+
+type memberFairnessResolver struct {
+	member *models.MemberFairness
+}
+
+func (r *memberFairnessResolver) UserID() gql.ID {
+	return gql.ID(r.member.UserID)
+}
+
+func (r *memberFairnessResolver) Username() string {
+	return r.member.Username
+}
+
+func (r *memberFairnessResolver) AssignmentCount() int32 {
+	return int32(r.member.AssignmentCount)
+}
+
+func (r *memberFairnessResolver) DaysActive() float64 {
+	return r.member.DaysActive
+}
+
+func (r *memberFairnessResolver) AssignmentsPerDay() float64 {
+	return r.member.AssignmentsPerDay
+}
+
+func memberFairness(members []*models.MemberFairness) []*memberFairnessResolver {
+	result := make([]*memberFairnessResolver, len(members))
+	for i, member := range members {
+		result[i] = &memberFairnessResolver{member: member}
+	}
+	return result
+}
+
+type teamFairnessResolver struct {
+	team *models.TeamFairness
+}
+
+func (r *teamFairnessResolver) TeamName() string {
+	return r.team.TeamName
+}
+
+func (r *teamFairnessResolver) ActiveMembers() int32 {
+	return int32(r.team.ActiveMembers)
+}
+
+func (r *teamFairnessResolver) TotalAssignments() int32 {
+	return int32(r.team.TotalAssignments)
+}
+
+func (r *teamFairnessResolver) MeanAssignmentsPerDay() float64 {
+	return r.team.MeanPerDay
+}
+
+func (r *teamFairnessResolver) StdDev() float64 {
+	return r.team.StdDev
+}
+
+func (r *teamFairnessResolver) Gini() float64 {
+	return r.team.Gini
+}
+
+func (r *teamFairnessResolver) Imbalanced() bool {
+	return r.team.Imbalanced
+}
+
+func (r *teamFairnessResolver) Overloaded() []*memberFairnessResolver {
+	return memberFairness(r.team.Overloaded)
+}
+
+func (r *teamFairnessResolver) Underloaded() []*memberFairnessResolver {
+	return memberFairness(r.team.Underloaded)
+}
+
+func (r *teamFairnessResolver) Members() []*memberFairnessResolver {
+	return memberFairness(r.team.Members)
+}
+
+type fairnessReportResolver struct {
+	report *models.FairnessReport
+}
+
+func (r *fairnessReportResolver) GiniThreshold() float64 {
+	return r.report.GiniThreshold
+}
+
+func (r *fairnessReportResolver) Imbalanced() bool {
+	return r.report.Imbalanced
+}
+
+func (r *fairnessReportResolver) Teams() []*teamFairnessResolver {
+	result := make([]*teamFairnessResolver, len(r.report.Teams))
+	for i, team := range r.report.Teams {
+		result[i] = &teamFairnessResolver{team: team}
+	}
+	return result
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func idValue(id *gql.ID) string {
+	if id == nil {
+		return ""
+	}
+	return string(*id)
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
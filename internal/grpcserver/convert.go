@@ -0,0 +1,282 @@
+package grpcserver
+
+import (
+	"strconv"
+	"time"
+
+	pb "github.com/vnchk1/pr-manager/api/prmanager/v1"
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// timestamp не заполняет поле для нулевого времени
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func toUser(user *models.User) *pb.User {
+	return &pb.User{
+		UserId:    user.ID,
+		Username:  user.Username,
+		TeamName:  user.TeamName,
+		IsActive:  user.IsActive,
+		CreatedAt: timestamp(user.CreatedAt),
+		UpdatedAt: timestamp(user.UpdatedAt),
+	}
+}
+
+func toUsers(users []*models.User) []*pb.User {
+	result := make([]*pb.User, len(users))
+	for i, user := range users {
+		result[i] = toUser(user)
+	}
+	return result
+}
+
+func toTeam(team *models.Team) *pb.Team {
+	return &pb.Team{
+		TeamName:  team.Name,
+		Members:   toUsers(team.Members),
+		CreatedAt: timestamp(team.CreatedAt),
+		UpdatedAt: timestamp(team.UpdatedAt),
+	}
+}
+
+var prStatuses = map[models.PullRequestStatus]pb.PullRequestStatus{
+	models.StatusOpen:   pb.PullRequestStatus_PULL_REQUEST_STATUS_OPEN,
+	models.StatusMerged: pb.PullRequestStatus_PULL_REQUEST_STATUS_MERGED,
+}
+
+func toPRStatus(s models.PullRequestStatus) pb.PullRequestStatus {
+	return prStatuses[s]
+}
+
+// fromPRStatus переводит фильтр статуса; UNSPECIFIED означает любой статус
+func fromPRStatus(s pb.PullRequestStatus) (models.PullRequestStatus, error) {
+	if s == pb.PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED {
+		return "", nil
+	}
+	for status, value := range prStatuses {
+		if value == s {
+			return status, nil
+		}
+	}
+	return "", invalidArgument("unknown pull request status")
+}
+
+var reviewStates = map[models.ReviewState]pb.ReviewState{
+	models.ReviewPending:          pb.ReviewState_REVIEW_STATE_PENDING,
+	models.ReviewApproved:         pb.ReviewState_REVIEW_STATE_APPROVED,
+	models.ReviewChangesRequested: pb.ReviewState_REVIEW_STATE_CHANGES_REQUESTED,
+}
+
+func toReviewState(s models.ReviewState) pb.ReviewState {
+	return reviewStates[s]
+}
+
+// fromReviewState возвращает пустое состояние для неизвестных значений:
+// его отклоняет проверка PRReviewRequest
+func fromReviewState(s pb.ReviewState) models.ReviewState {
+	for state, value := range reviewStates {
+		if value == s {
+			return state
+		}
+	}
+	return ""
+}
+
+func toPullRequest(pr *models.PullRequest) *pb.PullRequest {
+	result := &pb.PullRequest{
+		PullRequestId:     pr.ID,
+		PullRequestName:   pr.Name,
+		AuthorId:          pr.AuthorID,
+		Status:            toPRStatus(pr.Status),
+		AssignedReviewers: pr.AssignedReviewers,
+		CreatedAt:         timestamp(pr.CreatedAt),
+		UpdatedAt:         timestamp(pr.UpdatedAt),
+		Version:           int32(pr.Version),
+	}
+	if pr.MergedAt != nil {
+		result.MergedAt = timestamppb.New(*pr.MergedAt)
+	}
+	return result
+}
+
+func toPullRequests(prs []*models.PullRequest) []*pb.PullRequest {
+	result := make([]*pb.PullRequest, len(prs))
+	for i, pr := range prs {
+		result[i] = toPullRequest(pr)
+	}
+	return result
+}
+
+func toPullRequestDetails(details *models.PullRequestDetails) *pb.PullRequestDetails {
+	reviewers := make([]*pb.Reviewer, len(details.Reviewers))
+	for i, reviewer := range details.Reviewers {
+		reviewers[i] = &pb.Reviewer{
+			UserId:   reviewer.UserID,
+			Username: reviewer.Username,
+			IsActive: reviewer.IsActive,
+		}
+	}
+
+	return &pb.PullRequestDetails{
+		PullRequest:    toPullRequest(&details.PullRequest),
+		AuthorUsername: details.AuthorUsername,
+		Reviewers:      reviewers,
+	}
+}
+
+func toReviewPRs(prs []*models.ReviewPR) []*pb.ReviewPullRequest {
+	result := make([]*pb.ReviewPullRequest, len(prs))
+	for i, pr := range prs {
+		result[i] = &pb.ReviewPullRequest{
+			PullRequestId:   pr.ID,
+			PullRequestName: pr.Name,
+			AuthorId:        pr.AuthorID,
+			Status:          toPRStatus(pr.Status),
+			ReviewState:     toReviewState(pr.ReviewState),
+		}
+	}
+	return result
+}
+
+func toReviewQueue(queue *models.ReviewQueue) *pb.ReviewQueue {
+	return &pb.ReviewQueue{
+		NeedsReview:      toReviewPRs(queue.NeedsReview),
+		Approved:         toReviewPRs(queue.Approved),
+		ChangesRequested: toReviewPRs(queue.ChangesRequested),
+		Counts: &pb.ReviewCounts{
+			NeedsReview:      int32(queue.Counts.NeedsReview),
+			Approved:         int32(queue.Counts.Approved),
+			ChangesRequested: int32(queue.Counts.ChangesRequested),
+			Total:            int32(queue.Counts.Total),
+		},
+		NextCursor: queue.NextCursor,
+	}
+}
+
+// fromPage проверяет параметры страницы так же, как HTTP API
+func fromPage(page *pb.PageRequest) (models.PageRequest, error) {
+	var result models.PageRequest
+	if page == nil {
+		return result, nil
+	}
+
+	if page.GetLimit() < 0 || page.GetLimit() > models.MaxPageLimit {
+		return result, invalidArgument("limit must be between 0 and " + strconv.Itoa(models.MaxPageLimit))
+	}
+	result.Limit = int(page.GetLimit())
+
+	if page.GetCursor() != "" {
+		cursor, err := models.DecodeCursor(page.GetCursor())
+		if err != nil {
+			return result, invalidArgument(err.Error())
+		}
+		result.Cursor = cursor
+	}
+
+	switch page.GetOrder() {
+	case pb.SortOrder_SORT_ORDER_UNSPECIFIED:
+	case pb.SortOrder_SORT_ORDER_DESC:
+		result.Order = models.SortDesc
+	case pb.SortOrder_SORT_ORDER_ASC:
+		result.Order = models.SortAsc
+	default:
+		return result, invalidArgument(models.ErrInvalidSort.Error())
+	}
+
+	return result, nil
+}
+
+func toUserStats(stats *models.UserAssignmentStats) *pb.UserAssignmentStats {
+	return &pb.UserAssignmentStats{
+		UserId:          stats.UserID,
+		Username:        stats.Username,
+		TeamName:        stats.TeamName,
+		IsActive:        stats.IsActive,
+		AssignmentCount: int32(stats.AssignmentCount),
+	}
+}
+
+func toAssignmentStats(stats *models.AssignmentStatsResponse) *pb.AssignmentStats {
+	result := &pb.AssignmentStats{
+		UserStats:  make([]*pb.UserAssignmentStats, len(stats.UserStats)),
+		NextCursor: stats.NextCursor,
+	}
+	for i, userStats := range stats.UserStats {
+		result.UserStats[i] = toUserStats(userStats)
+	}
+
+	if pr := stats.PRStats; pr != nil {
+		result.PrStats = &pb.PullRequestAssignmentStats{
+			TotalPrs:            int32(pr.TotalPRs),
+			OpenPrs:             int32(pr.OpenPRs),
+			MergedPrs:           int32(pr.MergedPRs),
+			AvgReviewersPerPr:   pr.AvgReviewersPerPR,
+			PrsWithNoReviewers:  int32(pr.PRsWithNoReviewers),
+			PrsWithOneReviewer:  int32(pr.PRsWithOneReviewer),
+			PrsWithTwoReviewers: int32(pr.PRsWithTwoReviewers),
+		}
+	}
+	if summary := stats.Summary; summary != nil {
+		result.Summary = &pb.StatsSummary{
+			TotalUsers:       int32(summary.TotalUsers),
+			ActiveUsers:      int32(summary.ActiveUsers),
+			TotalAssignments: int32(summary.TotalAssignments),
+			MostAssignedUser: summary.MostAssignedUser,
+			MostAssignments:  int32(summary.MostAssignments),
+		}
+	}
+
+	return result
+}
+
+func toMemberFairness(members []*models.MemberFairness) []*pb.MemberFairness {
+	result := make([]*pb.MemberFairness, len(members))
+	for i, member := range members {
+		result[i] = &pb.MemberFairness{
+			UserId:            member.UserID,
+			Username:          member.Username,
+			AssignmentCount:   int32(member.AssignmentCount),
+			DaysActive:        member.DaysActive,
+			AssignmentsPerDay: member.AssignmentsPerDay,
+		}
+	}
+	return result
+}
+
+func toFairnessReport(report *models.FairnessReport) *pb.FairnessReport {
+	result := &pb.FairnessReport{
+		GiniThreshold: report.GiniThreshold,
+		Imbalanced:    report.Imbalanced,
+		Teams:         make([]*pb.TeamFairness, len(report.Teams)),
+	}
+	for i, team := range report.Teams {
+		result.Teams[i] = &pb.TeamFairness{
+			TeamName:              team.TeamName,
+			ActiveMembers:         int32(team.ActiveMembers),
+			TotalAssignments:      int32(team.TotalAssignments),
+			MeanAssignmentsPerDay: team.MeanPerDay,
+			StdDev:                team.StdDev,
+			Gini:                  team.Gini,
+			Imbalanced:            team.Imbalanced,
+			Overloaded:            toMemberFairness(team.Overloaded),
+			Underloaded:           toMemberFairness(team.Underloaded),
+			Members:               toMemberFairness(team.Members),
+		}
+	}
+	return result
+}
@@ -0,0 +1,55 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	logpkg "github.com/vnchk1/pr-manager/internal/logger"
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorCodes сопоставляет доменные ошибки кодам gRPC
+var errorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{models.ErrNotFound, codes.NotFound},
+	{models.ErrInvalidUserID, codes.InvalidArgument},
+	{models.ErrInvalidUsername, codes.InvalidArgument},
+	{models.ErrInvalidTeamName, codes.InvalidArgument},
+	{models.ErrInvalidPRID, codes.InvalidArgument},
+	{models.ErrInvalidPRName, codes.InvalidArgument},
+	{models.ErrInvalidAuthorID, codes.InvalidArgument},
+	{models.ErrInvalidReviewState, codes.InvalidArgument},
+	{models.ErrInvalidCursor, codes.InvalidArgument},
+	{models.ErrInvalidSort, codes.InvalidArgument},
+	{models.ErrUserExists, codes.AlreadyExists},
+	{models.ErrTeamExists, codes.AlreadyExists},
+	{models.ErrPRExists, codes.AlreadyExists},
+	{models.ErrUserNotActive, codes.FailedPrecondition},
+	{models.ErrTeamNotEmpty, codes.FailedPrecondition},
+	{models.ErrPRMerged, codes.FailedPrecondition},
+	{models.ErrNotAssigned, codes.FailedPrecondition},
+	{models.ErrNoCandidate, codes.FailedPrecondition},
+	{models.ErrVersionConflict, codes.Aborted},
+}
+
+// serviceError переводит ошибку сервиса в статус gRPC. Текст неизвестных ошибок
+// клиенту не отдается, они пишутся в лог.
+func serviceError(ctx context.Context, err error) error {
+	for _, mapping := range errorCodes {
+		if errors.Is(err, mapping.err) {
+			return status.Error(mapping.code, mapping.err.Error())
+		}
+	}
+
+	logpkg.FromContext(ctx).Error("rpc failed", "error", err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+func invalidArgument(message string) error {
+	return status.Error(codes.InvalidArgument, message)
+}
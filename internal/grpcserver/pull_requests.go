@@ -0,0 +1,149 @@
+package grpcserver
+
+import (
+	"context"
+	"strconv"
+
+	pb "github.com/vnchk1/pr-manager/api/prmanager/v1"
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/service"
+)
+
+type pullRequestServer struct {
+	pb.UnimplementedPullRequestServiceServer
+
+	prs service.PRService
+}
+
+func (s *pullRequestServer) CreatePullRequest(ctx context.Context, req *pb.CreatePullRequestRequest) (*pb.PullRequest, error) {
+	create := &models.PRCreateRequest{
+		ID:       req.GetPullRequestId(),
+		Name:     req.GetPullRequestName(),
+		AuthorID: req.GetAuthorId(),
+	}
+	switch {
+	case create.ID == "":
+		return nil, serviceError(ctx, models.ErrInvalidPRID)
+	case create.Name == "":
+		return nil, serviceError(ctx, models.ErrInvalidPRName)
+	case create.AuthorID == "":
+		return nil, serviceError(ctx, models.ErrInvalidAuthorID)
+	}
+
+	pr, err := s.prs.Create(ctx, create)
+	if err != nil {
+		return nil, serviceError(ctx, err)
+	}
+
+	return toPullRequest(pr), nil
+}
+
+func (s *pullRequestServer) GetPullRequest(ctx context.Context, req *pb.GetPullRequestRequest) (*pb.PullRequestDetails, error) {
+	details, err := s.prs.GetDetails(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, serviceError(ctx, err)
+	}
+
+	return toPullRequestDetails(details), nil
+}
+
+func (s *pullRequestServer) ListPullRequests(ctx context.Context, req *pb.ListPullRequestsRequest) (*pb.ListPullRequestsResponse, error) {
+	page, err := fromPage(req.GetPage())
+	if err != nil {
+		return nil, err
+	}
+
+	filter := models.PRFilter{
+		AuthorID:    req.GetAuthorId(),
+		ReviewerID:  req.GetReviewerId(),
+		TeamName:    req.GetTeamName(),
+		CreatedFrom: optionalTime(req.GetCreatedFrom()),
+		CreatedTo:   optionalTime(req.GetCreatedTo()),
+	}
+	if filter.Status, err = fromPRStatus(req.GetStatus()); err != nil {
+		return nil, err
+	}
+
+	result, err := s.prs.List(ctx, filter, page)
+	if err != nil {
+		return nil, serviceError(ctx, err)
+	}
+
+	return &pb.ListPullRequestsResponse{
+		PullRequests: toPullRequests(result.PullRequests),
+		NextCursor:   result.NextCursor,
+	}, nil
+}
+
+func (s *pullRequestServer) SearchPullRequests(ctx context.Context, req *pb.SearchPullRequestsRequest) (*pb.SearchPullRequestsResponse, error) {
+	if req.GetQuery() == "" {
+		return nil, invalidArgument("query is required")
+	}
+	if req.GetLimit() < 0 || req.GetLimit() > models.MaxPageLimit {
+		return nil, invalidArgument("limit must be between 0 and " + strconv.Itoa(models.MaxPageLimit))
+	}
+
+	search := models.PRSearch{
+		Query: req.GetQuery(),
+		Filter: models.PRFilter{
+			ReviewerID: req.GetReviewerId(),
+			TeamName:   req.GetTeamName(),
+		},
+		Limit: int(req.GetLimit()),
+	}
+
+	var err error
+	if search.Filter.Status, err = fromPRStatus(req.GetStatus()); err != nil {
+		return nil, err
+	}
+
+	prs, err := s.prs.Search(ctx, search)
+	if err != nil {
+		return nil, serviceError(ctx, err)
+	}
+
+	return &pb.SearchPullRequestsResponse{PullRequests: toPullRequests(prs)}, nil
+}
+
+func (s *pullRequestServer) MergePullRequest(ctx context.Context, req *pb.MergePullRequestRequest) (*pb.PullRequest, error) {
+	pr, err := s.prs.Merge(ctx, &models.PRMergeRequest{
+		ID:      req.GetPullRequestId(),
+		Version: int(req.GetExpectedVersion()),
+	})
+	if err != nil {
+		return nil, serviceError(ctx, err)
+	}
+
+	return toPullRequest(pr), nil
+}
+
+func (s *pullRequestServer) ReassignReviewer(ctx context.Context, req *pb.ReassignReviewerRequest) (*pb.ReassignReviewerResponse, error) {
+	pr, replacedBy, err := s.prs.ReassignReviewer(ctx, &models.PRReassignRequest{
+		ID:          req.GetPullRequestId(),
+		OldReviewer: req.GetOldUserId(),
+		Version:     int(req.GetExpectedVersion()),
+	})
+	if err != nil {
+		return nil, serviceError(ctx, err)
+	}
+
+	return &pb.ReassignReviewerResponse{PullRequest: toPullRequest(pr), ReplacedBy: replacedBy}, nil
+}
+
+func (s *pullRequestServer) SubmitReview(ctx context.Context, req *pb.SubmitReviewRequest) (*pb.Review, error) {
+	review, err := s.prs.SubmitReview(ctx, &models.PRReviewRequest{
+		ID:         req.GetPullRequestId(),
+		ReviewerID: req.GetUserId(),
+		State:      fromReviewState(req.GetState()),
+	})
+	if err != nil {
+		return nil, serviceError(ctx, err)
+	}
+
+	return &pb.Review{
+		PullRequestId: review.PullRequestID,
+		UserId:        review.ReviewerID,
+		State:         toReviewState(review.State),
+		UpdatedAt:     timestamp(review.UpdatedAt),
+	}, nil
+}
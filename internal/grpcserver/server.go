@@ -0,0 +1,129 @@
+// Package grpcserver реализует gRPC API (api/prmanager/v1) поверх тех же сервисов,
+// что и HTTP API
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"time"
+
+	pb "github.com/vnchk1/pr-manager/api/prmanager/v1"
+	logpkg "github.com/vnchk1/pr-manager/internal/logger"
+	"github.com/vnchk1/pr-manager/internal/metrics"
+	"github.com/vnchk1/pr-manager/internal/service"
+
+	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+type Server struct {
+	grpc *grpc.Server
+	port int
+}
+
+type options struct {
+	tracing bool
+	metrics *grpcprom.ServerMetrics
+}
+
+type Option func(*options)
+
+// WithTracing оборачивает каждый вызов в спан OpenTelemetry, как otelecho в HTTP API
+func WithTracing() Option {
+	return func(o *options) {
+		o.tracing = true
+	}
+}
+
+// WithMetrics считает вызовы и время их обработки в метриках Prometheus сервиса
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(o *options) {
+		o.metrics = m.GRPCServer()
+	}
+}
+
+func New(port int, svc *service.Service, logger *slog.Logger, opts ...Option) *Server {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var interceptors []grpc.UnaryServerInterceptor
+	if o.metrics != nil {
+		interceptors = append(interceptors, o.metrics.UnaryServerInterceptor())
+	}
+	interceptors = append(interceptors, loggingInterceptor(logger))
+
+	serverOpts := []grpc.ServerOption{grpc.ChainUnaryInterceptor(interceptors...)}
+	if o.tracing {
+		serverOpts = append(serverOpts, grpc.StatsHandler(otelgrpc.NewServerHandler()))
+	}
+
+	srv := grpc.NewServer(serverOpts...)
+
+	pb.RegisterTeamServiceServer(srv, &teamServer{teams: svc.Team})
+	pb.RegisterUserServiceServer(srv, &userServer{users: svc.User})
+	pb.RegisterPullRequestServiceServer(srv, &pullRequestServer{prs: svc.PR})
+	pb.RegisterStatsServiceServer(srv, &statsServer{stats: svc.Stats})
+
+	// Счетчики всех методов видны в /metrics с нулями еще до первого вызова
+	if o.metrics != nil {
+		o.metrics.InitializeMetrics(srv)
+	}
+
+	return &Server{grpc: srv, port: port}
+}
+
+func (s *Server) Start(logger *slog.Logger) error {
+	address := fmt.Sprintf(":%d", s.port)
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", address, err)
+	}
+
+	logger.Debug(fmt.Sprintf("Starting gRPC server on %s", address))
+	return s.Serve(lis)
+}
+
+// Serve принимает соединения на готовом listener, в тестах - на bufconn
+func (s *Server) Serve(lis net.Listener) error {
+	return s.grpc.Serve(lis)
+}
+
+// Shutdown дожидается завершения текущих вызовов, а по истечении ctx
+// закрывает соединения принудительно
+func (s *Server) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpc.Stop()
+		return ctx.Err()
+	}
+}
+
+// loggingInterceptor кладет логгер вызова в контекст и пишет итог каждого вызова
+func loggingInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		callLogger := logger.With("grpc_method", info.FullMethod)
+
+		resp, err := handler(logpkg.WithContext(ctx, callLogger), req)
+
+		callLogger.Info("completed rpc",
+			"code", status.Code(err).String(),
+			"latency_ms", time.Since(start).Milliseconds(),
+		)
+		return resp, err
+	}
+}
@@ -0,0 +1,347 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pb "github.com/vnchk1/pr-manager/api/prmanager/v1"
+	"github.com/vnchk1/pr-manager/internal/metrics"
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeTeamService struct {
+	service.TeamService
+	teams map[string]*models.Team
+}
+
+func (s *fakeTeamService) Create(_ context.Context, team *models.Team) (*models.Team, error) {
+	if err := team.Validate(); err != nil {
+		return nil, err
+	}
+	if _, ok := s.teams[team.Name]; ok {
+		return nil, models.ErrTeamExists
+	}
+	s.teams[team.Name] = team
+	return team, nil
+}
+
+func (s *fakeTeamService) Get(_ context.Context, teamName string) (*models.Team, error) {
+	team, ok := s.teams[teamName]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return team, nil
+}
+
+func (s *fakeTeamService) Delete(ctx context.Context, teamName string) error {
+	team, err := s.Get(ctx, teamName)
+	if err != nil {
+		return err
+	}
+	if len(team.Members) > 0 {
+		return models.ErrTeamNotEmpty
+	}
+	delete(s.teams, teamName)
+	return nil
+}
+
+type fakeUserService struct {
+	service.UserService
+}
+
+func (fakeUserService) GetReviewPRs(
+	_ context.Context,
+	userID string,
+	status models.PullRequestStatus,
+	page models.PageRequest,
+) (*models.ReviewQueue, error) {
+	if status != models.StatusOpen {
+		return nil, errors.New("unexpected status filter")
+	}
+	if page.Limit != 1 {
+		return nil, errors.New("unexpected page limit")
+	}
+	queue := models.NewReviewQueue([]*models.ReviewPR{{
+		PullRequestShort: models.PullRequestShort{ID: "pr-1", Name: "Add search", AuthorID: "u2", Status: models.StatusOpen},
+		ReviewState:      models.ReviewChangesRequested,
+	}})
+	queue.NextCursor = "next"
+	return queue, nil
+}
+
+type fakePRService struct {
+	service.PRService
+}
+
+func (fakePRService) Create(_ context.Context, req *models.PRCreateRequest) (*models.PullRequest, error) {
+	if req.ID == "pr-1" {
+		return nil, models.ErrPRExists
+	}
+	if req.ID == "pr-broken" {
+		return nil, errors.New("connection refused")
+	}
+	return &models.PullRequest{ID: req.ID, Name: req.Name, AuthorID: req.AuthorID, Status: models.StatusOpen}, nil
+}
+
+// fakePRVersion - текущая версия PR в fakePRService
+const fakePRVersion = 3
+
+func (fakePRService) Merge(_ context.Context, req *models.PRMergeRequest) (*models.PullRequest, error) {
+	if req.Version != 0 && req.Version != fakePRVersion {
+		return nil, models.ErrVersionConflict
+	}
+	return &models.PullRequest{ID: req.ID, Status: models.StatusMerged, Version: fakePRVersion + 1}, nil
+}
+
+func (fakePRService) ReassignReviewer(_ context.Context, req *models.PRReassignRequest) (*models.PullRequest, string, error) {
+	if req.ID == "pr-merged" {
+		return nil, "", models.ErrPRMerged
+	}
+	if req.Version != 0 && req.Version != fakePRVersion {
+		return nil, "", models.ErrVersionConflict
+	}
+	return &models.PullRequest{ID: req.ID, Status: models.StatusOpen, AssignedReviewers: []string{"u3"}, Version: fakePRVersion + 1}, "u3", nil
+}
+
+func (fakePRService) List(_ context.Context, _ models.PRFilter, page models.PageRequest) (*models.PRPage, error) {
+	mergedAt := time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)
+	return &models.PRPage{
+		PullRequests: []*models.PullRequest{{ID: "pr-2", Status: models.StatusMerged, MergedAt: &mergedAt}},
+		NextCursor:   page.Cursor.Encode(),
+	}, nil
+}
+
+func newTestClient(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+
+	svc := &service.Service{
+		Team: &fakeTeamService{teams: map[string]*models.Team{
+			"backend": {Name: "backend", Members: []*models.User{{ID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}}},
+		}},
+		User: fakeUserService{},
+		PR:   fakePRService{},
+	}
+	srv := New(0, svc, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(func() { _ = srv.Shutdown(context.Background()) })
+
+	return dial(t, lis)
+}
+
+func dial(t *testing.T, lis *bufconn.Listener) *grpc.ClientConn {
+	t.Helper()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func TestTeams(t *testing.T) {
+	client := pb.NewTeamServiceClient(newTestClient(t))
+	ctx := context.Background()
+
+	created, err := client.CreateTeam(ctx, &pb.CreateTeamRequest{
+		TeamName: "mobile",
+		Members:  []*pb.TeamMember{{UserId: "u7", Username: "Eve", IsActive: true}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "mobile", created.GetTeamName())
+	require.Len(t, created.GetMembers(), 1)
+	assert.Equal(t, "mobile", created.GetMembers()[0].GetTeamName())
+
+	team, err := client.GetTeam(ctx, &pb.GetTeamRequest{TeamName: "backend"})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", team.GetMembers()[0].GetUsername())
+}
+
+func TestStatusCodes(t *testing.T) {
+	conn := newTestClient(t)
+	teams := pb.NewTeamServiceClient(conn)
+	prs := pb.NewPullRequestServiceClient(conn)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		call    func() error
+		code    codes.Code
+		message string
+	}{
+		{"Not found", func() error {
+			_, err := teams.GetTeam(ctx, &pb.GetTeamRequest{TeamName: "frontend"})
+			return err
+		}, codes.NotFound, models.ErrNotFound.Error()},
+		{"Team not empty", func() error {
+			_, err := teams.DeleteTeam(ctx, &pb.DeleteTeamRequest{TeamName: "backend"})
+			return err
+		}, codes.FailedPrecondition, models.ErrTeamNotEmpty.Error()},
+		{"Duplicate member", func() error {
+			_, err := teams.CreateTeam(ctx, &pb.CreateTeamRequest{
+				TeamName: "ios",
+				Members:  []*pb.TeamMember{{UserId: "u8", Username: "A"}, {UserId: "u8", Username: "B"}},
+			})
+			return err
+		}, codes.InvalidArgument, "duplicate member user_id u8"},
+		{"Missing PR name", func() error {
+			_, err := prs.CreatePullRequest(ctx, &pb.CreatePullRequestRequest{PullRequestId: "pr-9", AuthorId: "u1"})
+			return err
+		}, codes.InvalidArgument, models.ErrInvalidPRName.Error()},
+		{"PR exists", func() error {
+			_, err := prs.CreatePullRequest(ctx, &pb.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "x", AuthorId: "u1"})
+			return err
+		}, codes.AlreadyExists, models.ErrPRExists.Error()},
+		{"PR merged", func() error {
+			_, err := prs.ReassignReviewer(ctx, &pb.ReassignReviewerRequest{PullRequestId: "pr-merged", OldUserId: "u2"})
+			return err
+		}, codes.FailedPrecondition, models.ErrPRMerged.Error()},
+		{"Stale merge", func() error {
+			_, err := prs.MergePullRequest(ctx, &pb.MergePullRequestRequest{PullRequestId: "pr-1", ExpectedVersion: 2})
+			return err
+		}, codes.Aborted, models.ErrVersionConflict.Error()},
+		{"Stale reassign", func() error {
+			_, err := prs.ReassignReviewer(ctx, &pb.ReassignReviewerRequest{PullRequestId: "pr-1", OldUserId: "u2", ExpectedVersion: 2})
+			return err
+		}, codes.Aborted, models.ErrVersionConflict.Error()},
+		{"Invalid cursor", func() error {
+			_, err := prs.ListPullRequests(ctx, &pb.ListPullRequestsRequest{Page: &pb.PageRequest{Cursor: "%%%"}})
+			return err
+		}, codes.InvalidArgument, models.ErrInvalidCursor.Error()},
+		{"Unknown error is hidden", func() error {
+			_, err := prs.CreatePullRequest(ctx, &pb.CreatePullRequestRequest{PullRequestId: "pr-broken", PullRequestName: "x", AuthorId: "u1"})
+			return err
+		}, codes.Internal, "internal error"},
+		{"Invalid gini threshold", func() error {
+			_, err := pb.NewStatsServiceClient(conn).GetFairnessReport(ctx, &pb.GetFairnessReportRequest{GiniThreshold: ptr(1.5)})
+			return err
+		}, codes.InvalidArgument, "gini_threshold must be a number between 0 and 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, ok := status.FromError(tt.call())
+			require.True(t, ok)
+			assert.Equal(t, tt.code, st.Code())
+			assert.Equal(t, tt.message, st.Message())
+		})
+	}
+}
+
+func TestPullRequestConversion(t *testing.T) {
+	conn := newTestClient(t)
+	ctx := context.Background()
+
+	cursor := models.TimeCursor(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), "pr-1").Encode()
+	list, err := pb.NewPullRequestServiceClient(conn).ListPullRequests(ctx, &pb.ListPullRequestsRequest{
+		Page: &pb.PageRequest{Cursor: cursor, Order: pb.SortOrder_SORT_ORDER_ASC},
+	})
+	require.NoError(t, err)
+	require.Len(t, list.GetPullRequests(), 1)
+	pr := list.GetPullRequests()[0]
+	assert.Equal(t, pb.PullRequestStatus_PULL_REQUEST_STATUS_MERGED, pr.GetStatus())
+	assert.Equal(t, time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC), pr.GetMergedAt().AsTime())
+	assert.Nil(t, pr.GetCreatedAt(), "zero time is not sent")
+	assert.Equal(t, cursor, list.GetNextCursor())
+
+	queue, err := pb.NewUserServiceClient(conn).GetUserReviews(ctx, &pb.GetUserReviewsRequest{
+		UserId: "u1",
+		Status: pb.PullRequestStatus_PULL_REQUEST_STATUS_OPEN,
+		Page:   &pb.PageRequest{Limit: 1},
+	})
+	require.NoError(t, err)
+	require.Len(t, queue.GetChangesRequested(), 1)
+	assert.Equal(t, pb.ReviewState_REVIEW_STATE_CHANGES_REQUESTED, queue.GetChangesRequested()[0].GetReviewState())
+	assert.Equal(t, int32(1), queue.GetCounts().GetTotal())
+	assert.Equal(t, "next", queue.GetNextCursor())
+}
+
+func TestShutdownStopsServing(t *testing.T) {
+	srv := New(0, &service.Service{Team: &fakeTeamService{teams: map[string]*models.Team{}}}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	lis := bufconn.Listen(1 << 20)
+
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(lis) }()
+
+	conn := dial(t, lis)
+	client := pb.NewTeamServiceClient(conn)
+	_, err := client.GetTeam(context.Background(), &pb.GetTeamRequest{TeamName: "backend"}, grpc.WaitForReady(true))
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, srv.Shutdown(ctx))
+
+	select {
+	case err := <-served:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Serve did not return after Shutdown")
+	}
+
+	_, err = client.GetTeam(context.Background(), &pb.GetTeamRequest{TeamName: "backend"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestMetricsCountCalls(t *testing.T) {
+	m := metrics.New()
+	svc := &service.Service{Team: &fakeTeamService{teams: map[string]*models.Team{}}}
+	srv := New(0, svc, slog.New(slog.NewTextHandler(io.Discard, nil)), WithMetrics(m), WithTracing())
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(func() { _ = srv.Shutdown(context.Background()) })
+
+	_, err := pb.NewTeamServiceClient(dial(t, lis)).GetTeam(context.Background(), &pb.GetTeamRequest{TeamName: "backend"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	assert.Contains(t, body, `pr_manager_grpc_server_handled_total{grpc_code="NotFound",grpc_method="GetTeam",grpc_service="prmanager.v1.TeamService",grpc_type="unary"} 1`)
+	// Методы без вызовов тоже видны с нулевыми счетчиками
+	assert.Contains(t, body, `pr_manager_grpc_server_started_total{grpc_method="DeleteTeam",grpc_service="prmanager.v1.TeamService",grpc_type="unary"} 0`)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestExpectedVersion(t *testing.T) {
+	prs := pb.NewPullRequestServiceClient(newTestClient(t))
+	ctx := context.Background()
+
+	merged, err := prs.MergePullRequest(ctx, &pb.MergePullRequestRequest{PullRequestId: "pr-1", ExpectedVersion: fakePRVersion})
+	require.NoError(t, err)
+	assert.Equal(t, int32(fakePRVersion+1), merged.GetVersion())
+
+	reassigned, err := prs.ReassignReviewer(ctx, &pb.ReassignReviewerRequest{PullRequestId: "pr-1", OldUserId: "u2", ExpectedVersion: fakePRVersion})
+	require.NoError(t, err)
+	assert.Equal(t, int32(fakePRVersion+1), reassigned.GetPullRequest().GetVersion())
+
+	// Без expected_version версия не проверяется
+	_, err = prs.MergePullRequest(ctx, &pb.MergePullRequestRequest{PullRequestId: "pr-1"})
+	require.NoError(t, err)
+}
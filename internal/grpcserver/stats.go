@@ -0,0 +1,58 @@
+package grpcserver
+
+import (
+	"context"
+
+	pb "github.com/vnchk1/pr-manager/api/prmanager/v1"
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/service"
+)
+
+type statsServer struct {
+	pb.UnimplementedStatsServiceServer
+
+	stats service.StatsService
+}
+
+func (s *statsServer) GetAssignmentStats(ctx context.Context, req *pb.GetAssignmentStatsRequest) (*pb.AssignmentStats, error) {
+	page, err := fromPage(req.GetPage())
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.stats.GetAssignmentStats(ctx, models.AssignmentStatsFilter{
+		TeamName: req.GetTeamName(),
+		Sort:     req.GetSort(),
+	}, page)
+	if err != nil {
+		return nil, serviceError(ctx, err)
+	}
+
+	return toAssignmentStats(stats), nil
+}
+
+func (s *statsServer) GetUserStats(ctx context.Context, req *pb.GetUserStatsRequest) (*pb.UserAssignmentStats, error) {
+	stats, err := s.stats.GetUserStats(ctx, req.GetUserId())
+	if err != nil {
+		return nil, serviceError(ctx, err)
+	}
+
+	return toUserStats(stats), nil
+}
+
+func (s *statsServer) GetFairnessReport(ctx context.Context, req *pb.GetFairnessReportRequest) (*pb.FairnessReport, error) {
+	giniThreshold := models.DefaultGiniThreshold
+	if req.GiniThreshold != nil {
+		giniThreshold = req.GetGiniThreshold()
+		if giniThreshold < 0 || giniThreshold > 1 {
+			return nil, invalidArgument("gini_threshold must be a number between 0 and 1")
+		}
+	}
+
+	report, err := s.stats.GetFairnessReport(ctx, giniThreshold)
+	if err != nil {
+		return nil, serviceError(ctx, err)
+	}
+
+	return toFairnessReport(report), nil
+}
@@ -0,0 +1,70 @@
+package grpcserver
+
+import (
+	"context"
+
+	pb "github.com/vnchk1/pr-manager/api/prmanager/v1"
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/service"
+)
+
+type teamServer struct {
+	pb.UnimplementedTeamServiceServer
+
+	teams service.TeamService
+}
+
+func (s *teamServer) CreateTeam(ctx context.Context, req *pb.CreateTeamRequest) (*pb.Team, error) {
+	seen := make(map[string]bool, len(req.GetMembers()))
+	team := &models.Team{Name: req.GetTeamName(), Members: make([]*models.User, 0, len(req.GetMembers()))}
+	for _, member := range req.GetMembers() {
+		if seen[member.GetUserId()] {
+			return nil, invalidArgument("duplicate member user_id " + member.GetUserId())
+		}
+		seen[member.GetUserId()] = true
+
+		team.Members = append(team.Members, &models.User{
+			ID:       member.GetUserId(),
+			Username: member.GetUsername(),
+			TeamName: req.GetTeamName(),
+			IsActive: member.GetIsActive(),
+		})
+	}
+
+	created, err := s.teams.Create(ctx, team)
+	if err != nil {
+		return nil, serviceError(ctx, err)
+	}
+
+	return toTeam(created), nil
+}
+
+func (s *teamServer) GetTeam(ctx context.Context, req *pb.GetTeamRequest) (*pb.Team, error) {
+	team, err := s.teams.Get(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, serviceError(ctx, err)
+	}
+
+	return toTeam(team), nil
+}
+
+func (s *teamServer) ListTeams(ctx context.Context, _ *pb.ListTeamsRequest) (*pb.ListTeamsResponse, error) {
+	teams, err := s.teams.List(ctx)
+	if err != nil {
+		return nil, serviceError(ctx, err)
+	}
+
+	resp := &pb.ListTeamsResponse{Teams: make([]*pb.Team, len(teams))}
+	for i, team := range teams {
+		resp.Teams[i] = toTeam(team)
+	}
+	return resp, nil
+}
+
+func (s *teamServer) DeleteTeam(ctx context.Context, req *pb.DeleteTeamRequest) (*pb.DeleteTeamResponse, error) {
+	if err := s.teams.Delete(ctx, req.GetTeamName()); err != nil {
+		return nil, serviceError(ctx, err)
+	}
+
+	return &pb.DeleteTeamResponse{}, nil
+}
@@ -0,0 +1,84 @@
+package grpcserver
+
+import (
+	"context"
+
+	pb "github.com/vnchk1/pr-manager/api/prmanager/v1"
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/service"
+)
+
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+
+	users service.UserService
+}
+
+func (s *userServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
+	user := &models.User{
+		ID:       req.GetUserId(),
+		Username: req.GetUsername(),
+		TeamName: req.GetTeamName(),
+		IsActive: true,
+	}
+	if req.IsActive != nil {
+		user.IsActive = req.GetIsActive()
+	}
+
+	created, err := s.users.Create(ctx, user)
+	if err != nil {
+		return nil, serviceError(ctx, err)
+	}
+
+	return toUser(created), nil
+}
+
+func (s *userServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	user, err := s.users.GetByID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, serviceError(ctx, err)
+	}
+
+	return toUser(user), nil
+}
+
+func (s *userServer) ListUsers(ctx context.Context, _ *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	users, err := s.users.List(ctx)
+	if err != nil {
+		return nil, serviceError(ctx, err)
+	}
+
+	return &pb.ListUsersResponse{Users: toUsers(users)}, nil
+}
+
+func (s *userServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.User, error) {
+	user, err := s.users.Update(ctx, req.GetUserId(), &models.UserUpdate{
+		Username: req.Username,
+		TeamName: req.TeamName,
+		IsActive: req.IsActive,
+	})
+	if err != nil {
+		return nil, serviceError(ctx, err)
+	}
+
+	return toUser(user), nil
+}
+
+func (s *userServer) GetUserReviews(ctx context.Context, req *pb.GetUserReviewsRequest) (*pb.ReviewQueue, error) {
+	status, err := fromPRStatus(req.GetStatus())
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := fromPage(req.GetPage())
+	if err != nil {
+		return nil, err
+	}
+
+	queue, err := s.users.GetReviewPRs(ctx, req.GetUserId(), status, page)
+	if err != nil {
+		return nil, serviceError(ctx, err)
+	}
+
+	return toReviewQueue(queue), nil
+}
@@ -0,0 +1,109 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// Checker проверяет доступность одной зависимости
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+type checkerFunc struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+func NewChecker(name string, check func(ctx context.Context) error) Checker {
+	return &checkerFunc{name: name, check: check}
+}
+
+func (c *checkerFunc) Name() string {
+	return c.name
+}
+
+func (c *checkerFunc) Check(ctx context.Context) error {
+	return c.check(ctx)
+}
+
+type DependencyReport struct {
+	Status    Status  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status Status                       `json:"status"`
+	Checks map[string]*DependencyReport `json:"checks,omitempty"`
+}
+
+// Run параллельно выполняет все проверки, ограничивая каждую таймаутом
+func Run(ctx context.Context, timeout time.Duration, checkers ...Checker) *Report {
+	report := &Report{
+		Status: StatusUp,
+		Checks: make(map[string]*DependencyReport, len(checkers)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, checker := range checkers {
+		wg.Add(1)
+		go func(checker Checker) {
+			defer wg.Done()
+
+			dependency := check(ctx, timeout, checker)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			report.Checks[checker.Name()] = dependency
+			if dependency.Status == StatusDown {
+				report.Status = StatusDown
+			}
+		}(checker)
+	}
+
+	wg.Wait()
+
+	return report
+}
+
+func check(ctx context.Context, timeout time.Duration, checker Checker) *DependencyReport {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- checker.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	dependency := &DependencyReport{
+		Status:    StatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		dependency.Status = StatusDown
+		dependency.Error = err.Error()
+	}
+
+	return dependency
+}
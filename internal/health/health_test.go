@@ -0,0 +1,46 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	ok := NewChecker("postgres", func(context.Context) error { return nil })
+	failing := NewChecker("migrations", func(context.Context) error { return errors.New("version mismatch") })
+	hanging := NewChecker("slow", func(context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	})
+
+	tests := []struct {
+		name       string
+		checkers   []Checker
+		wantStatus Status
+		wantDown   []string
+	}{
+		{name: "No checkers", wantStatus: StatusUp},
+		{name: "All up", checkers: []Checker{ok}, wantStatus: StatusUp},
+		{name: "One failing", checkers: []Checker{ok, failing}, wantStatus: StatusDown, wantDown: []string{"migrations"}},
+		{name: "Timeout", checkers: []Checker{ok, hanging}, wantStatus: StatusDown, wantDown: []string{"slow"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Run(context.Background(), 50*time.Millisecond, tt.checkers...)
+
+			assert.Equal(t, tt.wantStatus, report.Status)
+			require.Len(t, report.Checks, len(tt.checkers))
+			for _, name := range tt.wantDown {
+				require.Contains(t, report.Checks, name)
+				assert.Equal(t, StatusDown, report.Checks[name].Status)
+				assert.NotEmpty(t, report.Checks[name].Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Package i18n содержит каталог сообщений HTTP API на русском и английском
+// и выбор языка по заголовку Accept-Language
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Lang string
+
+const (
+	Russian Lang = "ru"
+	English Lang = "en"
+)
+
+// Supported - языки каталога. Каждое сообщение переведено на все языки из списка.
+var Supported = []Lang{Russian, English}
+
+// ParseLang разбирает код языка из конфигурации
+func ParseLang(s string) (Lang, error) {
+	lang := Lang(strings.ToLower(strings.TrimSpace(s)))
+	for _, supported := range Supported {
+		if lang == supported {
+			return lang, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported language %q, use ru or en", s)
+}
+
+// Negotiate выбирает язык ответа по заголовку Accept-Language (RFC 9110): из
+// поддерживаемых языков берется язык с наибольшим весом q, регион не учитывается
+// (en-US - это en). Если подходящего языка нет, возвращается fallback.
+func Negotiate(acceptLanguage string, fallback Lang) Lang {
+	type candidate struct {
+		lang Lang
+		q    float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+
+		if tag == "*" {
+			candidates = append(candidates, candidate{lang: fallback, q: q})
+			continue
+		}
+		primary, _, _ := strings.Cut(tag, "-")
+		if lang, err := ParseLang(primary); err == nil {
+			candidates = append(candidates, candidate{lang: lang, q: q})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return fallback
+	}
+
+	// При равном весе побеждает язык, указанный в заголовке раньше
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	return candidates[0].lang
+}
+
+// Translate возвращает сообщение на языке lang, подставляя аргументы в формат
+// сообщения. Неизвестный идентификатор возвращается как есть.
+func Translate(lang Lang, id MessageID, args ...any) string {
+	translations, ok := catalog[id]
+	if !ok {
+		return string(id)
+	}
+
+	format, ok := translations[lang]
+	if !ok {
+		format = translations[Russian]
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+type langKey struct{}
+
+// WithLang сохраняет выбранный язык ответа в контексте запроса
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, langKey{}, lang)
+}
+
+// FromContext возвращает язык ответа или русский, если язык не выбран
+func FromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(langKey{}).(Lang); ok {
+		return lang
+	}
+	return Russian
+}
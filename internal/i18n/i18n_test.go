@@ -0,0 +1,66 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		fallback Lang
+		want     Lang
+	}{
+		{"Empty header", "", English, English},
+		{"Exact match", "en", Russian, English},
+		{"Region is ignored", "en-US,en;q=0.9", Russian, English},
+		{"Highest weight wins", "ru;q=0.5, en;q=0.8", Russian, English},
+		{"Equal weight keeps order", "ru, en", English, Russian},
+		{"Unsupported language is skipped", "de-DE, en;q=0.3", Russian, English},
+		{"Only unsupported languages", "de, fr", English, English},
+		{"Wildcard is fallback", "*", English, English},
+		{"Zero weight excludes language", "en;q=0, ru;q=0.1", English, Russian},
+		{"Malformed weight is skipped", "en;q=abc", Russian, Russian},
+		{"Case insensitive", "EN-gb", Russian, English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Negotiate(tt.header, tt.fallback))
+		})
+	}
+}
+
+func TestParseLang(t *testing.T) {
+	lang, err := ParseLang(" EN ")
+	require.NoError(t, err)
+	assert.Equal(t, English, lang)
+
+	_, err = ParseLang("de")
+	assert.Error(t, err)
+}
+
+// TestCatalogComplete не дает добавить сообщение без перевода
+func TestCatalogComplete(t *testing.T) {
+	for id, translations := range catalog {
+		for _, lang := range Supported {
+			assert.NotEmpty(t, translations[lang], "message %s has no %s translation", id, lang)
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	assert.Equal(t, "Pull requests found: 3", Translate(English, PRsFound, 3))
+	assert.Equal(t, "Найдено pull requests: 3", Translate(Russian, PRsFound, 3))
+	assert.Equal(t, "Parameter q is required", Translate(English, ParamRequired, "q"))
+	assert.Equal(t, "unknown_id", Translate(English, MessageID("unknown_id")))
+}
+
+func TestLangContext(t *testing.T) {
+	assert.Equal(t, Russian, FromContext(context.Background()))
+	assert.Equal(t, English, FromContext(WithLang(context.Background(), English)))
+}
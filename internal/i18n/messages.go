@@ -0,0 +1,386 @@
+package i18n
+
+// MessageID - ключ сообщения в каталоге. Ключи стабильны, текст может меняться.
+type MessageID string
+
+// Ошибки запроса
+const (
+	InvalidRequestBody      MessageID = "invalid_request_body"
+	InvalidQuery            MessageID = "invalid_query"
+	ParamRequired           MessageID = "param_required"
+	AllFieldsRequired       MessageID = "all_fields_required"
+	PRIDRequired            MessageID = "pr_id_required"
+	ReassignFieldsRequired  MessageID = "reassign_fields_required"
+	ReviewFieldsRequired    MessageID = "review_fields_required"
+	InvalidReviewState      MessageID = "invalid_review_state"
+	TeamNameRequired        MessageID = "team_name_required"
+	MemberIDRequired        MessageID = "member_id_required"
+	DuplicateMemberIDs      MessageID = "duplicate_member_ids"
+	UserIDRequired          MessageID = "user_id_required"
+	InvalidGiniThreshold    MessageID = "invalid_gini_threshold"
+	UnsupportedRosterFormat MessageID = "unsupported_roster_format"
+	InvalidDryRun           MessageID = "invalid_dry_run"
+	InvalidRoster           MessageID = "invalid_roster"
+	DuplicateRosterUser     MessageID = "duplicate_roster_user"
+	InvalidLastEventID      MessageID = "invalid_last_event_id"
+	InvalidPreferences      MessageID = "invalid_preferences"
+	InvalidIdempotencyKey   MessageID = "invalid_idempotency_key"
+	IdempotencyKeyReused    MessageID = "idempotency_key_reused"
+	IdempotencyKeyInUse     MessageID = "idempotency_key_in_use"
+)
+
+// Ошибки предметной области
+const (
+	UserNotFound      MessageID = "user_not_found"
+	TeamNotFound      MessageID = "team_not_found"
+	PRNotFound        MessageID = "pr_not_found"
+	PRMerged          MessageID = "pr_merged"
+	NotAssigned       MessageID = "not_assigned"
+	PRVersionConflict MessageID = "pr_version_conflict"
+)
+
+// Внутренние ошибки
+const (
+	CreatePRFailed        MessageID = "create_pr_failed"
+	MergePRFailed         MessageID = "merge_pr_failed"
+	ReassignFailed        MessageID = "reassign_failed"
+	SaveReviewFailed      MessageID = "save_review_failed"
+	ListPRsFailed         MessageID = "list_prs_failed"
+	GetPRFailed           MessageID = "get_pr_failed"
+	SearchPRsFailed       MessageID = "search_prs_failed"
+	StatsFailed           MessageID = "stats_failed"
+	CreateTeamFailed      MessageID = "create_team_failed"
+	GetTeamFailed         MessageID = "get_team_failed"
+	UpdateUserFailed      MessageID = "update_user_failed"
+	ReviewPRsFailed       MessageID = "review_prs_failed"
+	ImportRosterFailed    MessageID = "import_roster_failed"
+	ReconcileRosterFailed MessageID = "reconcile_roster_failed"
+	ExportRosterFailed    MessageID = "export_roster_failed"
+	OpenAPIFailed         MessageID = "openapi_failed"
+	GetPreferencesFailed  MessageID = "get_preferences_failed"
+	SavePreferencesFailed MessageID = "save_preferences_failed"
+)
+
+// Успешные ответы
+const (
+	PRCreated              MessageID = "pr_created"
+	PRMergeSucceeded       MessageID = "pr_merge_succeeded"
+	ReviewerReassigned     MessageID = "reviewer_reassigned"
+	ReviewSaved            MessageID = "review_saved"
+	PRsFound               MessageID = "prs_found"
+	PRFound                MessageID = "pr_found"
+	TeamCreated            MessageID = "team_created"
+	TeamFound              MessageID = "team_found"
+	UserActivated          MessageID = "user_activated"
+	UserDeactivated        MessageID = "user_deactivated"
+	ReviewPRsFound         MessageID = "review_prs_found"
+	NoReviewPRs            MessageID = "no_review_prs"
+	RosterImported         MessageID = "roster_imported"
+	RosterImportPlanned    MessageID = "roster_import_planned"
+	RosterReconciled       MessageID = "roster_reconciled"
+	RosterReconcilePlanned MessageID = "roster_reconcile_planned"
+	PreferencesFound       MessageID = "preferences_found"
+	PreferencesSaved       MessageID = "preferences_saved"
+)
+
+// Ошибки протокола HTTP, которые возвращает echo
+const (
+	HTTPBadRequest       MessageID = "http_bad_request"
+	HTTPUnauthorized     MessageID = "http_unauthorized"
+	HTTPNotFound         MessageID = "http_not_found"
+	HTTPMethodNotAllowed MessageID = "http_method_not_allowed"
+	HTTPPayloadTooLarge  MessageID = "http_payload_too_large"
+	HTTPUnsupportedMedia MessageID = "http_unsupported_media"
+	HTTPInternal         MessageID = "http_internal"
+)
+
+var catalog = map[MessageID]map[Lang]string{
+	InvalidRequestBody: {
+		Russian: "Неверный формат запроса",
+		English: "Invalid request body",
+	},
+	InvalidQuery: {
+		Russian: "Неверные параметры выборки: %s",
+		English: "Invalid query parameters: %s",
+	},
+	ParamRequired: {
+		Russian: "Параметр %s обязателен",
+		English: "Parameter %s is required",
+	},
+	AllFieldsRequired: {
+		Russian: "Все поля обязательны для заполнения",
+		English: "All fields are required",
+	},
+	PRIDRequired: {
+		Russian: "ID pull request обязателен",
+		English: "Pull request ID is required",
+	},
+	ReassignFieldsRequired: {
+		Russian: "ID pull request и старого ревьювера обязательны",
+		English: "Pull request ID and old reviewer ID are required",
+	},
+	ReviewFieldsRequired: {
+		Russian: "ID pull request и ревьювера обязательны",
+		English: "Pull request ID and reviewer ID are required",
+	},
+	InvalidReviewState: {
+		Russian: "Состояние ревью должно быть APPROVED или CHANGES_REQUESTED",
+		English: "Review state must be APPROVED or CHANGES_REQUESTED",
+	},
+	TeamNameRequired: {
+		Russian: "Название команды обязательно",
+		English: "Team name is required",
+	},
+	MemberIDRequired: {
+		Russian: "ID участника команды не может быть пустым",
+		English: "Team member ID must not be empty",
+	},
+	DuplicateMemberIDs: {
+		Russian: "Обнаружены дублирующиеся ID участников",
+		English: "Duplicate team member IDs",
+	},
+	UserIDRequired: {
+		Russian: "ID пользователя обязательно",
+		English: "User ID is required",
+	},
+	InvalidGiniThreshold: {
+		Russian: "gini_threshold должен быть числом от 0 до 1",
+		English: "gini_threshold must be a number between 0 and 1",
+	},
+	InvalidLastEventID: {
+		Russian: "Заголовок Last-Event-ID должен быть неотрицательным целым числом",
+		English: "Last-Event-ID header must be a non-negative integer",
+	},
+	UnsupportedRosterFormat: {
+		Russian: "Неподдерживаемый формат, используйте json, yaml или csv",
+		English: "Unsupported format, use json, yaml or csv",
+	},
+	InvalidDryRun: {
+		Russian: "Параметр dry_run должен быть true или false",
+		English: "Parameter dry_run must be true or false",
+	},
+	InvalidRoster: {
+		Russian: "Не удалось разобрать состав команд",
+		English: "Failed to parse the team roster",
+	},
+	DuplicateRosterUser: {
+		Russian: "Пользователь указан в составе несколько раз",
+		English: "User appears in the roster more than once",
+	},
+	InvalidPreferences: {
+		Russian: "Неверные настройки уведомлений: %s",
+		English: "Invalid notification preferences: %s",
+	},
+	InvalidIdempotencyKey: {
+		Russian: "Заголовок Idempotency-Key должен содержать от 1 до 255 печатных ASCII-символов",
+		English: "Idempotency-Key header must contain 1 to 255 printable ASCII characters",
+	},
+	IdempotencyKeyReused: {
+		Russian: "Ключ Idempotency-Key уже использован для другого запроса",
+		English: "Idempotency-Key has already been used for a different request",
+	},
+	IdempotencyKeyInUse: {
+		Russian: "Запрос с этим Idempotency-Key еще выполняется, повторите позже",
+		English: "A request with this Idempotency-Key is still in progress, retry later",
+	},
+
+	UserNotFound: {
+		Russian: "Пользователь не найден",
+		English: "User not found",
+	},
+	TeamNotFound: {
+		Russian: "Команда не найдена",
+		English: "Team not found",
+	},
+	PRNotFound: {
+		Russian: "Pull request не найден",
+		English: "Pull request not found",
+	},
+	PRMerged: {
+		Russian: "Pull request уже смержен",
+		English: "Pull request is already merged",
+	},
+	NotAssigned: {
+		Russian: "Пользователь не назначен ревьювером этого pull request",
+		English: "User is not assigned as a reviewer of this pull request",
+	},
+	PRVersionConflict: {
+		Russian: "Pull request был изменен другим запросом, получите его заново и повторите",
+		English: "Pull request was modified by another request, fetch it again and retry",
+	},
+
+	CreatePRFailed: {
+		Russian: "Не удалось создать pull request",
+		English: "Failed to create pull request",
+	},
+	MergePRFailed: {
+		Russian: "Не удалось выполнить merge pull request",
+		English: "Failed to merge pull request",
+	},
+	ReassignFailed: {
+		Russian: "Не удалось перераспределить ревьювера",
+		English: "Failed to reassign reviewer",
+	},
+	SaveReviewFailed: {
+		Russian: "Не удалось сохранить ревью",
+		English: "Failed to save review",
+	},
+	ListPRsFailed: {
+		Russian: "Не удалось получить список pull requests",
+		English: "Failed to list pull requests",
+	},
+	GetPRFailed: {
+		Russian: "Не удалось получить pull request",
+		English: "Failed to get pull request",
+	},
+	SearchPRsFailed: {
+		Russian: "Не удалось выполнить поиск pull requests",
+		English: "Failed to search pull requests",
+	},
+	StatsFailed: {
+		Russian: "Не удалось получить статистику назначений",
+		English: "Failed to get assignment statistics",
+	},
+	CreateTeamFailed: {
+		Russian: "Не удалось создать команду",
+		English: "Failed to create team",
+	},
+	GetTeamFailed: {
+		Russian: "Не удалось получить информацию о команде",
+		English: "Failed to get team",
+	},
+	UpdateUserFailed: {
+		Russian: "Не удалось обновить статус пользователя",
+		English: "Failed to update user status",
+	},
+	ReviewPRsFailed: {
+		Russian: "Не удалось получить список pull requests для ревью",
+		English: "Failed to list pull requests for review",
+	},
+	ImportRosterFailed: {
+		Russian: "Не удалось импортировать состав команд",
+		English: "Failed to import the team roster",
+	},
+	ReconcileRosterFailed: {
+		Russian: "Не удалось синхронизировать состав команд",
+		English: "Failed to reconcile the team roster",
+	},
+	ExportRosterFailed: {
+		Russian: "Не удалось выгрузить состав команд",
+		English: "Failed to export the team roster",
+	},
+	OpenAPIFailed: {
+		Russian: "Не удалось сформировать описание API",
+		English: "Failed to build the API description",
+	},
+	GetPreferencesFailed: {
+		Russian: "Не удалось получить настройки уведомлений",
+		English: "Failed to get notification preferences",
+	},
+	SavePreferencesFailed: {
+		Russian: "Не удалось сохранить настройки уведомлений",
+		English: "Failed to save notification preferences",
+	},
+
+	PRCreated: {
+		Russian: "Pull request успешно создан",
+		English: "Pull request created",
+	},
+	PRMergeSucceeded: {
+		Russian: "Pull request успешно объединен",
+		English: "Pull request merged",
+	},
+	ReviewerReassigned: {
+		Russian: "Ревьювер успешно перераспределен",
+		English: "Reviewer reassigned",
+	},
+	ReviewSaved: {
+		Russian: "Ревью сохранено",
+		English: "Review saved",
+	},
+	PRsFound: {
+		Russian: "Найдено pull requests: %d",
+		English: "Pull requests found: %d",
+	},
+	PRFound: {
+		Russian: "Pull request найден",
+		English: "Pull request found",
+	},
+	TeamCreated: {
+		Russian: "Команда успешно создана",
+		English: "Team created",
+	},
+	TeamFound: {
+		Russian: "Информация о команде успешно получена",
+		English: "Team found",
+	},
+	UserActivated: {
+		Russian: "Пользователь успешно активирован",
+		English: "User activated",
+	},
+	UserDeactivated: {
+		Russian: "Пользователь успешно деактивирован",
+		English: "User deactivated",
+	},
+	ReviewPRsFound: {
+		Russian: "Найдено pull requests: %d, ожидают ревью: %d",
+		English: "Pull requests found: %d, awaiting review: %d",
+	},
+	NoReviewPRs: {
+		Russian: "Нет pull requests для ревью",
+		English: "No pull requests for review",
+	},
+	RosterImported: {
+		Russian: "Состав команд успешно импортирован",
+		English: "Team roster imported",
+	},
+	RosterImportPlanned: {
+		Russian: "Изменения рассчитаны, данные не изменены",
+		English: "Changes calculated, no data was modified",
+	},
+	RosterReconciled: {
+		Russian: "Состав команд синхронизирован",
+		English: "Team roster reconciled",
+	},
+	RosterReconcilePlanned: {
+		Russian: "План синхронизации рассчитан, данные не изменены",
+		English: "Reconciliation plan calculated, no data was modified",
+	},
+	PreferencesFound: {
+		Russian: "Настройки уведомлений получены",
+		English: "Notification preferences found",
+	},
+	PreferencesSaved: {
+		Russian: "Настройки уведомлений сохранены",
+		English: "Notification preferences saved",
+	},
+
+	HTTPBadRequest: {
+		Russian: "Неверный запрос",
+		English: "Bad request",
+	},
+	HTTPUnauthorized: {
+		Russian: "Требуется авторизация",
+		English: "Unauthorized",
+	},
+	HTTPNotFound: {
+		Russian: "Ресурс не найден",
+		English: "Not found",
+	},
+	HTTPMethodNotAllowed: {
+		Russian: "Метод не поддерживается",
+		English: "Method not allowed",
+	},
+	HTTPPayloadTooLarge: {
+		Russian: "Слишком большое тело запроса",
+		English: "Request body too large",
+	},
+	HTTPUnsupportedMedia: {
+		Russian: "Неподдерживаемый тип содержимого",
+		English: "Unsupported media type",
+	},
+	HTTPInternal: {
+		Russian: "Внутренняя ошибка сервера",
+		English: "Internal server error",
+	},
+}
@@ -0,0 +1,247 @@
+// Package idempotency делает POST-запросы с заголовком Idempotency-Key безопасными
+// для повтора: первый запрос выполняется, а его ответ сохраняется на TTL и отдается
+// на повторы с тем же ключом без повторного выполнения. Ключ действует в пределах
+// метода, пути и заголовка Authorization запроса. Пока запрос выполняется, ключ занят
+// на короткую аренду: если процесс упадет, не сохранив ответ, ключ освободится по ее
+// истечении, а не через TTL.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/i18n"
+	logpkg "github.com/vnchk1/pr-manager/internal/logger"
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderKey = "Idempotency-Key"
+	// HeaderReplayed отмечает ответ, взятый из сохраненных
+	HeaderReplayed = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+	// maxBodySize ограничивает тело запроса с ключом: оно читается в память целиком
+	maxBodySize = 1 << 20
+)
+
+// Store хранит ключи и ответы на запросы
+type Store interface {
+	// Reserve занимает ключ и возвращает true; если ключ уже занят и не истек,
+	// возвращает его запись и false
+	Reserve(ctx context.Context, key, requestHash string, expiresAt time.Time) (*models.IdempotencyRecord, bool, error)
+	// Complete сохраняет ответ и продлевает ключ до record.ExpiresAt
+	Complete(ctx context.Context, record *models.IdempotencyRecord) error
+	// Release освобождает ключ, ответ на который не сохранен
+	Release(ctx context.Context, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type Keys struct {
+	store Store
+	// ttl - срок хранения ответа, lease - срок, на который ключ занимается под запрос
+	ttl    time.Duration
+	lease  time.Duration
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+func New(store Store, ttl, lease time.Duration, logger *slog.Logger) *Keys {
+	return &Keys{store: store, ttl: ttl, lease: lease, logger: logger, now: time.Now}
+}
+
+// Middleware обрабатывает POST-запросы с Idempotency-Key. Повтор с тем же телом
+// получает сохраненный ответ, с другим телом - 422, а пока первый запрос не
+// завершился - 409. Ответы 5xx не сохраняются: такой запрос можно повторить.
+func (k *Keys) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error { //nolint:varnamelen
+			req := c.Request()
+			key := req.Header.Get(HeaderKey)
+			if req.Method != http.MethodPost || key == "" {
+				return next(c)
+			}
+			if !validKey(key) {
+				return echo.NewHTTPError(http.StatusBadRequest, string(i18n.InvalidIdempotencyKey))
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(c.Response(), req.Body, maxBodySize))
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					return echo.NewHTTPError(http.StatusRequestEntityTooLarge).SetInternal(err)
+				}
+				return echo.NewHTTPError(http.StatusBadRequest).SetInternal(err)
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := req.Context()
+			hash := requestHash(req, body)
+			record, reserved, err := k.store.Reserve(ctx, scopedKey(req, key), hash, k.now().Add(k.lease))
+			if err != nil {
+				return err
+			}
+
+			if !reserved {
+				switch {
+				case record.RequestHash != hash:
+					return echo.NewHTTPError(http.StatusUnprocessableEntity, string(i18n.IdempotencyKeyReused))
+				case !record.Completed():
+					return echo.NewHTTPError(http.StatusConflict, string(i18n.IdempotencyKeyInUse))
+				}
+				return replay(c, record)
+			}
+
+			return k.execute(c, next, record)
+		}
+	}
+}
+
+// execute выполняет запрос, занявший ключ, и сохраняет ответ. Если ответ не сохранен
+// (5xx, паника обработчика, ошибка хранилища), ключ освобождается, иначе повторы
+// получали бы 409 до истечения TTL.
+func (k *Keys) execute(c echo.Context, next echo.HandlerFunc, record *models.IdempotencyRecord) error {
+	res := c.Response()
+	recorder := &responseRecorder{ResponseWriter: res.Writer}
+	res.Writer = recorder
+
+	ctx := context.WithoutCancel(c.Request().Context())
+	logger := logpkg.FromContextOr(ctx, k.logger)
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			res.Writer = recorder.ResponseWriter
+			k.release(ctx, logger, record.Key)
+			panic(recovered)
+		}
+	}()
+
+	err := next(c)
+	if err != nil {
+		// Ошибку нужно записать в ответ здесь, чтобы она попала в сохраненный ответ;
+		// обработчик echo пропустит уже отправленный ответ
+		c.Error(err)
+	}
+	res.Writer = recorder.ResponseWriter
+
+	if res.Status >= http.StatusInternalServerError {
+		k.release(ctx, logger, record.Key)
+		return err
+	}
+
+	record.StatusCode = res.Status
+	record.Header = storedHeader(res.Header())
+	record.Body = recorder.body.Bytes()
+	record.ExpiresAt = k.now().Add(k.ttl)
+	if completeErr := k.store.Complete(ctx, record); completeErr != nil {
+		logger.Error("failed to save idempotent response", "error", completeErr)
+		k.release(ctx, logger, record.Key)
+	}
+
+	return err
+}
+
+func (k *Keys) release(ctx context.Context, logger *slog.Logger, key string) {
+	if err := k.store.Release(ctx, key); err != nil {
+		logger.Error("failed to release idempotency key", "error", err)
+	}
+}
+
+// Run раз в interval удаляет истекшие ключи, пока не отменен контекст
+func (k *Keys) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := k.store.DeleteExpired(ctx)
+			if err != nil {
+				k.logger.Error("failed to delete expired idempotency keys", "error", err)
+				continue
+			}
+			if deleted > 0 {
+				k.logger.Debug("expired idempotency keys deleted", "count", deleted)
+			}
+		}
+	}
+}
+
+func replay(c echo.Context, record *models.IdempotencyRecord) error {
+	header := c.Response().Header()
+	for name, values := range record.Header {
+		header[name] = values
+	}
+	header.Set(HeaderReplayed, "true")
+
+	c.Response().WriteHeader(record.StatusCode)
+	_, err := c.Response().Write(record.Body)
+	return err
+}
+
+// scopedKey - ключ записи в хранилище: ключ клиента вместе с методом, путем и заголовком
+// Authorization. Одинаковые ключи на разных маршрутах или от разных клиентов не пересекаются.
+// Запросы без Authorization делят пространство ключей маршрута.
+func scopedKey(req *http.Request, key string) string {
+	h := sha256.New()
+	h.Write([]byte(req.Method + " " + req.URL.Path + "\n"))
+	h.Write([]byte(req.Header.Get(echo.HeaderAuthorization) + "\n"))
+	h.Write([]byte(key))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// requestHash отличает запросы с тем же ключом: метод, путь с параметрами и тело
+func requestHash(req *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(req.Method + " " + req.URL.RequestURI() + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// storedHeader - заголовки ответа для повтора. X-Request-ID у каждого ответа свой.
+func storedHeader(header http.Header) map[string][]string {
+	stored := header.Clone()
+	stored.Del(echo.HeaderXRequestID)
+	stored.Del(echo.HeaderContentLength)
+	return stored
+}
+
+func validKey(key string) bool {
+	if len(key) > maxKeyLength {
+		return false
+	}
+
+	for _, r := range key {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// responseRecorder копирует тело ответа, передавая его клиенту
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
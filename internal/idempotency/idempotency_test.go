@@ -0,0 +1,308 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memStore повторяет семантику таблицы idempotency_keys
+type memStore struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	records map[string]*models.IdempotencyRecord
+	// failComplete заставляет Complete вернуть ошибку
+	failComplete bool
+}
+
+func newMemStore(now func() time.Time) *memStore {
+	return &memStore{now: now, records: make(map[string]*models.IdempotencyRecord)}
+}
+
+func (s *memStore) Reserve(_ context.Context, key, requestHash string, expiresAt time.Time) (*models.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[key]; ok && record.ExpiresAt.After(s.now()) {
+		found := *record
+		return &found, false, nil
+	}
+	s.records[key] = &models.IdempotencyRecord{Key: key, RequestHash: requestHash, ExpiresAt: expiresAt}
+	return &models.IdempotencyRecord{Key: key, RequestHash: requestHash, ExpiresAt: expiresAt}, true, nil
+}
+
+func (s *memStore) Complete(_ context.Context, record *models.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failComplete {
+		return errors.New("connection reset")
+	}
+	saved := *record
+	s.records[record.Key] = &saved
+	return nil
+}
+
+func (s *memStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[key]; ok && !record.Completed() {
+		delete(s.records, key)
+	}
+	return nil
+}
+
+func (s *memStore) DeleteExpired(_ context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for key, record := range s.records {
+		if !record.ExpiresAt.After(s.now()) {
+			delete(s.records, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+type testServer struct {
+	echo  *echo.Echo
+	now   time.Time
+	calls int
+	// fail заставляет обработчик ответить 503, panic - упасть с паникой
+	fail  bool
+	panic bool
+	// block задерживает обработчик, пока канал не закрыт; о входе в обработчик
+	// сообщает entered
+	block   chan struct{}
+	entered chan struct{}
+}
+
+func newTestServer(t *testing.T) (*testServer, *memStore) {
+	t.Helper()
+
+	ts := &testServer{echo: echo.New(), now: time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)}
+	store := newMemStore(func() time.Time { return ts.now })
+	keys := New(store, time.Hour, time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	keys.now = func() time.Time { return ts.now }
+
+	ts.echo.Use(keys.Middleware())
+	ts.echo.POST("/reassign", func(c echo.Context) error {
+		if ts.block != nil {
+			ts.entered <- struct{}{}
+			<-ts.block
+		}
+		ts.calls++
+		if ts.panic {
+			panic("handler failed")
+		}
+		if ts.fail {
+			return echo.NewHTTPError(http.StatusServiceUnavailable)
+		}
+		body, _ := io.ReadAll(c.Request().Body)
+		c.Response().Header().Set("X-Reviewer", "u"+string(rune('0'+ts.calls)))
+		return c.String(http.StatusCreated, "reviewer "+string(rune('0'+ts.calls))+" for "+string(body))
+	})
+	ts.echo.GET("/reassign", func(c echo.Context) error {
+		ts.calls++
+		return c.NoContent(http.StatusOK)
+	})
+	ts.echo.POST("/merge", func(c echo.Context) error {
+		ts.calls++
+		return c.NoContent(http.StatusOK)
+	})
+
+	return ts, store
+}
+
+func (ts *testServer) do(method, key, body string) *httptest.ResponseRecorder {
+	return ts.doAt(method, "/reassign", key, "", body)
+}
+
+func (ts *testServer) doAt(method, path, key, authorization, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(HeaderKey, key)
+	}
+	if authorization != "" {
+		req.Header.Set(echo.HeaderAuthorization, authorization)
+	}
+	rec := httptest.NewRecorder()
+	ts.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestReplaysStoredResponse(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	first := ts.do(http.MethodPost, "ci-42", `{"pr":"pr-1"}`)
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(HeaderReplayed))
+
+	second := ts.do(http.MethodPost, "ci-42", `{"pr":"pr-1"}`)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, "u1", second.Header().Get("X-Reviewer"))
+	assert.Equal(t, "true", second.Header().Get(HeaderReplayed))
+	assert.Equal(t, 1, ts.calls, "the retry must not run the handler again")
+
+	// Без ключа и не для POST запрос выполняется каждый раз
+	ts.do(http.MethodPost, "", `{"pr":"pr-1"}`)
+	ts.do(http.MethodGet, "ci-42", "")
+	assert.Equal(t, 3, ts.calls)
+}
+
+func TestKeyReusedWithDifferentBody(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	require.Equal(t, http.StatusCreated, ts.do(http.MethodPost, "ci-42", `{"pr":"pr-1"}`).Code)
+	rec := ts.do(http.MethodPost, "ci-42", `{"pr":"pr-2"}`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "idempotency_key_reused")
+	assert.Equal(t, 1, ts.calls)
+}
+
+func TestKeyInProgress(t *testing.T) {
+	ts, _ := newTestServer(t)
+	ts.block = make(chan struct{})
+	ts.entered = make(chan struct{})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- ts.do(http.MethodPost, "ci-42", `{"pr":"pr-1"}`) }()
+
+	// Первый запрос занял ключ и ждет в обработчике
+	<-ts.entered
+	rec := ts.do(http.MethodPost, "ci-42", `{"pr":"pr-1"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "idempotency_key_in_use")
+
+	close(ts.block)
+	assert.Equal(t, http.StatusCreated, (<-done).Code)
+	assert.Equal(t, 1, ts.calls)
+}
+
+func TestServerErrorReleasesKey(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	ts.fail = true
+	require.Equal(t, http.StatusServiceUnavailable, ts.do(http.MethodPost, "ci-42", `{"pr":"pr-1"}`).Code)
+
+	ts.fail = false
+	rec := ts.do(http.MethodPost, "ci-42", `{"pr":"pr-1"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Empty(t, rec.Header().Get(HeaderReplayed))
+	assert.Equal(t, 2, ts.calls)
+}
+
+func TestPanicReleasesKey(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	ts.panic = true
+	assert.Panics(t, func() { ts.do(http.MethodPost, "ci-42", `{"pr":"pr-1"}`) })
+
+	ts.panic = false
+	rec := ts.do(http.MethodPost, "ci-42", `{"pr":"pr-1"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, 2, ts.calls)
+}
+
+func TestFailedSaveReleasesKey(t *testing.T) {
+	ts, store := newTestServer(t)
+
+	store.failComplete = true
+	require.Equal(t, http.StatusCreated, ts.do(http.MethodPost, "ci-42", `{"pr":"pr-1"}`).Code)
+
+	store.failComplete = false
+	rec := ts.do(http.MethodPost, "ci-42", `{"pr":"pr-1"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code, "an unsaved response must not leave the key in progress")
+	assert.Empty(t, rec.Header().Get(HeaderReplayed))
+	assert.Equal(t, 2, ts.calls)
+}
+
+func TestKeyScopedByRouteAndClient(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	require.Equal(t, http.StatusCreated, ts.doAt(http.MethodPost, "/reassign", "ci-42", "Bearer a", `{}`).Code)
+
+	// Тот же ключ на другом маршруте и от другого клиента - независимые запросы
+	assert.Equal(t, http.StatusOK, ts.doAt(http.MethodPost, "/merge", "ci-42", "Bearer a", `{}`).Code)
+	rec := ts.doAt(http.MethodPost, "/reassign", "ci-42", "Bearer b", `{"pr":"pr-2"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Empty(t, rec.Header().Get(HeaderReplayed))
+	assert.Equal(t, 3, ts.calls)
+
+	replayed := ts.doAt(http.MethodPost, "/reassign", "ci-42", "Bearer a", `{}`)
+	assert.Equal(t, "true", replayed.Header().Get(HeaderReplayed))
+	assert.Equal(t, 3, ts.calls)
+}
+
+func TestExpiredKey(t *testing.T) {
+	ts, store := newTestServer(t)
+
+	require.Equal(t, http.StatusCreated, ts.do(http.MethodPost, "ci-42", `{"pr":"pr-1"}`).Code)
+
+	ts.now = ts.now.Add(time.Hour)
+	rec := ts.do(http.MethodPost, "ci-42", `{"pr":"pr-2"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code, "an expired key can be used for a new request")
+	assert.Equal(t, 2, ts.calls)
+
+	ts.now = ts.now.Add(2 * time.Hour)
+	deleted, err := store.DeleteExpired(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
+
+func TestLeaseFreesKeyOfLostRequest(t *testing.T) {
+	ts, store := newTestServer(t)
+
+	// Процесс занял ключ и упал, не сохранив ответ
+	req := httptest.NewRequest(http.MethodPost, "/reassign", nil)
+	_, reserved, err := store.Reserve(context.Background(), scopedKey(req, "ci-42"),
+		requestHash(req, []byte(`{"pr":"pr-1"}`)), ts.now.Add(time.Minute))
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	assert.Equal(t, http.StatusConflict, ts.do(http.MethodPost, "ci-42", `{"pr":"pr-1"}`).Code)
+
+	// По истечении аренды, а не TTL, ключ снова можно использовать; сохраненный ответ
+	// живет весь TTL
+	ts.now = ts.now.Add(time.Minute)
+	require.Equal(t, http.StatusCreated, ts.do(http.MethodPost, "ci-42", `{"pr":"pr-1"}`).Code)
+
+	ts.now = ts.now.Add(30 * time.Minute)
+	rec := ts.do(http.MethodPost, "ci-42", `{"pr":"pr-1"}`)
+	assert.Equal(t, "true", rec.Header().Get(HeaderReplayed))
+	assert.Equal(t, 1, ts.calls)
+}
+
+func TestBodyTooLarge(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	rec := ts.do(http.MethodPost, "ci-42", strings.Repeat("x", maxBodySize+1))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Zero(t, ts.calls)
+}
+
+func TestInvalidKey(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	assert.Equal(t, http.StatusBadRequest, ts.do(http.MethodPost, "bad key", `{}`).Code)
+	assert.Equal(t, http.StatusBadRequest, ts.do(http.MethodPost, strings.Repeat("k", maxKeyLength+1), `{}`).Code)
+	assert.Zero(t, ts.calls)
+}
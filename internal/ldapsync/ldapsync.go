@@ -0,0 +1,253 @@
+package ldapsync
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/config"
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// accountDisable - флаг ACCOUNTDISABLE в userAccountControl Active Directory
+const accountDisable = 0x2
+
+// RosterService применяет состав групп одной транзакцией, переназначая ревью
+// деактивированных и перешедших в другую команду пользователей
+type RosterService interface {
+	SyncTeams(ctx context.Context, roster *models.Roster) (*models.ReconcilePlan, error)
+}
+
+// Result - число изменений, внесенных одной синхронизацией
+type Result struct {
+	TeamsCreated     int
+	UsersCreated     int
+	UsersUpdated     int
+	UsersDeactivated int
+}
+
+// Syncer переносит группы LDAP в команды. Управляются только команды, для которых
+// есть группа: участники такой команды, исключенные из группы, деактивируются.
+type Syncer struct {
+	cfg    config.LDAPConfig
+	roster RosterService
+	logger *slog.Logger
+}
+
+func New(cfg config.LDAPConfig, roster RosterService, logger *slog.Logger) *Syncer {
+	return &Syncer{cfg: cfg, roster: roster, logger: logger}
+}
+
+// Run синхронизирует состав сразу и затем с интервалом SyncInterval до отмены контекста
+func (s *Syncer) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.SyncInterval)
+	defer ticker.Stop()
+
+	for {
+		result, err := s.Sync(ctx)
+		if err != nil {
+			s.logger.Warn("ldap sync failed", "error", err)
+		} else {
+			s.logger.Info("ldap sync finished",
+				"teams_created", result.TeamsCreated,
+				"users_created", result.UsersCreated,
+				"users_updated", result.UsersUpdated,
+				"users_deactivated", result.UsersDeactivated,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Syncer) Sync(ctx context.Context) (*Result, error) {
+	roster, err := s.Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := s.roster.SyncTeams(ctx, roster)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		TeamsCreated:     len(plan.TeamsCreated),
+		UsersCreated:     len(plan.UsersCreated),
+		UsersUpdated:     len(plan.UsersUpdated) + len(plan.UsersMoved),
+		UsersDeactivated: len(plan.UsersDeactivated),
+	}, nil
+}
+
+// Fetch читает группы и их участников из LDAP. Пользователь, состоящий в нескольких
+// группах, попадает в первую по алфавиту.
+func (s *Syncer) Fetch(ctx context.Context) (*models.Roster, error) {
+	conn, err := s.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	groups, err := conn.Search(ldap.NewSearchRequest(
+		s.cfg.GroupBaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		s.timeLimit(),
+		false,
+		s.cfg.GroupFilter,
+		[]string{s.cfg.GroupNameAttr, s.cfg.MemberAttr},
+		nil,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search groups: %w", err)
+	}
+
+	sort.Slice(groups.Entries, func(i, j int) bool {
+		return groups.Entries[i].GetAttributeValue(s.cfg.GroupNameAttr) < groups.Entries[j].GetAttributeValue(s.cfg.GroupNameAttr)
+	})
+
+	roster := &models.Roster{}
+	owners := make(map[string]string)
+	cache := make(map[string]*models.RosterMember)
+
+	for _, group := range groups.Entries {
+		team := &models.RosterTeam{Name: group.GetAttributeValue(s.cfg.GroupNameAttr)}
+		if team.Name == "" {
+			s.logger.Warn("ldap group has no name attribute, skipping", "dn", group.DN)
+			continue
+		}
+
+		for _, memberDN := range group.GetAttributeValues(s.cfg.MemberAttr) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			member, err := s.lookupMember(conn, memberDN, cache)
+			if err != nil {
+				return nil, err
+			}
+			if member == nil {
+				continue
+			}
+
+			if owner, ok := owners[member.UserID]; ok {
+				s.logger.Warn("ldap user belongs to several groups, keeping the first one",
+					"user_id", member.UserID, "team", owner, "skipped_team", team.Name)
+				continue
+			}
+
+			owners[member.UserID] = team.Name
+			team.Members = append(team.Members, member)
+		}
+
+		roster.Teams = append(roster.Teams, team)
+	}
+
+	return roster, nil
+}
+
+func (s *Syncer) connect() (*ldap.Conn, error) {
+	conn, err := ldap.DialURL(s.cfg.URL, ldap.DialWithDialer(&net.Dialer{Timeout: s.cfg.Timeout}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to ldap: %w", err)
+	}
+	conn.SetTimeout(s.cfg.Timeout)
+
+	if s.cfg.StartTLS {
+		parsed, err := url.Parse(s.cfg.URL)
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("invalid ldap url: %w", err)
+		}
+		if err := conn.StartTLS(&tls.Config{ServerName: parsed.Hostname()}); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+
+	if s.cfg.BindDN != "" {
+		if err := conn.Bind(s.cfg.BindDN, s.cfg.BindPassword); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to bind to ldap: %w", err)
+		}
+	}
+
+	return conn, nil
+}
+
+// lookupMember читает учетную запись участника группы. Несуществующие записи
+// и записи без идентификатора пропускаются.
+func (s *Syncer) lookupMember(conn *ldap.Conn, dn string, cache map[string]*models.RosterMember) (*models.RosterMember, error) {
+	if member, ok := cache[dn]; ok {
+		return member, nil
+	}
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		dn,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		1,
+		s.timeLimit(),
+		false,
+		"(objectClass=*)",
+		[]string{s.cfg.UserIDAttr, s.cfg.UsernameAttr, s.cfg.DisabledAttr},
+		nil,
+	))
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+		s.logger.Warn("ldap group member not found", "dn", dn)
+		cache[dn] = nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read member %s: %w", dn, err)
+	}
+
+	var member *models.RosterMember
+	if len(result.Entries) > 0 {
+		entry := result.Entries[0]
+		member = &models.RosterMember{
+			UserID:   entry.GetAttributeValue(s.cfg.UserIDAttr),
+			Username: entry.GetAttributeValue(s.cfg.UsernameAttr),
+			IsActive: !s.disabled(entry),
+		}
+		if member.UserID == "" {
+			s.logger.Warn("ldap user has no id attribute, skipping", "dn", dn, "attribute", s.cfg.UserIDAttr)
+			member = nil
+		} else if member.Username == "" {
+			member.Username = member.UserID
+		}
+	}
+
+	cache[dn] = member
+	return member, nil
+}
+
+func (s *Syncer) disabled(entry *ldap.Entry) bool {
+	value := entry.GetAttributeValue(s.cfg.DisabledAttr)
+	if strings.EqualFold(s.cfg.DisabledAttr, "userAccountControl") {
+		flags, err := strconv.Atoi(value)
+		return err == nil && flags&accountDisable != 0
+	}
+	return strings.EqualFold(value, "true")
+}
+
+// timeLimit - ограничение времени поиска на сервере в целых секундах. Округляется вверх,
+// иначе таймаут меньше секунды превратился бы в 0, то есть в отсутствие ограничения.
+func (s *Syncer) timeLimit() int {
+	return int(math.Ceil(s.cfg.Timeout.Seconds()))
+}
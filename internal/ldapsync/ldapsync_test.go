@@ -0,0 +1,195 @@
+package ldapsync
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/config"
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/repository"
+	"github.com/vnchk1/pr-manager/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDirectory() *stubDirectory {
+	return &stubDirectory{
+		bindDN:   "cn=sync,dc=example,dc=com",
+		password: "secret",
+		entries: map[string]map[string][]string{
+			"cn=backend,ou=groups,dc=example,dc=com": {
+				"objectClass": {"groupOfNames"},
+				"cn":          {"backend"},
+				"member": {
+					"uid=alice,ou=people,dc=example,dc=com",
+					"uid=bob,ou=people,dc=example,dc=com",
+				},
+			},
+			"cn=frontend,ou=groups,dc=example,dc=com": {
+				"objectClass": {"groupOfNames"},
+				"cn":          {"frontend"},
+				"member": {
+					"uid=carol,ou=people,dc=example,dc=com",
+					"uid=alice,ou=people,dc=example,dc=com",
+					"uid=ghost,ou=people,dc=example,dc=com",
+				},
+			},
+			"uid=alice,ou=people,dc=example,dc=com": {
+				"uid":                {"alice"},
+				"cn":                 {"Alice"},
+				"userAccountControl": {"512"},
+			},
+			"uid=bob,ou=people,dc=example,dc=com": {
+				"uid":                {"bob"},
+				"cn":                 {"Bob"},
+				"userAccountControl": {"514"},
+			},
+			"uid=carol,ou=people,dc=example,dc=com": {
+				"uid": {"carol"},
+				"cn":  {"Carol"},
+			},
+		},
+	}
+}
+
+func newConfig(url string) config.LDAPConfig {
+	return config.LDAPConfig{
+		URL:           url,
+		BindDN:        "cn=sync,dc=example,dc=com",
+		BindPassword:  "secret",
+		Timeout:       5 * time.Second,
+		SyncInterval:  time.Minute,
+		GroupBaseDN:   "ou=groups,dc=example,dc=com",
+		GroupFilter:   "(objectClass=groupOfNames)",
+		GroupNameAttr: "cn",
+		MemberAttr:    "member",
+		UserIDAttr:    "uid",
+		UsernameAttr:  "cn",
+		DisabledAttr:  "userAccountControl",
+	}
+}
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestFetch(t *testing.T) {
+	url := startStubDirectory(t, newDirectory())
+	syncer := New(newConfig(url), nil, discardLogger())
+
+	roster, err := syncer.Fetch(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, &models.Roster{Teams: []*models.RosterTeam{
+		{Name: "backend", Members: []*models.RosterMember{
+			{UserID: "alice", Username: "Alice", IsActive: true},
+			{UserID: "bob", Username: "Bob", IsActive: false},
+		}},
+		{Name: "frontend", Members: []*models.RosterMember{
+			{UserID: "carol", Username: "Carol", IsActive: true},
+		}},
+	}}, roster)
+}
+
+func TestFetchInvalidCredentials(t *testing.T) {
+	url := startStubDirectory(t, newDirectory())
+	cfg := newConfig(url)
+	cfg.BindPassword = "wrong"
+
+	_, err := New(cfg, nil, discardLogger()).Fetch(context.Background())
+	assert.ErrorContains(t, err, "failed to bind")
+}
+
+// fakeRosterRepo хранит состав в памяти и применяет план так же, как репозиторий
+type fakeRosterRepo struct {
+	repository.RosterRepository
+	users   map[string]*models.User
+	teams   map[string]bool
+	openPRs []*models.PullRequest
+	applied *models.ReconcilePlan
+}
+
+func (r *fakeRosterRepo) Sync(_ context.Context, dryRun bool, planner repository.RosterPlanner) (*models.ReconcilePlan, error) {
+	current := &models.Roster{}
+	for _, name := range slices.Sorted(maps.Keys(r.teams)) {
+		team := &models.RosterTeam{Name: name}
+		for _, id := range slices.Sorted(maps.Keys(r.users)) {
+			if user := r.users[id]; user.TeamName == name {
+				team.Members = append(team.Members, &models.RosterMember{UserID: id, Username: user.Username, IsActive: user.IsActive})
+			}
+		}
+		current.Teams = append(current.Teams, team)
+	}
+
+	plan, err := planner(current, r.openPRs)
+	if err != nil || dryRun || plan.Empty() {
+		return plan, err
+	}
+
+	for _, name := range plan.TeamsCreated {
+		r.teams[name] = true
+	}
+	for _, change := range plan.UserChanges() {
+		r.users[change.UserID] = &models.User{
+			ID:       change.UserID,
+			Username: change.After.Username,
+			TeamName: change.After.TeamName,
+			IsActive: change.After.IsActive,
+		}
+	}
+	r.applied = plan
+
+	return plan, nil
+}
+
+func TestSync(t *testing.T) {
+	url := startStubDirectory(t, newDirectory())
+	repo := &fakeRosterRepo{
+		users: map[string]*models.User{
+			"alice": {ID: "alice", Username: "Alice", TeamName: "legacy", IsActive: true},
+			"bob":   {ID: "bob", Username: "Bob", TeamName: "backend", IsActive: true},
+			"dave":  {ID: "dave", Username: "Dave", TeamName: "backend", IsActive: true},
+			"erin":  {ID: "erin", Username: "Erin", TeamName: "legacy", IsActive: true},
+		},
+		teams: map[string]bool{"backend": true, "legacy": true},
+		openPRs: []*models.PullRequest{
+			{ID: "pr-1", AuthorID: "erin", AssignedReviewers: []string{"dave"}, Version: 1},
+		},
+	}
+	syncer := New(newConfig(url), service.NewRosterService(repo, nil, nil), discardLogger())
+
+	result, err := syncer.Sync(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, &Result{TeamsCreated: 1, UsersCreated: 1, UsersUpdated: 1, UsersDeactivated: 2}, result)
+	assert.True(t, repo.teams["frontend"])
+
+	assert.Equal(t, "backend", repo.users["alice"].TeamName)
+	assert.False(t, repo.users["bob"].IsActive)
+	assert.Equal(t, "frontend", repo.users["carol"].TeamName)
+	// dave пропал из группы backend, erin в команде без группы и не затрагивается
+	assert.False(t, repo.users["dave"].IsActive)
+	assert.True(t, repo.users["erin"].IsActive)
+
+	// Ревью dave переходит к alice, единственному активному участнику backend после синхронизации
+	require.Len(t, repo.applied.Reassignments, 1)
+	assert.Equal(t, map[string]string{"dave": "alice"}, repo.applied.Reassignments[0].Replaced)
+
+	result, err = syncer.Sync(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Result{}, result)
+}
+
+func TestTimeLimitRoundsUp(t *testing.T) {
+	cfg := newConfig("ldap://localhost")
+	for timeout, want := range map[time.Duration]int{500 * time.Millisecond: 1, time.Second: 1, 1500 * time.Millisecond: 2} {
+		cfg.Timeout = timeout
+		assert.Equal(t, want, New(cfg, nil, discardLogger()).timeLimit(), timeout.String())
+	}
+}
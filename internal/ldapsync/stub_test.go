@@ -0,0 +1,169 @@
+package ldapsync
+
+import (
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+)
+
+const (
+	appBindRequest       = 0
+	appBindResponse      = 1
+	appUnbindRequest     = 2
+	appSearchRequest     = 3
+	appSearchResultEntry = 4
+	appSearchResultDone  = 5
+
+	resultSuccess            = 0
+	resultNoSuchObject       = 32
+	resultInvalidCredentials = 49
+
+	scopeBaseObject = 0
+)
+
+// stubDirectory - минимальный LDAP-сервер для тестов. Поиск с областью base
+// возвращает запись по DN, поиск по поддереву - все записи groupOfNames под базой;
+// фильтр запроса не разбирается.
+type stubDirectory struct {
+	bindDN   string
+	password string
+	entries  map[string]map[string][]string
+
+	listener net.Listener
+	wg       sync.WaitGroup
+}
+
+func startStubDirectory(t *testing.T, dir *stubDirectory) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	dir.listener = listener
+
+	dir.wg.Add(1)
+	go func() {
+		defer dir.wg.Done()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			dir.wg.Add(1)
+			go func() {
+				defer dir.wg.Done()
+				dir.serve(conn)
+			}()
+		}
+	}()
+
+	t.Cleanup(func() {
+		listener.Close()
+		dir.wg.Wait()
+	})
+
+	return "ldap://" + listener.Addr().String()
+}
+
+func (d *stubDirectory) serve(conn net.Conn) {
+	defer conn.Close()
+
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+		if len(packet.Children) < 2 {
+			return
+		}
+
+		messageID := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+
+		switch op.Tag {
+		case appBindRequest:
+			name := op.Children[1].Value.(string)
+			password := string(op.Children[2].Data.Bytes())
+			code := resultSuccess
+			if name != d.bindDN || password != d.password {
+				code = resultInvalidCredentials
+			}
+			d.write(conn, messageID, resultPacket(appBindResponse, code))
+		case appSearchRequest:
+			d.search(conn, messageID, op)
+		case appUnbindRequest:
+			return
+		default:
+			return
+		}
+	}
+}
+
+func (d *stubDirectory) search(conn net.Conn, messageID int64, op *ber.Packet) {
+	baseDN := op.Children[0].Value.(string)
+	scope := op.Children[1].Value.(int64)
+
+	if scope == scopeBaseObject {
+		attrs, ok := d.entries[baseDN]
+		if !ok {
+			d.write(conn, messageID, resultPacket(appSearchResultDone, resultNoSuchObject))
+			return
+		}
+		d.write(conn, messageID, entryPacket(baseDN, attrs))
+		d.write(conn, messageID, resultPacket(appSearchResultDone, resultSuccess))
+		return
+	}
+
+	dns := make([]string, 0, len(d.entries))
+	for dn, attrs := range d.entries {
+		if strings.HasSuffix(dn, baseDN) && slices.Contains(attrs["objectClass"], "groupOfNames") {
+			dns = append(dns, dn)
+		}
+	}
+	slices.Sort(dns)
+
+	for _, dn := range dns {
+		d.write(conn, messageID, entryPacket(dn, d.entries[dn]))
+	}
+	d.write(conn, messageID, resultPacket(appSearchResultDone, resultSuccess))
+}
+
+func (d *stubDirectory) write(conn net.Conn, messageID int64, op *ber.Packet) {
+	envelope := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "MessageID"))
+	envelope.AppendChild(op)
+	_, _ = conn.Write(envelope.Bytes())
+}
+
+func resultPacket(tag ber.Tag, code int) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "resultCode"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "matchedDN"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "diagnosticMessage"))
+	return op
+}
+
+func entryPacket(dn string, attrs map[string][]string) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, appSearchResultEntry, nil, "Search Result Entry")
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, "objectName"))
+
+	list := ber.NewSequence("attributes")
+	for name, values := range attrs {
+		attr := ber.NewSequence("attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "vals")
+		for _, value := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "value"))
+		}
+		attr.AppendChild(set)
+		list.AppendChild(attr)
+	}
+	op.AppendChild(list)
+
+	return op
+}
@@ -0,0 +1,147 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pr_manager"
+
+// DomainSource - источник доменных показателей, которые периодически выгружаются в gauges
+type DomainSource interface {
+	GetOpenPRCounts(ctx context.Context) (*models.OpenPRCounts, error)
+	GetActiveUsersByTeam(ctx context.Context) (map[string]int, error)
+}
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requestsTotal   *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	grpcServer      *grpcprom.ServerMetrics
+
+	openPRs             prometheus.Gauge
+	prsWithoutReviewers prometheus.Gauge
+	activeUsers         *prometheus.GaugeVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Total number of HTTP requests by method, route and status.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		grpcServer: grpcprom.NewServerMetrics(
+			grpcprom.WithServerCounterOptions(grpcprom.WithNamespace(namespace)),
+			grpcprom.WithServerHandlingTimeHistogram(grpcprom.WithHistogramNamespace(namespace)),
+		),
+		openPRs: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "open_pull_requests",
+			Help:      "Number of open pull requests.",
+		}),
+		prsWithoutReviewers: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "open_pull_requests_without_reviewers",
+			Help:      "Number of open pull requests with no assigned reviewers.",
+		}),
+		activeUsers: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "active_users",
+			Help:      "Number of active users per team.",
+		}, []string{"team"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requestsTotal,
+		m.requestDuration,
+		m.grpcServer,
+		m.openPRs,
+		m.prsWithoutReviewers,
+		m.activeUsers,
+	)
+
+	return m
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) Register(collector prometheus.Collector) error {
+	return m.registry.Register(collector)
+}
+
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	m.requestsTotal.WithLabelValues(method, route, code).Inc()
+	m.requestDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+// GRPCServer - счетчики и время обработки вызовов gRPC, их интерцепторы подключает grpcserver.WithMetrics
+func (m *Metrics) GRPCServer() *grpcprom.ServerMetrics {
+	return m.grpcServer
+}
+
+// RunRefresher обновляет доменные gauges с заданным интервалом до отмены контекста
+func (m *Metrics) RunRefresher(ctx context.Context, source DomainSource, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.refresh(ctx, source); err != nil {
+			logger.Warn("failed to refresh domain metrics", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Metrics) refresh(ctx context.Context, source DomainSource) error {
+	counts, err := source.GetOpenPRCounts(ctx)
+	if err != nil {
+		return err
+	}
+
+	activeByTeam, err := source.GetActiveUsersByTeam(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.openPRs.Set(float64(counts.Open))
+	m.prsWithoutReviewers.Set(float64(counts.WithoutReviewers))
+
+	// Сбрасываем, чтобы удаленные команды не оставались в выдаче
+	m.activeUsers.Reset()
+	for team, count := range activeByTeam {
+		m.activeUsers.WithLabelValues(team).Set(float64(count))
+	}
+
+	return nil
+}
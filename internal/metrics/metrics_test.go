@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vnchk1/pr-manager/internal/models"
+)
+
+type fakeSource struct {
+	activeByTeam map[string]int
+}
+
+func (f *fakeSource) GetOpenPRCounts(_ context.Context) (*models.OpenPRCounts, error) {
+	return &models.OpenPRCounts{Open: 7, WithoutReviewers: 2}, nil
+}
+
+func (f *fakeSource) GetActiveUsersByTeam(_ context.Context) (map[string]int, error) {
+	return f.activeByTeam, nil
+}
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	return rec.Body.String()
+}
+
+func TestMetrics_Refresh(t *testing.T) {
+	m := New()
+	source := &fakeSource{activeByTeam: map[string]int{"backend": 3, "frontend": 1}}
+
+	require.NoError(t, m.refresh(context.Background(), source))
+
+	body := scrape(t, m)
+	assert.Contains(t, body, "pr_manager_open_pull_requests 7")
+	assert.Contains(t, body, "pr_manager_open_pull_requests_without_reviewers 2")
+	assert.Contains(t, body, `pr_manager_active_users{team="backend"} 3`)
+
+	// Удаленная команда пропадает из выдачи после следующего обновления
+	source.activeByTeam = map[string]int{"backend": 2}
+	require.NoError(t, m.refresh(context.Background(), source))
+
+	body = scrape(t, m)
+	assert.Contains(t, body, `pr_manager_active_users{team="backend"} 2`)
+	assert.False(t, strings.Contains(body, `team="frontend"`))
+}
+
+func TestMetrics_ObserveRequest(t *testing.T) {
+	m := New()
+	m.ObserveRequest(http.MethodPost, "/pullRequest/create", http.StatusCreated, 25*time.Millisecond)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `pr_manager_http_requests_total{method="POST",route="/pullRequest/create",status="201"} 1`)
+	assert.Contains(t, body, `pr_manager_http_request_duration_seconds_count{method="POST",route="/pullRequest/create",status="201"} 1`)
+}
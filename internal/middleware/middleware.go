@@ -1,12 +1,76 @@
 package middleware
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
 	"log/slog"
+	"net/http"
+	"strings"
 	"time"
 
+	"github.com/vnchk1/pr-manager/internal/i18n"
+	logpkg "github.com/vnchk1/pr-manager/internal/logger"
+
 	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const maxRequestIDLength = 128
+
+const (
+	headerAcceptLanguage  = "Accept-Language"
+	headerContentLanguage = "Content-Language"
 )
 
+// RequestIDMiddleware принимает X-Request-ID от клиента или генерирует новый,
+// возвращает его в ответе и кладет в контекст вместе с логгером запроса
+func RequestIDMiddleware(logger *slog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error { //nolint:varnamelen
+			req := c.Request()
+
+			requestID := req.Header.Get(echo.HeaderXRequestID)
+			if !validRequestID(requestID) {
+				requestID = newRequestID()
+			}
+			c.Response().Header().Set(echo.HeaderXRequestID, requestID)
+
+			reqLogger := logger.With("request_id", requestID)
+			if spanCtx := trace.SpanContextFromContext(req.Context()); spanCtx.HasTraceID() {
+				reqLogger = reqLogger.With("trace_id", spanCtx.TraceID().String())
+			}
+
+			ctx := logpkg.WithContext(req.Context(), reqLogger)
+			ctx = logpkg.WithRequestID(ctx, requestID)
+			c.SetRequest(req.WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range requestID {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
 func LoggingMiddleware(logger *slog.Logger) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error { //nolint:varnamelen
@@ -15,15 +79,87 @@ func LoggingMiddleware(logger *slog.Logger) echo.MiddlewareFunc {
 
 			defer func() {
 				latency := time.Since(start)
-				logger.Info("completed request",
+				attrs := []any{
 					"method", c.Request().Method,
 					"path", c.Request().URL.Path,
 					"status", c.Response().Status,
 					"latency_ms", latency.Milliseconds(),
-					"ip", c.RealIP())
+					"ip", c.RealIP(),
+				}
+				if err != nil {
+					attrs = append(attrs, "error", err.Error())
+				}
+
+				logpkg.FromContextOr(c.Request().Context(), logger).Info("completed request", attrs...)
 			}()
 
 			return err
 		}
 	}
 }
+
+// RequestObserver принимает результат каждого запроса (реализуется metrics.Metrics)
+type RequestObserver interface {
+	ObserveRequest(method, route string, status int, duration time.Duration)
+}
+
+func MetricsMiddleware(observer RequestObserver) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error { //nolint:varnamelen
+			start := time.Now()
+			err := next(c)
+
+			status := c.Response().Status
+			var httpErr *echo.HTTPError
+			if err != nil && !c.Response().Committed {
+				status = http.StatusInternalServerError
+				if errors.As(err, &httpErr) {
+					status = httpErr.Code
+				}
+			}
+
+			// Шаблон маршрута вместо фактического пути, чтобы не раздувать кардинальность
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+
+			observer.ObserveRequest(c.Request().Method, route, status, time.Since(start))
+
+			return err
+		}
+	}
+}
+
+// BearerAuthMiddleware пропускает только запросы с заголовком Authorization: Bearer <token>.
+// Пустой token не пропускает ни одного запроса.
+func BearerAuthMiddleware(token string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			got, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+			if !ok || token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				return echo.NewHTTPError(http.StatusUnauthorized, string(i18n.HTTPUnauthorized))
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// LanguageMiddleware выбирает язык сообщений по Accept-Language и кладет его в
+// контекст запроса; без заголовка используется defaultLang
+func LanguageMiddleware(defaultLang i18n.Lang) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error { //nolint:varnamelen
+			req := c.Request()
+			lang := i18n.Negotiate(req.Header.Get(headerAcceptLanguage), defaultLang)
+
+			header := c.Response().Header()
+			header.Set(headerContentLanguage, string(lang))
+			header.Add(echo.HeaderVary, headerAcceptLanguage)
+
+			c.SetRequest(req.WithContext(i18n.WithLang(req.Context(), lang)))
+			return next(c)
+		}
+	}
+}
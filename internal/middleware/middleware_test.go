@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vnchk1/pr-manager/internal/i18n"
+	logpkg "github.com/vnchk1/pr-manager/internal/logger"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{name: "Accepts client id", incoming: "ci-run-42", keep: true},
+		{name: "Generates when missing", incoming: "", keep: false},
+		{name: "Replaces id with spaces", incoming: "bad id", keep: false},
+		{name: "Replaces too long id", incoming: strings.Repeat("a", maxRequestIDLength+1), keep: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+			e := echo.New()
+			e.Use(RequestIDMiddleware(logger))
+			e.GET("/", func(c echo.Context) error {
+				ctx := c.Request().Context()
+				logpkg.FromContext(ctx).Info("inside handler")
+				return c.String(http.StatusOK, logpkg.RequestIDFromContext(ctx))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(echo.HeaderXRequestID, tt.incoming)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			requestID := rec.Header().Get(echo.HeaderXRequestID)
+			require.NotEmpty(t, requestID)
+			assert.Equal(t, requestID, rec.Body.String())
+			assert.Contains(t, buf.String(), `"request_id":"`+requestID+`"`)
+
+			if tt.keep {
+				assert.Equal(t, tt.incoming, requestID)
+			} else {
+				assert.NotEqual(t, tt.incoming, requestID)
+				assert.Len(t, requestID, 32)
+			}
+		})
+	}
+}
+
+func TestLanguageMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(LanguageMiddleware(i18n.Russian))
+	e.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, string(i18n.FromContext(c.Request().Context())))
+	})
+
+	for header, want := range map[string]string{"": "ru", "en-US,en;q=0.9": "en", "de": "ru"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if header != "" {
+			req.Header.Set(headerAcceptLanguage, header)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, want, rec.Body.String(), header)
+		assert.Equal(t, want, rec.Header().Get(headerContentLanguage))
+		assert.Equal(t, headerAcceptLanguage, rec.Header().Get(echo.HeaderVary))
+	}
+}
@@ -8,53 +8,103 @@ import (
 	"time"
 
 	"github.com/vnchk1/pr-manager/internal/config"
+	"github.com/vnchk1/pr-manager/migrations"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/pressly/goose/v3"
 )
 
-func RunMigrations(cfg config.DatabaseConfig) error {
-	dsn := fmt.Sprintf(
-		"host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
-		cfg.Host,
-		cfg.Port,
-		cfg.User,
-		cfg.Password,
-		cfg.DBName,
-	)
+const connectAttempts = 10
 
-	db, err := sql.Open("pgx", dsn)
+// Open подключается к БД для миграций, дожидаясь ее готовности
+func Open(cfg config.DatabaseConfig) (*sql.DB, error) {
+	db, err := sql.Open("pgx", cfg.DSN())
 	if err != nil {
-		return fmt.Errorf("failed to open database: %w", err)
+		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
-	defer db.Close()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	for i := 0; i < 10; i++ {
+	for i := 0; i < connectAttempts; i++ {
 		err = db.PingContext(ctx)
 		if err == nil {
 			break
 		}
-		log.Printf("Waiting for database... attempt %d/10", i+1)
+		log.Printf("Waiting for database... attempt %d/%d", i+1, connectAttempts)
 		time.Sleep(3 * time.Second)
 	}
 
 	if err != nil {
-		return fmt.Errorf("failed to connect to database after retries: %w", err)
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to database after retries: %w", err)
 	}
 
-	if err = goose.SetDialect("postgres"); err != nil {
-		return fmt.Errorf("failed to set dialect: %w", err)
+	return db, nil
+}
+
+// NewProvider создает goose-провайдер поверх встроенных миграций.
+// Закрытие провайдера закрывает и переданный *sql.DB.
+func NewProvider(db *sql.DB) (*goose.Provider, error) {
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, migrations.FS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration provider: %w", err)
 	}
 
-	migrationsDir := "/root/migrations"
+	return provider, nil
+}
 
-	if err = goose.Up(db, migrationsDir); err != nil {
+func RunMigrations(cfg config.DatabaseConfig) error {
+	db, err := Open(cfg)
+	if err != nil {
+		return err
+	}
+
+	provider, err := NewProvider(db)
+	if err != nil {
+		db.Close()
+		return err
+	}
+	defer provider.Close()
+
+	results, err := provider.Up(context.Background())
+	if err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
-	log.Println("Migrations applied successfully")
+	log.Printf("Migrations applied successfully: %d new", len(results))
+	return nil
+}
+
+// VersionChecker сверяет версию схемы в БД с последней встроенной миграцией.
+// Провайдер создается один раз и переиспользуется всеми проверками готовности.
+type VersionChecker struct {
+	provider *goose.Provider
+}
+
+func NewVersionChecker(pool *pgxpool.Pool) (*VersionChecker, error) {
+	provider, err := NewProvider(stdlib.OpenDBFromPool(pool))
+	if err != nil {
+		return nil, err
+	}
+
+	return &VersionChecker{provider: provider}, nil
+}
+
+func (c *VersionChecker) Check(ctx context.Context) error {
+	current, expected, err := c.provider.GetVersions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database version: %w", err)
+	}
+
+	if current != expected {
+		return fmt.Errorf("database schema version %d does not match expected %d", current, expected)
+	}
+
 	return nil
 }
+
+func (c *VersionChecker) Close() error {
+	return c.provider.Close()
+}
@@ -9,8 +9,10 @@ var (
 	ErrInvalidUsername = errors.New("invalid username")
 	ErrInvalidTeamName = errors.New("invalid team name")
 	ErrUserNotActive   = errors.New("user is not active")
+	ErrUserExists      = errors.New("user already exists")
 
-	ErrTeamExists = errors.New("team already exists")
+	ErrTeamExists   = errors.New("team already exists")
+	ErrTeamNotEmpty = errors.New("team still has members")
 
 	ErrInvalidPRID      = errors.New("invalid pull request id")
 	ErrInvalidPRName    = errors.New("invalid pull request name")
@@ -20,4 +22,7 @@ var (
 	ErrNotAssigned      = errors.New("reviewer is not assigned to this PR")
 	ErrNoCandidate      = errors.New("no active replacement candidate in team")
 	ErrTooManyReviewers = errors.New("too many reviewers assigned")
+	ErrVersionConflict  = errors.New("pull request was modified concurrently")
+
+	ErrInvalidReviewState = errors.New("review state must be APPROVED or CHANGES_REQUESTED")
 )
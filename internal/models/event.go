@@ -0,0 +1,75 @@
+package models
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventPRCreated    EventType = "pr.created"
+	EventPRMerged     EventType = "pr.merged"
+	EventPRReassigned EventType = "pr.reassigned"
+
+	EventUserActivated   EventType = "user.activated"
+	EventUserDeactivated EventType = "user.deactivated"
+)
+
+// Event - запись журнала событий. TeamName и UserIDs нужны для фильтрации подписок:
+// для PR это команда автора, автор и ревьюверы, включая снятого.
+type Event struct {
+	ID        int64           `json:"id"`
+	Type      EventType       `json:"type"`
+	TeamName  string          `json:"team_name,omitempty"`
+	UserIDs   []string        `json:"user_ids"`
+	Data      json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// PREventData - данные событий pr.*. Пустой NewReviewer при переназначении означает,
+// что ревьювер снят без замены.
+type PREventData struct {
+	PullRequest *PullRequest `json:"pull_request"`
+	OldReviewer string       `json:"old_reviewer,omitempty"`
+	NewReviewer string       `json:"new_reviewer,omitempty"`
+}
+
+type UserEventData struct {
+	User *User `json:"user"`
+}
+
+func NewPREvent(eventType EventType, teamName string, data PREventData) *Event {
+	userIDs := append([]string{data.PullRequest.AuthorID}, data.PullRequest.AssignedReviewers...)
+	if data.OldReviewer != "" && !slices.Contains(userIDs, data.OldReviewer) {
+		userIDs = append(userIDs, data.OldReviewer)
+	}
+
+	// Структуры без каналов и функций сериализуются всегда
+	payload, _ := json.Marshal(data)
+
+	return &Event{Type: eventType, TeamName: teamName, UserIDs: userIDs, Data: payload}
+}
+
+func NewUserEvent(eventType EventType, user *User) *Event {
+	payload, _ := json.Marshal(UserEventData{User: user})
+
+	return &Event{Type: eventType, TeamName: user.TeamName, UserIDs: []string{user.ID}, Data: payload}
+}
+
+// EventFilter - условия подписки; пустое поле не ограничивает выборку
+type EventFilter struct {
+	TeamName string
+	UserID   string
+}
+
+func (f EventFilter) Match(event *Event) bool {
+	if f.TeamName != "" && event.TeamName != f.TeamName {
+		return false
+	}
+	if f.UserID != "" && !slices.Contains(event.UserIDs, f.UserID) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// IdempotencyRecord - сохраненный ответ на запрос с Idempotency-Key
+type IdempotencyRecord struct {
+	Key string
+	// RequestHash - хеш метода, пути и тела запроса, занявшего ключ
+	RequestHash string
+	// StatusCode равен 0, пока запрос выполняется
+	StatusCode int
+	Header     map[string][]string
+	Body       []byte
+	ExpiresAt  time.Time
+}
+
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
@@ -0,0 +1,214 @@
+package models
+
+import (
+	"errors"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// NotificationKind - повод уведомления
+type NotificationKind string
+
+const (
+	NotifyAssigned   NotificationKind = "assigned"
+	NotifyReassigned NotificationKind = "reassigned"
+	NotifyOverdue    NotificationKind = "overdue"
+)
+
+type NotificationChannel string
+
+const (
+	ChannelChat    NotificationChannel = "chat"
+	ChannelEmail   NotificationChannel = "email"
+	ChannelWebhook NotificationChannel = "webhook"
+)
+
+// DeliveryMode - как пользователь получает уведомления: сразу или только ежедневной сводкой
+type DeliveryMode string
+
+const (
+	DeliveryImmediate DeliveryMode = "immediate"
+	DeliveryDigest    DeliveryMode = "digest"
+)
+
+var (
+	ErrInvalidChannel          = errors.New("channels must be chat, email or webhook")
+	ErrInvalidNotificationType = errors.New("event types must be assigned, reassigned or overdue")
+	ErrInvalidQuietHours       = errors.New("quiet hours must be in HH:MM format")
+	ErrInvalidTimezone         = errors.New("unknown timezone")
+	ErrInvalidDelivery         = errors.New("delivery must be immediate or digest")
+	ErrInvalidEmail            = errors.New("invalid email address")
+	ErrInvalidWebhookURL       = errors.New("webhook url must be an absolute http(s) url of a public host")
+)
+
+// QuietHours - интервал [Start, End) в часовом поясе пользователя, формат HH:MM.
+// Start позже End означает интервал через полночь.
+type QuietHours struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// NotificationPreferences - настройки уведомлений пользователя. Пустой Email означает
+// адрес по умолчанию; канал webhook без WebhookURL ничего не отправляет.
+type NotificationPreferences struct {
+	UserID     string                `json:"user_id"`
+	Channels   []NotificationChannel `json:"channels"`
+	EventTypes []NotificationKind    `json:"event_types"`
+	QuietHours *QuietHours           `json:"quiet_hours,omitempty"`
+	Timezone   string                `json:"timezone"`
+	Delivery   DeliveryMode          `json:"delivery"`
+	Email      string                `json:"email,omitempty"`
+	WebhookURL string                `json:"webhook_url,omitempty"`
+}
+
+// DefaultNotificationPreferences - настройки пользователя, который их не менял:
+// все каналы и поводы, без тихих часов
+func DefaultNotificationPreferences(userID string) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID:     userID,
+		Channels:   []NotificationChannel{ChannelChat, ChannelEmail, ChannelWebhook},
+		EventTypes: []NotificationKind{NotifyAssigned, NotifyReassigned, NotifyOverdue},
+		Timezone:   "UTC",
+		Delivery:   DeliveryImmediate,
+	}
+}
+
+func (p *NotificationPreferences) Validate() error {
+	if p.UserID == "" {
+		return ErrInvalidUserID
+	}
+	for _, channel := range p.Channels {
+		if channel != ChannelChat && channel != ChannelEmail && channel != ChannelWebhook {
+			return ErrInvalidChannel
+		}
+	}
+	for _, kind := range p.EventTypes {
+		if kind != NotifyAssigned && kind != NotifyReassigned && kind != NotifyOverdue {
+			return ErrInvalidNotificationType
+		}
+	}
+	if p.QuietHours != nil {
+		if _, err := time.Parse("15:04", p.QuietHours.Start); err != nil {
+			return ErrInvalidQuietHours
+		}
+		if _, err := time.Parse("15:04", p.QuietHours.End); err != nil {
+			return ErrInvalidQuietHours
+		}
+	}
+	if _, err := time.LoadLocation(p.Timezone); err != nil || p.Timezone == "" {
+		return ErrInvalidTimezone
+	}
+	if p.Delivery != DeliveryImmediate && p.Delivery != DeliveryDigest {
+		return ErrInvalidDelivery
+	}
+	if p.Email != "" {
+		if address, err := mail.ParseAddress(p.Email); err != nil || address.Address != p.Email {
+			return ErrInvalidEmail
+		}
+	}
+	if p.WebhookURL != "" {
+		if u, err := url.Parse(p.WebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || !webhookHostAllowed(u.Hostname()) {
+			return ErrInvalidWebhookURL
+		}
+	}
+	return nil
+}
+
+// webhookHostAllowed отклоняет localhost и внутренние IP-адреса. Имена, которые разрешаются
+// во внутренние адреса, отклоняются при подключении (см. WebhookAddrAllowed).
+func webhookHostAllowed(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "" || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return WebhookAddrAllowed(addr)
+	}
+	return true
+}
+
+// nonPublicPrefixes - сети, не маршрутизируемые в интернете: специальные диапазоны IANA,
+// частные сети, CGNAT, loopback, link-local, документация, мультикаст и шлюзы между IPv4 и IPv6
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("127.0.0.0/8"),
+	netip.MustParsePrefix("169.254.0.0/16"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("192.88.99.0/24"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("224.0.0.0/4"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("255.255.255.255/32"),
+
+	netip.MustParsePrefix("::/128"),
+	netip.MustParsePrefix("::1/128"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("100::/64"),
+	netip.MustParsePrefix("2001:db8::/32"),
+	netip.MustParsePrefix("2002::/16"),
+	netip.MustParsePrefix("fc00::/7"),
+	netip.MustParsePrefix("fe80::/10"),
+	netip.MustParsePrefix("ff00::/8"),
+}
+
+// WebhookAddrAllowed сообщает, можно ли отправлять личный вебхук на адрес addr. Адреса из
+// nonPublicPrefixes запрещены: иначе через свои настройки пользователь мог бы отправлять
+// запросы к сервисам внутри сети.
+func WebhookAddrAllowed(addr netip.Addr) bool {
+	// Префиксы не содержат адресов с зоной, поэтому зона отбрасывается
+	addr = addr.Unmap().WithZone("")
+	if !addr.IsValid() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *NotificationPreferences) HasChannel(channel NotificationChannel) bool {
+	return slices.Contains(p.Channels, channel)
+}
+
+// Allows сообщает, можно ли сейчас отправить пользователю уведомление kind в канал channel
+func (p *NotificationPreferences) Allows(channel NotificationChannel, kind NotificationKind, now time.Time) bool {
+	return p.Delivery == DeliveryImmediate &&
+		p.HasChannel(channel) &&
+		slices.Contains(p.EventTypes, kind) &&
+		!p.InQuietHours(now)
+}
+
+func (p *NotificationPreferences) InQuietHours(now time.Time) bool {
+	if p.QuietHours == nil {
+		return false
+	}
+	start, errStart := time.Parse("15:04", p.QuietHours.Start)
+	end, errEnd := time.Parse("15:04", p.QuietHours.End)
+	loc, errLoc := time.LoadLocation(p.Timezone)
+	if errStart != nil || errEnd != nil || errLoc != nil {
+		return false
+	}
+
+	local := now.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+
+	if from <= to {
+		return minute >= from && minute < to
+	}
+	return minute >= from || minute < to
+}
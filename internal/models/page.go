@@ -0,0 +1,128 @@
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+	ErrInvalidSort   = errors.New("invalid sort option")
+)
+
+type SortOrder string
+
+const (
+	SortDesc SortOrder = "desc"
+	SortAsc  SortOrder = "asc"
+)
+
+// Cursor - позиция в выдаче: значение ключа сортировки и id последней записи страницы.
+// Для списков PR ключом служит created_at.
+type Cursor struct {
+	Value string
+	ID    string
+}
+
+func (c *Cursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.Value + "|" + c.ID))
+}
+
+func DecodeCursor(raw string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	value, id, ok := strings.Cut(string(data), "|")
+	if !ok || id == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{Value: value, ID: id}, nil
+}
+
+// TimeCursor строит курсор по created_at и id записи
+func TimeCursor(createdAt time.Time, id string) *Cursor {
+	return &Cursor{Value: createdAt.UTC().Format(time.RFC3339Nano), ID: id}
+}
+
+// Time разбирает значение курсора, построенного TimeCursor
+func (c *Cursor) Time() (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, c.Value)
+	if err != nil {
+		return time.Time{}, ErrInvalidCursor
+	}
+	return t, nil
+}
+
+type PageRequest struct {
+	Limit  int
+	Cursor *Cursor
+	Order  SortOrder
+}
+
+// Normalize подставляет значения по умолчанию и ограничивает размер страницы
+func (p *PageRequest) Normalize() {
+	if p.Limit <= 0 {
+		p.Limit = DefaultPageLimit
+	}
+	if p.Limit > MaxPageLimit {
+		p.Limit = MaxPageLimit
+	}
+	if p.Order != SortAsc {
+		p.Order = SortDesc
+	}
+}
+
+// PRFilter - условия выборки PR, пустые поля не учитываются. TeamName - команда автора.
+type PRFilter struct {
+	AuthorID    string
+	ReviewerID  string
+	TeamName    string
+	Status      PullRequestStatus
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+}
+
+// UserFilter - условия выборки пользователей, пустые поля не учитываются.
+// Username сравнивается без учета регистра.
+type UserFilter struct {
+	ID       string
+	Username string
+	TeamName string
+	IsActive *bool
+}
+
+// OffsetPage - страница по номеру первой записи, для протоколов без курсоров вроде SCIM
+type OffsetPage struct {
+	Offset int
+	Limit  int
+}
+
+type PRPage struct {
+	PullRequests []*PullRequest
+	NextCursor   string
+}
+
+// ReviewPage - страница очереди ревьювера. Counts считаются по всем его PR, а не по странице
+type ReviewPage struct {
+	PullRequests []*ReviewPR
+	NextCursor   string
+	Counts       ReviewCounts
+}
+
+// PRSearch - полнотекстовый поиск по названию PR с дополнительными условиями.
+// Из Filter учитываются TeamName, ReviewerID и Status.
+type PRSearch struct {
+	Query  string
+	Filter PRFilter
+	Limit  int
+}
@@ -17,6 +17,8 @@ type PullRequest struct {
 	AuthorID          string            `json:"author_id"`
 	Status            PullRequestStatus `json:"status"`
 	AssignedReviewers []string          `json:"assigned_reviewers"` // user_ids
+	// Version увеличивается при каждом изменении PR, по ней строится ETag
+	Version int `json:"version"`
 
 	CreatedAt time.Time  `json:"created_at,omitempty"`
 	MergedAt  *time.Time `json:"merged_at,omitempty"`
@@ -54,9 +56,27 @@ type PRCreateRequest struct {
 
 type PRMergeRequest struct {
 	ID string `json:"pull_request_id"`
+	// Version - ожидаемая версия PR из If-Match, 0 - без проверки
+	Version int `json:"-"`
 }
 
 type PRReassignRequest struct {
 	ID          string `json:"pull_request_id"`
 	OldReviewer string `json:"old_user_id"`
+	// Version - ожидаемая версия PR из If-Match, 0 - без проверки
+	Version int `json:"-"`
+}
+
+type PRReviewer struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	IsActive bool   `json:"is_active"`
+}
+
+// PullRequestDetails - PR с именами автора и ревьюверов. Ревьюверы, которых нет
+// в базе, возвращаются только с user_id.
+type PullRequestDetails struct {
+	PullRequest
+	AuthorUsername string        `json:"author_username"`
+	Reviewers      []*PRReviewer `json:"reviewers"`
 }
@@ -0,0 +1,103 @@
+package models
+
+import "time"
+
+type ReviewState string
+
+const (
+	// ReviewPending - ревьювер назначен, но еще не оставил ревью
+	ReviewPending          ReviewState = "PENDING"
+	ReviewApproved         ReviewState = "APPROVED"
+	ReviewChangesRequested ReviewState = "CHANGES_REQUESTED"
+)
+
+type PRReviewRequest struct {
+	ID         string      `json:"pull_request_id"`
+	ReviewerID string      `json:"user_id"`
+	State      ReviewState `json:"state"`
+}
+
+func (r *PRReviewRequest) Validate() error {
+	if r.ID == "" {
+		return ErrInvalidPRID
+	}
+	if r.ReviewerID == "" {
+		return ErrInvalidUserID
+	}
+	if r.State != ReviewApproved && r.State != ReviewChangesRequested {
+		return ErrInvalidReviewState
+	}
+	return nil
+}
+
+type PRReview struct {
+	PullRequestID string      `json:"pull_request_id"`
+	ReviewerID    string      `json:"user_id"`
+	State         ReviewState `json:"state"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// ReviewPR - PR, назначенный пользователю, с его собственным состоянием ревью
+type ReviewPR struct {
+	PullRequestShort
+	ReviewState ReviewState `json:"review_state"`
+}
+
+type ReviewCounts struct {
+	NeedsReview      int `json:"needs_review"`
+	Approved         int `json:"approved"`
+	ChangesRequested int `json:"changes_requested"`
+	Total            int `json:"total"`
+}
+
+// ReviewQueue - PR ревьювера, сгруппированные по его состоянию ревью. Группы относятся
+// к одной странице выдачи, счетчики - ко всем PR ревьювера.
+type ReviewQueue struct {
+	NeedsReview      []*ReviewPR  `json:"needs_review"`
+	Approved         []*ReviewPR  `json:"approved"`
+	ChangesRequested []*ReviewPR  `json:"changes_requested"`
+	Counts           ReviewCounts `json:"counts"`
+	NextCursor       string       `json:"next_cursor,omitempty"`
+}
+
+// NewReviewQueue раскладывает PR по группам с сохранением порядка и считает их.
+// Смерженные PR без ревью пропускаются: ревьюить их уже не нужно. Для постраничной
+// выдачи счетчики заменяются посчитанными по всем PR.
+func NewReviewQueue(prs []*ReviewPR) *ReviewQueue {
+	queue := &ReviewQueue{
+		NeedsReview:      []*ReviewPR{},
+		Approved:         []*ReviewPR{},
+		ChangesRequested: []*ReviewPR{},
+	}
+
+	for _, pr := range prs {
+		switch pr.ReviewState {
+		case ReviewApproved:
+			queue.Approved = append(queue.Approved, pr)
+		case ReviewChangesRequested:
+			queue.ChangesRequested = append(queue.ChangesRequested, pr)
+		default:
+			if pr.Status == StatusMerged {
+				continue
+			}
+			queue.NeedsReview = append(queue.NeedsReview, pr)
+		}
+	}
+
+	queue.Counts = ReviewCounts{
+		NeedsReview:      len(queue.NeedsReview),
+		Approved:         len(queue.Approved),
+		ChangesRequested: len(queue.ChangesRequested),
+	}
+	queue.Counts.Total = queue.Counts.NeedsReview + queue.Counts.Approved + queue.Counts.ChangesRequested
+
+	return queue
+}
+
+// All возвращает PR всех групп: сначала ожидающие ревью, затем одобренные и с запрошенными изменениями
+func (q *ReviewQueue) All() []*ReviewPR {
+	all := make([]*ReviewPR, 0, len(q.NeedsReview)+len(q.Approved)+len(q.ChangesRequested))
+	all = append(all, q.NeedsReview...)
+	all = append(all, q.Approved...)
+	return append(all, q.ChangesRequested...)
+}
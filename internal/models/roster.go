@@ -0,0 +1,128 @@
+package models
+
+import "errors"
+
+var (
+	ErrInvalidRosterFormat = errors.New("unsupported roster format")
+	ErrDuplicateRosterUser = errors.New("user listed more than once in roster")
+)
+
+// Roster - состав всех команд, используется для импорта и экспорта
+type Roster struct {
+	Teams []*RosterTeam `json:"teams" yaml:"teams"`
+}
+
+type RosterTeam struct {
+	Name    string          `json:"team_name" yaml:"team_name"`
+	Members []*RosterMember `json:"members" yaml:"members"`
+}
+
+type RosterMember struct {
+	UserID   string `json:"user_id" yaml:"user_id"`
+	Username string `json:"username" yaml:"username"`
+	IsActive bool   `json:"is_active" yaml:"is_active"`
+}
+
+func (r *Roster) Validate() error {
+	seenTeams := make(map[string]bool)
+	seenUsers := make(map[string]bool)
+
+	for _, team := range r.Teams {
+		if team.Name == "" || seenTeams[team.Name] {
+			return ErrInvalidTeamName
+		}
+		seenTeams[team.Name] = true
+
+		for _, member := range team.Members {
+			if member.UserID == "" {
+				return ErrInvalidUserID
+			}
+			if member.Username == "" {
+				return ErrInvalidUsername
+			}
+			if seenUsers[member.UserID] {
+				return ErrDuplicateRosterUser
+			}
+			seenUsers[member.UserID] = true
+		}
+	}
+
+	return nil
+}
+
+// RosterUserState - состояние пользователя до или после применения изменений
+type RosterUserState struct {
+	Username string `json:"username"`
+	TeamName string `json:"team_name"`
+	IsActive bool   `json:"is_active"`
+}
+
+type RosterUserChange struct {
+	UserID string           `json:"user_id"`
+	Before *RosterUserState `json:"before,omitempty"`
+	After  *RosterUserState `json:"after,omitempty"`
+}
+
+// RosterDiff - изменения, которые импорт вносит в текущее состояние
+type RosterDiff struct {
+	TeamsCreated   []string            `json:"teams_created"`
+	UsersCreated   []*RosterUserChange `json:"users_created"`
+	UsersUpdated   []*RosterUserChange `json:"users_updated"`
+	Reassignments  []*PRReviewerChange `json:"reassignments"`
+	UsersUnchanged int                 `json:"users_unchanged"`
+}
+
+func (d *RosterDiff) Empty() bool {
+	return len(d.TeamsCreated) == 0 && len(d.UsersCreated) == 0 && len(d.UsersUpdated) == 0 &&
+		len(d.Reassignments) == 0
+}
+
+// Plan возвращает изменения импорта в виде плана синхронизации
+func (d *RosterDiff) Plan() *ReconcilePlan {
+	return &ReconcilePlan{
+		TeamsCreated:  d.TeamsCreated,
+		UsersCreated:  d.UsersCreated,
+		UsersUpdated:  d.UsersUpdated,
+		Reassignments: d.Reassignments,
+	}
+}
+
+// PRReviewerChange - замена ревьюверов открытого PR, затронутых синхронизацией.
+// Пустое значение в Replaced означает, что замены не нашлось и ревьювер снят.
+// Version - версия PR, по которой составлена замена.
+type PRReviewerChange struct {
+	PullRequestID     string            `json:"pull_request_id"`
+	Version           int               `json:"-"`
+	Replaced          map[string]string `json:"replaced"`
+	AssignedReviewers []string          `json:"assigned_reviewers"`
+}
+
+// ReconcilePlan - изменения, приводящие текущее состояние к желаемому составу
+type ReconcilePlan struct {
+	TeamsCreated     []string            `json:"teams_created"`
+	TeamsDeleted     []string            `json:"teams_deleted"`
+	UsersCreated     []*RosterUserChange `json:"users_created"`
+	UsersUpdated     []*RosterUserChange `json:"users_updated"`
+	UsersMoved       []*RosterUserChange `json:"users_moved"`
+	UsersDeactivated []*RosterUserChange `json:"users_deactivated"`
+	Reassignments    []*PRReviewerChange `json:"reassignments"`
+	UsersUnchanged   int                 `json:"users_unchanged"`
+}
+
+func (p *ReconcilePlan) Empty() bool {
+	return len(p.TeamsCreated) == 0 && len(p.TeamsDeleted) == 0 &&
+		len(p.UsersCreated) == 0 && len(p.UsersUpdated) == 0 &&
+		len(p.UsersMoved) == 0 && len(p.UsersDeactivated) == 0 &&
+		len(p.Reassignments) == 0
+}
+
+// UserChanges возвращает все изменения пользователей плана
+func (p *ReconcilePlan) UserChanges() []*RosterUserChange {
+	changes := make([]*RosterUserChange, 0,
+		len(p.UsersCreated)+len(p.UsersUpdated)+len(p.UsersMoved)+len(p.UsersDeactivated))
+	changes = append(changes, p.UsersCreated...)
+	changes = append(changes, p.UsersUpdated...)
+	changes = append(changes, p.UsersMoved...)
+	changes = append(changes, p.UsersDeactivated...)
+	return changes
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type UserAssignmentStats struct {
 	UserID          string `json:"user_id"`
 	Username        string `json:"username"`
@@ -19,9 +21,28 @@ type PRAssignmentStats struct {
 }
 
 type AssignmentStatsResponse struct {
-	UserStats []*UserAssignmentStats `json:"user_stats"`
-	PRStats   *PRAssignmentStats     `json:"pr_stats"`
-	Summary   *StatsSummary          `json:"summary"`
+	UserStats  []*UserAssignmentStats `json:"user_stats"`
+	PRStats    *PRAssignmentStats     `json:"pr_stats"`
+	Summary    *StatsSummary          `json:"summary"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+}
+
+const (
+	// StatsSortAssignments - по убыванию числа назначений
+	StatsSortAssignments = "assignments"
+	// StatsSortUsername - по имени пользователя
+	StatsSortUsername = "username"
+)
+
+// AssignmentStatsFilter - условия выборки статистики по пользователям
+type AssignmentStatsFilter struct {
+	TeamName string
+	Sort     string
+}
+
+type AssignmentStatsPage struct {
+	UserStats  []*UserAssignmentStats
+	NextCursor string
 }
 
 type StatsSummary struct {
@@ -31,3 +52,46 @@ type StatsSummary struct {
 	MostAssignedUser string `json:"most_assigned_user,omitempty"`
 	MostAssignments  int    `json:"most_assignments,omitempty"`
 }
+
+type MemberAssignment struct {
+	UserID          string
+	Username        string
+	TeamName        string
+	AssignmentCount int
+	ActiveSince     time.Time
+}
+
+type MemberFairness struct {
+	UserID            string  `json:"user_id"`
+	Username          string  `json:"username"`
+	AssignmentCount   int     `json:"assignment_count"`
+	DaysActive        float64 `json:"days_active"`
+	AssignmentsPerDay float64 `json:"assignments_per_day"`
+}
+
+type TeamFairness struct {
+	TeamName         string            `json:"team_name"`
+	ActiveMembers    int               `json:"active_members"`
+	TotalAssignments int               `json:"total_assignments"`
+	MeanPerDay       float64           `json:"mean_assignments_per_day"`
+	StdDev           float64           `json:"std_dev"`
+	Gini             float64           `json:"gini"`
+	Imbalanced       bool              `json:"imbalanced"`
+	Overloaded       []*MemberFairness `json:"overloaded"`
+	Underloaded      []*MemberFairness `json:"underloaded"`
+	Members          []*MemberFairness `json:"members"`
+}
+
+// DefaultGiniThreshold - значение коэффициента Джини, выше которого распределение считается несправедливым
+const DefaultGiniThreshold = 0.3
+
+type FairnessReport struct {
+	GiniThreshold float64         `json:"gini_threshold"`
+	Imbalanced    bool            `json:"imbalanced"`
+	Teams         []*TeamFairness `json:"teams"`
+}
+
+type OpenPRCounts struct {
+	Open             int `json:"open"`
+	WithoutReviewers int `json:"without_reviewers"`
+}
@@ -0,0 +1,208 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+)
+
+type UserLister interface {
+	List(ctx context.Context) ([]*models.User, error)
+}
+
+type ReviewSource interface {
+	GetOpenPRsWithReviewer(ctx context.Context, reviewerID string) ([]*models.PullRequest, error)
+}
+
+// DigestMarks отмечает, за какой день пользователь уже получил сводку
+type DigestMarks interface {
+	ClaimDigest(ctx context.Context, userID string, day time.Time) (bool, error)
+	ReleaseDigest(ctx context.Context, userID string, day time.Time) error
+}
+
+// digestRetryInterval - через сколько повторить отправку тем, у кого были тихие часы
+const digestRetryInterval = 15 * time.Minute
+
+// Digest раз в день отправляет активным пользователям письмо со списком открытых PR,
+// ожидающих их ревью. Письмо получают пользователи с включенным каналом email
+// независимо от режима доставки; пользователям без таких PR оно не отправляется.
+// В тихие часы пользователя сводка откладывается до их окончания. Каждый пользователь
+// получает сводку раз в день, сколько бы реплик ее ни отправляли.
+type Digest struct {
+	email        *Email
+	overdueAfter time.Duration
+	users        UserLister
+	reviews      ReviewSource
+	prefs        PreferencesSource
+	marks        DigestMarks
+	logger       *slog.Logger
+	now          func() time.Time
+}
+
+func NewDigest(email *Email, overdueAfter time.Duration, users UserLister, reviews ReviewSource,
+	prefs PreferencesSource, marks DigestMarks, logger *slog.Logger) *Digest {
+	return &Digest{
+		email:        email,
+		overdueAfter: overdueAfter,
+		users:        users,
+		reviews:      reviews,
+		prefs:        prefs,
+		marks:        marks,
+		logger:       logger,
+		now:          time.Now,
+	}
+}
+
+// Run отправляет сводку ежедневно в DigestAt до отмены контекста. Пока есть отложенные
+// из-за тихих часов сводки, отправка повторяется раз в digestRetryInterval до следующего дня.
+func (d *Digest) Run(ctx context.Context) {
+	next := nextDigest(d.now(), d.email.cfg.DigestAt, d.email.cfg.DigestLocation)
+	for {
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		sent, postponed, err := d.Send(ctx, d.now())
+		if err != nil {
+			d.logger.Error("failed to send review digest", "emails", sent, "postponed", postponed, "error", err)
+		} else {
+			d.logger.Info("review digest sent", "emails", sent, "postponed", postponed)
+		}
+
+		now := d.now()
+		next = nextDigest(now, d.email.cfg.DigestAt, d.email.cfg.DigestLocation)
+		if retry := now.Add(digestRetryInterval); postponed > 0 && retry.Before(next) {
+			next = retry
+		}
+	}
+}
+
+// Send отправляет сводку за день, к которому относится now, тем, кто ее еще не получил.
+// Возвращает число отправленных писем и число сводок, отложенных из-за тихих часов.
+func (d *Digest) Send(ctx context.Context, now time.Time) (int, int, error) {
+	users, err := d.users.List(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	byID := make(map[string]*models.User, len(users))
+	var active []string
+	for _, user := range users {
+		byID[user.ID] = user
+		if user.IsActive {
+			active = append(active, user.ID)
+		}
+	}
+
+	preferences, err := loadPreferences(ctx, d.prefs, active)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to load notification preferences: %w", err)
+	}
+
+	day := digestDay(now, d.email.cfg.DigestAt, d.email.cfg.DigestLocation)
+	sent, postponed := 0, 0
+	var errs []error
+	for _, userID := range active {
+		p := preferences[userID]
+		if !p.HasChannel(models.ChannelEmail) {
+			continue
+		}
+		address := d.email.address(p)
+		if address == "" {
+			continue
+		}
+
+		prs, err := d.reviews.GetOpenPRsWithReviewer(ctx, userID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", userID, err))
+			continue
+		}
+		var included []*models.PullRequest
+		for _, pr := range prs {
+			if d.includes(p, pr, now) {
+				included = append(included, pr)
+			}
+		}
+		prs = included
+		if len(prs) == 0 {
+			continue
+		}
+		if p.InQuietHours(now) {
+			postponed++
+			continue
+		}
+
+		claimed, err := d.marks.ClaimDigest(ctx, userID, day)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", userID, err))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		// Сначала самые старые: их ревью задерживается дольше всего
+		slices.SortFunc(prs, func(a, b *models.PullRequest) int {
+			return a.CreatedAt.Compare(b.CreatedAt)
+		})
+
+		data := digestEmail{Reviewer: displayName(byID, userID)}
+		for _, pr := range prs {
+			data.PullRequests = append(data.PullRequests, digestItem{
+				ID:     pr.ID,
+				Name:   pr.Name,
+				Author: displayName(byID, pr.AuthorID),
+				Age:    formatAge(now.Sub(pr.CreatedAt)),
+			})
+		}
+
+		if err := d.email.send(ctx, address, "digest", data); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", userID, err))
+			if err := d.marks.ReleaseDigest(ctx, userID, day); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", userID, err))
+			}
+			continue
+		}
+		sent++
+	}
+
+	return sent, postponed, errors.Join(errs...)
+}
+
+// includes сообщает, попадает ли PR в сводку пользователя с настройками p. Поводы
+// assigned и reassigned включают все ожидающие ревью PR, overdue - только просроченные.
+func (d *Digest) includes(p *models.NotificationPreferences, pr *models.PullRequest, now time.Time) bool {
+	if slices.Contains(p.EventTypes, KindAssigned) || slices.Contains(p.EventTypes, KindReassigned) {
+		return true
+	}
+	return slices.Contains(p.EventTypes, KindOverdue) && now.Sub(pr.CreatedAt) >= d.overdueAfter
+}
+
+// nextDigest возвращает ближайший после now момент отправки сводки
+func nextDigest(now time.Time, at time.Duration, loc *time.Location) time.Time {
+	local := now.In(loc)
+	year, month, day := local.Date()
+
+	next := time.Date(year, month, day, 0, 0, 0, 0, loc).Add(at)
+	if !next.After(now) {
+		next = time.Date(year, month, day+1, 0, 0, 0, 0, loc).Add(at)
+	}
+	return next
+}
+
+// digestDay возвращает день последней к моменту now отправки сводки: отложенные и
+// повторные отправки до следующего DigestAt относятся к тому же дню
+func digestDay(now time.Time, at time.Duration, loc *time.Location) time.Time {
+	year, month, day := nextDigest(now, at, loc).In(loc).Date()
+	return time.Date(year, month, day-1, 0, 0, 0, 0, time.UTC)
+}
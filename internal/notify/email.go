@@ -0,0 +1,139 @@
+package notify
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/config"
+	"github.com/vnchk1/pr-manager/internal/models"
+)
+
+//go:embed templates
+var emailTemplates embed.FS
+
+// emailTemplate - пара шаблонов письма; тема задается блоком subject текстового шаблона
+type emailTemplate struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+type assignmentEmail struct {
+	Reviewer    string
+	ID          string
+	Name        string
+	Author      string
+	OldReviewer string
+}
+
+type digestEmail struct {
+	Reviewer     string
+	PullRequests []digestItem
+}
+
+type digestItem struct {
+	ID     string
+	Name   string
+	Author string
+	Age    string
+}
+
+// Email отправляет письма о назначениях и ежедневную сводку. Адрес берется из
+// настроек пользователя, иначе user_id@AddressDomain.
+type Email struct {
+	cfg       config.SMTPConfig
+	timeout   time.Duration
+	templates map[string]emailTemplate
+}
+
+func NewEmail(cfg config.SMTPConfig, timeout time.Duration) (*Email, error) {
+	templates := make(map[string]emailTemplate)
+	for _, name := range []string{"assigned", "digest"} {
+		text, err := texttemplate.ParseFS(emailTemplates, "templates/"+name+".txt.tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s text template: %w", name, err)
+		}
+		html, err := htmltemplate.ParseFS(emailTemplates, "templates/"+name+".html.tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s html template: %w", name, err)
+		}
+		templates[name] = emailTemplate{text: text, html: html}
+	}
+
+	return &Email{cfg: cfg, timeout: timeout, templates: templates}, nil
+}
+
+func (e *Email) Name() string {
+	return "email"
+}
+
+// Send пишет каждому назначенному ревьюверу; о просроченных PR напоминает сводка
+func (e *Email) Send(ctx context.Context, n *Notification) error {
+	if n.Kind == KindOverdue {
+		return nil
+	}
+
+	var errs []error
+	for _, reviewerID := range n.Recipients(models.ChannelEmail) {
+		address := e.address(n.Preferences[reviewerID])
+		if address == "" {
+			continue
+		}
+
+		data := assignmentEmail{
+			Reviewer: displayName(n.Users, reviewerID),
+			ID:       n.PullRequest.ID,
+			Name:     n.PullRequest.Name,
+			Author:   displayName(n.Users, n.PullRequest.AuthorID),
+		}
+		if n.OldReviewer != "" {
+			data.OldReviewer = displayName(n.Users, n.OldReviewer)
+		}
+
+		if err := e.send(ctx, address, "assigned", data); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", reviewerID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// address возвращает адрес из настроек или адрес по умолчанию; пустая строка - адреса нет
+func (e *Email) address(p *models.NotificationPreferences) string {
+	if p.Email != "" {
+		return p.Email
+	}
+	if e.cfg.AddressDomain != "" {
+		return p.UserID + "@" + e.cfg.AddressDomain
+	}
+	return ""
+}
+
+func (e *Email) send(ctx context.Context, to, templateName string, data any) error {
+	tmpl := e.templates[templateName]
+
+	var subject, text, html strings.Builder
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return fmt.Errorf("failed to render %s subject: %w", templateName, err)
+	}
+	if err := tmpl.text.Execute(&text, data); err != nil {
+		return fmt.Errorf("failed to render %s text: %w", templateName, err)
+	}
+	if err := tmpl.html.Execute(&html, data); err != nil {
+		return fmt.Errorf("failed to render %s html: %w", templateName, err)
+	}
+
+	return e.deliver(ctx, emailMessage{To: to, Subject: subject.String(), Text: text.String(), HTML: html.String()})
+}
+
+func displayName(users map[string]*models.User, userID string) string {
+	if user, ok := users[userID]; ok {
+		return user.Username
+	}
+	return userID
+}
@@ -0,0 +1,355 @@
+package notify
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/config"
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePreferences []*models.NotificationPreferences
+
+func (f fakePreferences) GetByUserIDs(_ context.Context, userIDs []string) ([]*models.NotificationPreferences, error) {
+	var result []*models.NotificationPreferences
+	for _, p := range f {
+		for _, id := range userIDs {
+			if p.UserID == id {
+				result = append(result, p)
+			}
+		}
+	}
+	return result, nil
+}
+
+type fakeReviews map[string][]*models.PullRequest
+
+func (f fakeReviews) GetOpenPRsWithReviewer(_ context.Context, reviewerID string) ([]*models.PullRequest, error) {
+	return f[reviewerID], nil
+}
+
+type fakeUserList []*models.User
+
+func (f fakeUserList) List(_ context.Context) ([]*models.User, error) {
+	return f, nil
+}
+
+func testPreference(userID string, update func(p *models.NotificationPreferences)) *models.NotificationPreferences {
+	p := models.DefaultNotificationPreferences(userID)
+	update(p)
+	return p
+}
+
+var testPreferences = fakePreferences{
+	testPreference("u2", func(p *models.NotificationPreferences) { p.Email = "bob@corp.example" }),
+	testPreference("u3", func(p *models.NotificationPreferences) {
+		p.Channels = []models.NotificationChannel{models.ChannelChat, models.ChannelWebhook}
+	}),
+}
+
+// withPreferences заполняет настройки ревьюверов, как это делает Notifier перед отправкой
+func withPreferences(t *testing.T, n *Notification) *Notification {
+	t.Helper()
+
+	var err error
+	n.Preferences, err = loadPreferences(context.Background(), testPreferences, n.Reviewers)
+	require.NoError(t, err)
+	n.At = time.Now()
+	return n
+}
+
+func newTestEmail(t *testing.T, sink *smtpSink, username, password string) *Email {
+	t.Helper()
+
+	host, port, err := net.SplitHostPort(sink.addr)
+	require.NoError(t, err)
+	portNum, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	email, err := NewEmail(config.SMTPConfig{
+		Host:           host,
+		Port:           portNum,
+		Username:       username,
+		Password:       password,
+		From:           "pr-manager@corp.example",
+		AddressDomain:  "example.com",
+		DigestAt:       9 * time.Hour,
+		DigestLocation: time.UTC,
+	}, 5*time.Second)
+	require.NoError(t, err)
+
+	return email
+}
+
+type parsedMail struct {
+	subject string
+	text    string
+	html    string
+}
+
+func parseMail(t *testing.T, data string) parsedMail {
+	t.Helper()
+
+	msg, err := mail.ReadMessage(strings.NewReader(data))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	result := parsedMail{subject: subject}
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		raw, err := io.ReadAll(part)
+		require.NoError(t, err)
+		// SMTP передает строки с CRLF
+		content := strings.ReplaceAll(string(raw), "\r\n", "\n")
+		if strings.HasPrefix(part.Header.Get("Content-Type"), "text/html") {
+			result.html = content
+		} else {
+			result.text = content
+		}
+	}
+
+	return result
+}
+
+func TestEmailAssignmentNotifications(t *testing.T) {
+	sink := newSMTPSink(t, "")
+	email := newTestEmail(t, sink, "", "")
+
+	err := email.Send(context.Background(), withPreferences(t, &Notification{
+		Kind:        KindAssigned,
+		TeamName:    "backend",
+		PullRequest: &models.PullRequest{ID: "pr-1", Name: "Add <search>", AuthorID: "u1"},
+		Reviewers:   []string{"u2", "u3", "u5"},
+		Users:       map[string]*models.User{"u1": testUsers["u1"], "u2": testUsers["u2"]},
+	}))
+	require.NoError(t, err)
+
+	messages := sink.received()
+	require.Len(t, messages, 2, "u3 turned off the email channel")
+
+	assert.Equal(t, "pr-manager@corp.example", messages[0].from)
+	assert.Equal(t, []string{"bob@corp.example"}, messages[0].to)
+	assert.Equal(t, []string{"u5@example.com"}, messages[1].to, "address from the default domain")
+
+	bob := parseMail(t, messages[0].data)
+	assert.Equal(t, "Вас назначили ревьювером: Add <search>", bob.subject)
+	assert.Contains(t, bob.text, "Здравствуйте, bob!")
+	assert.Contains(t, bob.text, "Add <search> (pr-1)\n  Автор: alice")
+	assert.Contains(t, bob.html, "<b>Add &lt;search&gt;</b> (pr-1)")
+
+	assert.Contains(t, parseMail(t, messages[1].data).text, "Здравствуйте, u5!")
+}
+
+func TestEmailReassignmentAndOverdue(t *testing.T) {
+	sink := newSMTPSink(t, "secret")
+	email := newTestEmail(t, sink, "notifier", "secret")
+
+	pr := &models.PullRequest{ID: "pr-1", Name: "Fix login", AuthorID: "u1"}
+	users := map[string]*models.User{"u1": testUsers["u1"], "u2": testUsers["u2"], "u3": testUsers["u3"]}
+
+	require.NoError(t, email.Send(context.Background(), withPreferences(t, &Notification{
+		Kind: KindReassigned, PullRequest: pr, Reviewers: []string{"u2"}, OldReviewer: "u3", Users: users,
+	})))
+	require.NoError(t, email.Send(context.Background(), withPreferences(t, &Notification{
+		Kind: KindOverdue, PullRequest: pr, Reviewers: []string{"u2"}, Users: users,
+	})))
+
+	messages := sink.received()
+	require.Len(t, messages, 1, "overdue PRs are covered by the digest")
+	assert.Equal(t, []string{"", "notifier", "secret"}, sink.auth)
+
+	parsed := parseMail(t, messages[0].data)
+	assert.Equal(t, "Вам передано ревью: Fix login", parsed.subject)
+	assert.Contains(t, parsed.text, "Вам передано ревью PR вместо carol.")
+}
+
+func TestEmailAuthFailure(t *testing.T) {
+	sink := newSMTPSink(t, "secret")
+	email := newTestEmail(t, sink, "notifier", "wrong")
+
+	err := email.Send(context.Background(), withPreferences(t, &Notification{
+		Kind: KindAssigned, PullRequest: &models.PullRequest{ID: "pr-1", AuthorID: "u1"}, Reviewers: []string{"u2"},
+	}))
+	assert.ErrorContains(t, err, "smtp auth failed")
+	assert.Empty(t, sink.received())
+}
+
+// fakeDigestMarks - день последней сводки по пользователям
+type fakeDigestMarks map[string]time.Time
+
+func (f fakeDigestMarks) ClaimDigest(_ context.Context, userID string, day time.Time) (bool, error) {
+	if last, ok := f[userID]; ok && !last.Before(day) {
+		return false, nil
+	}
+	f[userID] = day
+	return true, nil
+}
+
+func (f fakeDigestMarks) ReleaseDigest(_ context.Context, userID string, day time.Time) error {
+	if f[userID].Equal(day) {
+		delete(f, userID)
+	}
+	return nil
+}
+
+func TestDigest(t *testing.T) {
+	sink := newSMTPSink(t, "")
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	users := fakeUserList{
+		{ID: "u1", Username: "alice", TeamName: "backend", IsActive: true},
+		{ID: "u2", Username: "bob", TeamName: "backend", IsActive: true},
+		{ID: "u3", Username: "carol", TeamName: "backend", IsActive: true},
+		{ID: "u6", Username: "erin", TeamName: "backend", IsActive: true},
+		{ID: "u7", Username: "frank", TeamName: "backend", IsActive: false},
+	}
+
+	reviews := fakeReviews{
+		"u2": {
+			{ID: "pr-2", Name: "Fix login", AuthorID: "u1", CreatedAt: now.Add(-5 * time.Hour)},
+			{ID: "pr-1", Name: "Add search", AuthorID: "u3", CreatedAt: now.Add(-51 * time.Hour)},
+		},
+		"u3": {{ID: "pr-1", Name: "Add search", AuthorID: "u3", CreatedAt: now.Add(-51 * time.Hour)}},
+		"u7": {{ID: "pr-3", Name: "Stale", AuthorID: "u1", CreatedAt: now.Add(-100 * time.Hour)}},
+	}
+
+	// Режим digest отключает только мгновенные уведомления, сводка приходит
+	prefs := append(fakePreferences{
+		testPreference("u6", func(p *models.NotificationPreferences) { p.Delivery = models.DeliveryDigest }),
+	}, testPreferences...)
+	reviews["u6"] = []*models.PullRequest{{ID: "pr-4", Name: "Docs", AuthorID: "u1", CreatedAt: now.Add(-2 * time.Hour)}}
+
+	digest := NewDigest(newTestEmail(t, sink, "", ""), 48*time.Hour, users, reviews, prefs, fakeDigestMarks{},
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+	sent, postponed, err := digest.Send(context.Background(), now)
+	require.NoError(t, err)
+
+	// u1 без открытых ревью, у u3 выключен email, u7 неактивен
+	assert.Equal(t, 2, sent)
+	assert.Zero(t, postponed)
+	messages := sink.received()
+	require.Len(t, messages, 2)
+	assert.Equal(t, []string{"bob@corp.example"}, messages[0].to)
+	assert.Equal(t, []string{"u6@example.com"}, messages[1].to)
+
+	parsed := parseMail(t, messages[0].data)
+	assert.Equal(t, "Открытые PR на ревью: 2", parsed.subject)
+	assert.Equal(t, `Здравствуйте, bob!
+
+Открытые PR, ожидающие вашего ревью:
+
+  Add search (pr-1)
+  Автор: carol, открыт 2 д 3 ч назад
+
+  Fix login (pr-2)
+  Автор: alice, открыт 5 ч назад
+`, parsed.text)
+	assert.Contains(t, parsed.html, "<tr><td><b>Add search</b> (pr-1)</td><td>carol</td><td>2 д 3 ч назад</td></tr>")
+
+	// Повторная отправка в тот же день, например с другой реплики, писем не шлет
+	sent, _, err = digest.Send(context.Background(), now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+	assert.Len(t, sink.received(), 2)
+}
+
+func TestDigestPreferences(t *testing.T) {
+	sink := newSMTPSink(t, "")
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	users := fakeUserList{
+		{ID: "u2", Username: "bob", TeamName: "backend", IsActive: true},
+		{ID: "u3", Username: "carol", TeamName: "backend", IsActive: true},
+		{ID: "u4", Username: "dave", TeamName: "backend", IsActive: true},
+	}
+	prs := []*models.PullRequest{
+		{ID: "pr-1", Name: "Add search", AuthorID: "u1", CreatedAt: now.Add(-51 * time.Hour)},
+		{ID: "pr-2", Name: "Fix login", AuthorID: "u1", CreatedAt: now.Add(-5 * time.Hour)},
+	}
+	reviews := fakeReviews{"u2": prs, "u3": prs, "u4": prs}
+
+	prefs := fakePreferences{
+		// Тихие часы до 10:00 UTC: сводка откладывается
+		testPreference("u2", func(p *models.NotificationPreferences) {
+			p.QuietHours = &models.QuietHours{Start: "22:00", End: "10:00"}
+		}),
+		// Только просрочка: в сводке лишь PR старше 48 ч
+		testPreference("u3", func(p *models.NotificationPreferences) {
+			p.EventTypes = []models.NotificationKind{models.NotifyOverdue}
+		}),
+		// Без поводов сводка не нужна
+		testPreference("u4", func(p *models.NotificationPreferences) { p.EventTypes = nil }),
+	}
+
+	digest := NewDigest(newTestEmail(t, sink, "", ""), 48*time.Hour, users, reviews, prefs, fakeDigestMarks{},
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	sent, postponed, err := digest.Send(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, 1, postponed)
+
+	messages := sink.received()
+	require.Len(t, messages, 1)
+	assert.Equal(t, []string{"u3@example.com"}, messages[0].to)
+	carol := parseMail(t, messages[0].data)
+	assert.Contains(t, carol.text, "Add search (pr-1)")
+	assert.NotContains(t, carol.text, "pr-2")
+
+	// После тихих часов отложенная сводка уходит, остальным повторно не отправляется
+	sent, postponed, err = digest.Send(context.Background(), now.Add(90*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Zero(t, postponed)
+
+	messages = sink.received()
+	require.Len(t, messages, 2)
+	assert.Equal(t, []string{"u2@example.com"}, messages[1].to)
+}
+
+func TestDigestDay(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	at := 9 * time.Hour
+
+	// До 09:00 MSK действует вчерашняя сводка, с 09:00 - сегодняшняя
+	assert.Equal(t, time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC), digestDay(time.Date(2025, 3, 10, 5, 59, 0, 0, time.UTC), at, moscow))
+	assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), digestDay(time.Date(2025, 3, 10, 6, 0, 0, 0, time.UTC), at, moscow))
+	assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), digestDay(time.Date(2025, 3, 10, 22, 0, 0, 0, time.UTC), at, moscow))
+}
+
+func TestNextDigest(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	at := 9 * time.Hour
+
+	// 05:30 UTC - 08:30 по Москве: сводка сегодня в 09:00 MSK
+	now := time.Date(2025, 3, 10, 5, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 3, 10, 9, 0, 0, 0, moscow), nextDigest(now, at, moscow))
+
+	// Ровно в момент отправки следующая - завтра
+	now = time.Date(2025, 3, 10, 6, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 3, 11, 9, 0, 0, 0, moscow), nextDigest(now, at, moscow))
+}
@@ -0,0 +1,255 @@
+// Package notify оповещает ревьюверов о назначениях и просроченных PR. Назначения
+// берутся из шины событий, просроченные PR - периодической проверкой открытых PR,
+// которая отмечает оповещенные PR в базе.
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/config"
+	"github.com/vnchk1/pr-manager/internal/events"
+	"github.com/vnchk1/pr-manager/internal/models"
+)
+
+type Kind = models.NotificationKind
+
+const (
+	KindAssigned   = models.NotifyAssigned
+	KindReassigned = models.NotifyReassigned
+	KindOverdue    = models.NotifyOverdue
+)
+
+// Notification - повод оповестить ревьюверов PR
+type Notification struct {
+	Kind        Kind
+	TeamName    string
+	PullRequest *models.PullRequest
+	// Reviewers - кого оповестить: при назначении и просрочке - все ревьюверы PR,
+	// при переназначении - только новый
+	Reviewers   []string
+	OldReviewer string
+	// Age - возраст просроченного PR
+	Age time.Duration
+	// Users - автор и упомянутые ревьюверы по ID; отсутствующие в базе пропускаются
+	Users map[string]*models.User
+	// Preferences - настройки каждого из Reviewers, включая значения по умолчанию
+	Preferences map[string]*models.NotificationPreferences
+	// At - момент отправки, по нему проверяются тихие часы
+	At time.Time
+}
+
+// Recipients возвращает ревьюверов, которые сейчас принимают уведомления этого вида
+// в канал channel. Каналы должны отправлять уведомления только им.
+func (n *Notification) Recipients(channel models.NotificationChannel) []string {
+	var recipients []string
+	for _, reviewerID := range n.Reviewers {
+		if p, ok := n.Preferences[reviewerID]; ok && p.Allows(channel, n.Kind, n.At) {
+			recipients = append(recipients, reviewerID)
+		}
+	}
+	return recipients
+}
+
+// Sink - канал доставки уведомлений
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, n *Notification) error
+}
+
+type PRSource interface {
+	// ClaimOverdue возвращает открытые PR, созданные не позже createdBefore, о которых
+	// еще не оповещали, и отмечает их оповещенными
+	ClaimOverdue(ctx context.Context, createdBefore time.Time) ([]*models.PullRequest, error)
+}
+
+type UserSource interface {
+	GetByIDs(ctx context.Context, userIDs []string) ([]*models.User, error)
+}
+
+type PreferencesSource interface {
+	GetByUserIDs(ctx context.Context, userIDs []string) ([]*models.NotificationPreferences, error)
+}
+
+type Notifier struct {
+	cfg    config.NotifyConfig
+	prs    PRSource
+	users  UserSource
+	prefs  PreferencesSource
+	sinks  []Sink
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+func New(cfg config.NotifyConfig, prs PRSource, users UserSource, prefs PreferencesSource, logger *slog.Logger, sinks ...Sink) *Notifier {
+	return &Notifier{cfg: cfg, prs: prs, users: users, prefs: prefs, sinks: sinks, logger: logger, now: time.Now}
+}
+
+// Run рассылает уведомления о событиях шины и раз в OverdueCheckInterval проверяет
+// просроченные PR, пока не отменен контекст
+func (n *Notifier) Run(ctx context.Context, bus *events.Bus) {
+	go n.watchOverdue(ctx)
+	n.listen(ctx, bus)
+}
+
+func (n *Notifier) listen(ctx context.Context, bus *events.Bus) {
+	sub := bus.Subscribe(models.EventFilter{})
+	defer func() { bus.Unsubscribe(sub) }()
+
+	var lastID int64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-sub.Events():
+			if ok {
+				if event.ID > lastID {
+					lastID = event.ID
+					n.handleEvent(ctx, event)
+				}
+				continue
+			}
+
+			// Шина отписала нас за медленную доставку: подписываемся заново и
+			// догоняем пропущенное по журналу
+			sub = bus.Subscribe(models.EventFilter{})
+			if lastID == 0 {
+				continue
+			}
+			var err error
+			lastID, err = bus.Replay(ctx, lastID, models.EventFilter{}, func(event *models.Event) error {
+				n.handleEvent(ctx, event)
+				return nil
+			})
+			if err != nil {
+				n.logger.Error("failed to replay events for notifications", "error", err)
+			}
+		}
+	}
+}
+
+func (n *Notifier) handleEvent(ctx context.Context, event *models.Event) {
+	if event.Type != models.EventPRCreated && event.Type != models.EventPRReassigned {
+		return
+	}
+
+	var data models.PREventData
+	if err := json.Unmarshal(event.Data, &data); err != nil || data.PullRequest == nil {
+		n.logger.Error("invalid pull request event", "event_id", event.ID, "error", err)
+		return
+	}
+
+	notification := &Notification{
+		Kind:        KindAssigned,
+		TeamName:    event.TeamName,
+		PullRequest: data.PullRequest,
+		Reviewers:   data.PullRequest.AssignedReviewers,
+	}
+	if event.Type == models.EventPRReassigned {
+		notification.Kind = KindReassigned
+		notification.OldReviewer = data.OldReviewer
+		notification.Reviewers = nil
+		if data.NewReviewer != "" {
+			notification.Reviewers = []string{data.NewReviewer}
+		}
+	}
+
+	n.dispatch(ctx, notification)
+}
+
+func (n *Notifier) watchOverdue(ctx context.Context) {
+	ticker := time.NewTicker(n.cfg.OverdueCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := n.checkOverdue(ctx, n.now()); err != nil {
+				n.logger.Error("failed to check overdue pull requests", "error", err)
+			}
+		}
+	}
+}
+
+// checkOverdue оповещает о PR, срок которых истек к моменту now. PR забираются из базы
+// с отметкой, поэтому о каждом PR оповещает одна реплика один раз, а PR, просроченные
+// во время простоя, попадают в первую проверку после запуска.
+func (n *Notifier) checkOverdue(ctx context.Context, now time.Time) error {
+	prs, err := n.prs.ClaimOverdue(ctx, now.Add(-n.cfg.OverdueAfter))
+	if err != nil {
+		return err
+	}
+
+	for _, pr := range prs {
+		n.dispatch(ctx, &Notification{
+			Kind:        KindOverdue,
+			PullRequest: pr,
+			Reviewers:   pr.AssignedReviewers,
+			Age:         now.Sub(pr.CreatedAt),
+		})
+	}
+
+	return nil
+}
+
+// dispatch загружает упомянутых пользователей и настройки ревьюверов и отправляет
+// уведомление во все каналы. Ошибка канала пишется в лог и не мешает остальным.
+func (n *Notifier) dispatch(ctx context.Context, notification *Notification) {
+	if len(notification.Reviewers) == 0 {
+		return
+	}
+
+	userIDs := append([]string{notification.PullRequest.AuthorID}, notification.Reviewers...)
+	if notification.OldReviewer != "" {
+		userIDs = append(userIDs, notification.OldReviewer)
+	}
+	users, err := n.users.GetByIDs(ctx, userIDs)
+	if err != nil {
+		n.logger.Error("failed to load users for notification", "pr_id", notification.PullRequest.ID, "error", err)
+		return
+	}
+
+	notification.Users = make(map[string]*models.User, len(users))
+	for _, user := range users {
+		notification.Users[user.ID] = user
+	}
+	if author, ok := notification.Users[notification.PullRequest.AuthorID]; ok && notification.TeamName == "" {
+		notification.TeamName = author.TeamName
+	}
+
+	notification.Preferences, err = loadPreferences(ctx, n.prefs, notification.Reviewers)
+	if err != nil {
+		n.logger.Error("failed to load notification preferences", "pr_id", notification.PullRequest.ID, "error", err)
+		return
+	}
+	notification.At = n.now()
+
+	for _, sink := range n.sinks {
+		if err := sink.Send(ctx, notification); err != nil {
+			n.logger.Error("failed to send notification",
+				"sink", sink.Name(), "kind", notification.Kind, "pr_id", notification.PullRequest.ID, "error", err)
+		}
+	}
+}
+
+// loadPreferences возвращает настройки каждого пользователя; кто их не сохранял,
+// получает настройки по умолчанию
+func loadPreferences(ctx context.Context, source PreferencesSource, userIDs []string) (map[string]*models.NotificationPreferences, error) {
+	saved, err := source.GetByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := make(map[string]*models.NotificationPreferences, len(userIDs))
+	for _, userID := range userIDs {
+		preferences[userID] = models.DefaultNotificationPreferences(userID)
+	}
+	for _, p := range saved {
+		preferences[p.UserID] = p
+	}
+	return preferences, nil
+}
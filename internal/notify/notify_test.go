@@ -0,0 +1,245 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/config"
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookRecorder заменяет Slack: запоминает тексты сообщений по пути вебхука
+type webhookRecorder struct {
+	mu       sync.Mutex
+	messages map[string][]string
+	status   int
+}
+
+func newWebhookRecorder(t *testing.T) (*webhookRecorder, *httptest.Server) {
+	t.Helper()
+
+	recorder := &webhookRecorder{messages: make(map[string][]string), status: http.StatusOK}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var payload struct {
+			Text string `json:"text"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+
+		recorder.mu.Lock()
+		defer recorder.mu.Unlock()
+		recorder.messages[r.URL.Path] = append(recorder.messages[r.URL.Path], payload.Text)
+		w.WriteHeader(recorder.status)
+		_, _ = w.Write([]byte("no_service"))
+	}))
+	t.Cleanup(srv.Close)
+
+	return recorder, srv
+}
+
+func (r *webhookRecorder) sent(path string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.messages[path]
+}
+
+type fakeUsers map[string]*models.User
+
+func (f fakeUsers) GetByIDs(_ context.Context, userIDs []string) ([]*models.User, error) {
+	var users []*models.User
+	for _, id := range userIDs {
+		if user, ok := f[id]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+// fakePRs - открытые PR; ClaimOverdue убирает отданные PR, как это делает отметка в базе
+type fakePRs []*models.PullRequest
+
+func (f *fakePRs) ClaimOverdue(_ context.Context, createdBefore time.Time) ([]*models.PullRequest, error) {
+	var claimed, rest []*models.PullRequest
+	for _, pr := range *f {
+		if pr.CreatedAt.After(createdBefore) {
+			rest = append(rest, pr)
+		} else {
+			claimed = append(claimed, pr)
+		}
+	}
+	*f = rest
+	return claimed, nil
+}
+
+var testUsers = fakeUsers{
+	"u1": {ID: "u1", Username: "alice", TeamName: "backend"},
+	"u2": {ID: "u2", Username: "bob", TeamName: "backend"},
+	"u3": {ID: "u3", Username: "carol", TeamName: "backend"},
+	"u4": {ID: "u4", Username: "dave", TeamName: "frontend"},
+}
+
+func newTestNotifier(t *testing.T, prs fakePRs, cfg *SlackConfig) *Notifier {
+	t.Helper()
+
+	slack, err := NewSlack(cfg, http.DefaultClient)
+	require.NoError(t, err)
+
+	notifyCfg := config.NotifyConfig{OverdueAfter: 48 * time.Hour, OverdueCheckInterval: time.Hour}
+	return New(notifyCfg, &prs, testUsers, testPreferences, slog.New(slog.NewTextHandler(io.Discard, nil)), slack)
+}
+
+func prEvent(eventType models.EventType, teamName string, data models.PREventData) *models.Event {
+	event := models.NewPREvent(eventType, teamName, data)
+	event.ID = 1
+	return event
+}
+
+func TestSlackAssignmentNotifications(t *testing.T) {
+	recorder, srv := newWebhookRecorder(t)
+	n := newTestNotifier(t, nil, &SlackConfig{
+		Webhooks: map[string]string{"backend": srv.URL + "/backend"},
+		Mentions: map[string]string{"u2": "<@U02>"},
+	})
+	ctx := context.Background()
+
+	pr := &models.PullRequest{ID: "pr-1", Name: "Add <search>", AuthorID: "u1", AssignedReviewers: []string{"u2", "u3"}}
+	n.handleEvent(ctx, prEvent(models.EventPRCreated, "backend", models.PREventData{PullRequest: pr}))
+
+	reassigned := &models.PullRequest{ID: "pr-1", Name: "Add <search>", AuthorID: "u1", AssignedReviewers: []string{"u2", "u5"}}
+	n.handleEvent(ctx, prEvent(models.EventPRReassigned, "backend", models.PREventData{
+		PullRequest: reassigned, OldReviewer: "u3", NewReviewer: "u5",
+	}))
+
+	// Без замены оповещать некого, у frontend нет вебхука, мерж не оповещается
+	n.handleEvent(ctx, prEvent(models.EventPRReassigned, "backend", models.PREventData{PullRequest: pr, OldReviewer: "u3"}))
+	n.handleEvent(ctx, prEvent(models.EventPRCreated, "frontend", models.PREventData{
+		PullRequest: &models.PullRequest{ID: "pr-2", Name: "UI", AuthorID: "u4", AssignedReviewers: []string{"u3"}},
+	}))
+	n.handleEvent(ctx, prEvent(models.EventPRMerged, "backend", models.PREventData{PullRequest: pr}))
+
+	assert.Equal(t, []string{
+		"<@U02>, @carol, вас назначили ревьювером PR *Add &lt;search&gt;* (pr-1) от @alice",
+		"u5, вам передано ревью PR *Add &lt;search&gt;* (pr-1) от @alice вместо @carol",
+	}, recorder.sent("/backend"))
+}
+
+func TestSlackCustomTemplate(t *testing.T) {
+	recorder, srv := newWebhookRecorder(t)
+	n := newTestNotifier(t, nil, &SlackConfig{
+		Webhooks:  map[string]string{"backend": srv.URL + "/backend"},
+		Templates: map[Kind]string{KindAssigned: `Review {{.ID}}: {{join .Reviewers " "}}`},
+	})
+
+	n.handleEvent(context.Background(), prEvent(models.EventPRCreated, "backend", models.PREventData{
+		PullRequest: &models.PullRequest{ID: "pr-1", AuthorID: "u1", AssignedReviewers: []string{"u2", "u3"}},
+	}))
+
+	assert.Equal(t, []string{"Review pr-1: @bob @carol"}, recorder.sent("/backend"))
+}
+
+func TestOverdueNotifications(t *testing.T) {
+	recorder, srv := newWebhookRecorder(t)
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	n := newTestNotifier(t, fakePRs{
+		{ID: "pr-due", Name: "Due", AuthorID: "u1", AssignedReviewers: []string{"u2"}, CreatedAt: now.Add(-48*time.Hour - 30*time.Minute)},
+		{ID: "pr-old", Name: "Old", AuthorID: "u1", AssignedReviewers: []string{"u2"}, CreatedAt: now.Add(-50 * time.Hour)},
+		{ID: "pr-new", Name: "New", AuthorID: "u1", AssignedReviewers: []string{"u2"}, CreatedAt: now.Add(-time.Hour)},
+		{ID: "pr-lonely", Name: "Lonely", AuthorID: "u1", CreatedAt: now.Add(-48*time.Hour - 10*time.Minute)},
+		{ID: "pr-front", Name: "Front", AuthorID: "u4", AssignedReviewers: []string{"u3"}, CreatedAt: now.Add(-49*time.Hour - 20*time.Minute)},
+	}, &SlackConfig{Webhooks: map[string]string{"backend": srv.URL + "/backend", "frontend": srv.URL + "/frontend"}})
+
+	// Первая проверка после запуска застает PR, просроченные во время простоя
+	require.NoError(t, n.checkOverdue(context.Background(), now.Add(-time.Hour)))
+	require.NoError(t, n.checkOverdue(context.Background(), now))
+	require.NoError(t, n.checkOverdue(context.Background(), now.Add(time.Hour)))
+
+	assert.Equal(t, []string{"@carol, PR *Front* (pr-front) от @dave ждет ревью уже 2 д"}, recorder.sent("/frontend"))
+	assert.Equal(t, []string{
+		"@bob, PR *Old* (pr-old) от @alice ждет ревью уже 2 д 1 ч",
+		"@bob, PR *Due* (pr-due) от @alice ждет ревью уже 2 д",
+	}, recorder.sent("/backend"), "each PR is reported once")
+}
+
+func TestSlackWebhookError(t *testing.T) {
+	recorder, srv := newWebhookRecorder(t)
+	recorder.status = http.StatusNotFound
+
+	slack, err := NewSlack(&SlackConfig{Webhooks: map[string]string{"backend": srv.URL}}, http.DefaultClient)
+	require.NoError(t, err)
+
+	err = slack.Send(context.Background(), withPreferences(t, &Notification{
+		Kind:        KindAssigned,
+		TeamName:    "backend",
+		PullRequest: &models.PullRequest{ID: "pr-1", AuthorID: "u1"},
+		Reviewers:   []string{"u2"},
+	}))
+	assert.ErrorContains(t, err, "webhook responded 404: no_service")
+}
+
+func TestNewSlackValidatesConfig(t *testing.T) {
+	_, err := NewSlack(&SlackConfig{Webhooks: map[string]string{"backend": "hooks.slack.com/services/x"}}, http.DefaultClient)
+	assert.ErrorContains(t, err, "invalid webhook url for team backend")
+
+	_, err = NewSlack(&SlackConfig{Templates: map[Kind]string{KindOverdue: "{{.Age"}}, http.DefaultClient)
+	assert.ErrorContains(t, err, "invalid overdue template")
+
+	_, err = NewSlack(&SlackConfig{Templates: map[Kind]string{"merged": "done"}}, http.DefaultClient)
+	assert.ErrorContains(t, err, "unknown template merged")
+}
+
+func TestFormatAge(t *testing.T) {
+	assert.Equal(t, "5 ч", formatAge(5*time.Hour+40*time.Minute))
+	assert.Equal(t, "3 д", formatAge(72*time.Hour))
+	assert.Equal(t, "2 д 3 ч", formatAge(51*time.Hour))
+}
+
+func TestNotificationPreferences(t *testing.T) {
+	recorder, srv := newWebhookRecorder(t)
+	slack, err := NewSlack(&SlackConfig{Webhooks: map[string]string{"backend": srv.URL + "/backend"}}, http.DefaultClient)
+	require.NoError(t, err)
+
+	prefs := fakePreferences{
+		// Тихие часы через полночь по Москве: 23:00-08:00 MSK = 20:00-05:00 UTC
+		testPreference("u1", func(p *models.NotificationPreferences) {
+			p.QuietHours = &models.QuietHours{Start: "23:00", End: "08:00"}
+			p.Timezone = "Europe/Moscow"
+		}),
+		testPreference("u2", func(p *models.NotificationPreferences) { p.Delivery = models.DeliveryDigest }),
+		testPreference("u3", func(p *models.NotificationPreferences) {
+			p.EventTypes = []models.NotificationKind{models.NotifyOverdue}
+		}),
+		testPreference("u4", func(p *models.NotificationPreferences) {
+			p.Channels = []models.NotificationChannel{models.ChannelEmail}
+		}),
+	}
+	notifyCfg := config.NotifyConfig{OverdueAfter: 48 * time.Hour, OverdueCheckInterval: time.Hour}
+	n := New(notifyCfg, nil, testUsers, prefs, slog.New(slog.NewTextHandler(io.Discard, nil)), slack)
+
+	pr := &models.PullRequest{ID: "pr-1", Name: "Fix", AuthorID: "u5", AssignedReviewers: []string{"u1", "u2", "u3", "u4", "u5"}}
+
+	// 02:00 UTC - 05:00 по Москве, у u1 тихие часы
+	n.now = func() time.Time { return time.Date(2025, 3, 10, 2, 0, 0, 0, time.UTC) }
+	n.handleEvent(context.Background(), prEvent(models.EventPRCreated, "backend", models.PREventData{PullRequest: pr}))
+
+	// 06:00 UTC - 09:00 по Москве, тихие часы закончились
+	n.now = func() time.Time { return time.Date(2025, 3, 10, 6, 0, 0, 0, time.UTC) }
+	n.handleEvent(context.Background(), prEvent(models.EventPRCreated, "backend", models.PREventData{PullRequest: pr}))
+
+	// u2 получает только сводку, u3 не подписан на назначения, u4 выключил чат,
+	// u5 настроек не сохранял
+	assert.Equal(t, []string{
+		"u5, вас назначили ревьювером PR *Fix* (pr-1) от u5",
+		"@alice, u5, вас назначили ревьювером PR *Fix* (pr-1) от u5",
+	}, recorder.sent("/backend"))
+}
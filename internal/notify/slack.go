@@ -0,0 +1,183 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+var defaultSlackTemplates = map[Kind]string{
+	KindAssigned:   `{{join .Reviewers ", "}}, вас назначили ревьювером PR *{{.Name}}* ({{.ID}}) от {{.Author}}`,
+	KindReassigned: `{{join .Reviewers ", "}}, вам передано ревью PR *{{.Name}}* ({{.ID}}) от {{.Author}}{{if .OldReviewer}} вместо {{.OldReviewer}}{{end}}`,
+	KindOverdue:    `{{join .Reviewers ", "}}, PR *{{.Name}}* ({{.ID}}) от {{.Author}} ждет ревью уже {{.Age}}`,
+}
+
+// SlackConfig - содержимое файла SLACK_CONFIG
+type SlackConfig struct {
+	// Webhooks - URL входящих вебхуков по командам; команды без вебхука не оповещаются
+	Webhooks map[string]string `yaml:"webhooks"`
+	// Mentions - упоминания по user_id, например <@U024BE7LH>. Пользователь без
+	// упоминания указывается как @username.
+	Mentions map[string]string `yaml:"mentions"`
+	// Templates переопределяют шаблоны text/template по видам уведомлений
+	Templates map[Kind]string `yaml:"templates"`
+}
+
+func LoadSlackConfig(path string) (*SlackConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read slack config: %w", err)
+	}
+
+	var cfg SlackConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse slack config: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// slackMessage - данные шаблона. Строки уже экранированы для разметки Slack.
+type slackMessage struct {
+	ID          string
+	Name        string
+	Author      string
+	Reviewers   []string
+	OldReviewer string
+	Age         string
+}
+
+// Slack отправляет уведомления во входящие вебхуки Slack и совместимых мессенджеров
+type Slack struct {
+	webhooks  map[string]string
+	mentions  map[string]string
+	templates map[Kind]*template.Template
+	client    *http.Client
+}
+
+func NewSlack(cfg *SlackConfig, client *http.Client) (*Slack, error) {
+	for team, webhook := range cfg.Webhooks {
+		if u, err := url.Parse(webhook); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return nil, fmt.Errorf("invalid webhook url for team %s", team)
+		}
+	}
+
+	templates := make(map[Kind]*template.Template, len(defaultSlackTemplates))
+	for kind, text := range defaultSlackTemplates {
+		if custom, ok := cfg.Templates[kind]; ok {
+			text = custom
+		}
+		tmpl, err := template.New(string(kind)).Funcs(template.FuncMap{"join": strings.Join}).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s template: %w", kind, err)
+		}
+		templates[kind] = tmpl
+	}
+	for kind := range cfg.Templates {
+		if _, ok := templates[kind]; !ok {
+			return nil, fmt.Errorf("unknown template %s", kind)
+		}
+	}
+
+	return &Slack{webhooks: cfg.Webhooks, mentions: cfg.Mentions, templates: templates, client: client}, nil
+}
+
+func (s *Slack) Name() string {
+	return "slack"
+}
+
+func (s *Slack) Send(ctx context.Context, n *Notification) error {
+	webhook, ok := s.webhooks[n.TeamName]
+	if !ok {
+		return nil
+	}
+	recipients := n.Recipients(models.ChannelChat)
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	message := slackMessage{
+		ID:     escapeSlack(n.PullRequest.ID),
+		Name:   escapeSlack(n.PullRequest.Name),
+		Author: s.mention(n, n.PullRequest.AuthorID),
+		Age:    formatAge(n.Age),
+	}
+	for _, reviewerID := range recipients {
+		message.Reviewers = append(message.Reviewers, s.mention(n, reviewerID))
+	}
+	if n.OldReviewer != "" {
+		message.OldReviewer = s.mention(n, n.OldReviewer)
+	}
+
+	var text strings.Builder
+	if err := s.templates[n.Kind].Execute(&text, message); err != nil {
+		return fmt.Errorf("failed to render %s message: %w", n.Kind, err)
+	}
+
+	return s.post(ctx, webhook, text.String())
+}
+
+func (s *Slack) mention(n *Notification, userID string) string {
+	if mention, ok := s.mentions[userID]; ok {
+		return mention
+	}
+	if user, ok := n.Users[userID]; ok {
+		return "@" + escapeSlack(user.Username)
+	}
+	return escapeSlack(userID)
+}
+
+func (s *Slack) post(ctx context.Context, webhook, text string) error {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		reply, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook responded %d: %s", resp.StatusCode, reply)
+	}
+
+	return nil
+}
+
+// escapeSlack экранирует управляющие символы разметки Slack
+func escapeSlack(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// formatAge округляет возраст PR до часов: "5 ч", "2 д 3 ч"
+func formatAge(age time.Duration) string {
+	hours := int(age.Hours())
+	if hours < 24 {
+		return fmt.Sprintf("%d ч", hours)
+	}
+	if hours%24 == 0 {
+		return fmt.Sprintf("%d д", hours/24)
+	}
+	return fmt.Sprintf("%d д %d ч", hours/24, hours%24)
+}
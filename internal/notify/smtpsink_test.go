@@ -0,0 +1,131 @@
+package notify
+
+import (
+	"bufio"
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type sinkMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+// smtpSink - минимальный SMTP-сервер: принимает письма без TLS и запоминает их.
+// Если задан password, требует AUTH PLAIN.
+type smtpSink struct {
+	addr     string
+	password string
+
+	mu       sync.Mutex
+	messages []sinkMessage
+	auth     []string
+}
+
+func newSMTPSink(t *testing.T, password string) *smtpSink {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	sink := &smtpSink{addr: ln.Addr().String(), password: password}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go sink.serve(conn)
+		}
+	}()
+
+	return sink
+}
+
+func (s *smtpSink) serve(conn net.Conn) {
+	defer conn.Close()
+
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 sink ready")
+
+	var msg sinkMessage
+	authorized := s.password == ""
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			_ = tp.PrintfLine("250-sink\r\n250 AUTH PLAIN")
+		case "AUTH":
+			credentials, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(arg, "PLAIN "))
+			parts := strings.Split(string(credentials), "\x00")
+			s.mu.Lock()
+			s.auth = parts
+			s.mu.Unlock()
+			if len(parts) == 3 && parts[2] == s.password {
+				authorized = true
+				_ = tp.PrintfLine("235 authenticated")
+			} else {
+				_ = tp.PrintfLine("535 invalid credentials")
+			}
+		case "MAIL":
+			if !authorized {
+				_ = tp.PrintfLine("530 authentication required")
+				continue
+			}
+			msg = sinkMessage{from: strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")}
+			_ = tp.PrintfLine("250 ok")
+		case "RCPT":
+			msg.to = append(msg.to, strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>"))
+			_ = tp.PrintfLine("250 ok")
+		case "DATA":
+			_ = tp.PrintfLine("354 go ahead")
+			data, err := readData(tp.Reader.R)
+			if err != nil {
+				return
+			}
+			msg.data = data
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+			_ = tp.PrintfLine("250 queued")
+		case "QUIT":
+			_ = tp.PrintfLine("221 bye")
+			return
+		default:
+			_ = tp.PrintfLine("250 ok")
+		}
+	}
+}
+
+func readData(r *bufio.Reader) (string, error) {
+	var data strings.Builder
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		if line == ".\r\n" {
+			return data.String(), nil
+		}
+		data.WriteString(strings.TrimPrefix(line, "."))
+	}
+}
+
+func (s *smtpSink) received() []sinkMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]sinkMessage(nil), s.messages...)
+}
@@ -0,0 +1,116 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+)
+
+var errWebhookAddr = errors.New("webhook address is not public")
+
+// webhookPayload - тело запроса на личный вебхук пользователя
+type webhookPayload struct {
+	Kind        Kind                `json:"kind"`
+	UserID      string              `json:"user_id"`
+	PullRequest *models.PullRequest `json:"pull_request"`
+	OldReviewer string              `json:"old_reviewer,omitempty"`
+	AgeSeconds  int64               `json:"age_seconds,omitempty"`
+}
+
+// Webhook отправляет уведомления на личные вебхуки из настроек пользователей.
+// Пользователи без webhook_url пропускаются.
+type Webhook struct {
+	client *http.Client
+}
+
+func NewWebhook(client *http.Client) *Webhook {
+	return &Webhook{client: client}
+}
+
+// NewWebhookClient возвращает HTTP-клиент для личных вебхуков. Адрес проверяется при каждом
+// подключении, уже после разрешения имени и в том числе при редиректах, поэтому вебхук
+// не попадет во внутреннюю сеть через имя, указывающее на внутренний адрес.
+// Прокси из окружения не используется: за ним проверка адреса не работает.
+func NewWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: publicAddrOnly}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+func publicAddrOnly(_, address string, _ syscall.RawConn) error {
+	addr, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !models.WebhookAddrAllowed(addr.Addr()) {
+		return fmt.Errorf("%w: %s", errWebhookAddr, address)
+	}
+	return nil
+}
+
+func (w *Webhook) Name() string {
+	return "webhook"
+}
+
+func (w *Webhook) Send(ctx context.Context, n *Notification) error {
+	var errs []error
+	for _, reviewerID := range n.Recipients(models.ChannelWebhook) {
+		webhookURL := n.Preferences[reviewerID].WebhookURL
+		if webhookURL == "" {
+			continue
+		}
+
+		payload := webhookPayload{
+			Kind:        n.Kind,
+			UserID:      reviewerID,
+			PullRequest: n.PullRequest,
+			OldReviewer: n.OldReviewer,
+			AgeSeconds:  int64(n.Age.Seconds()),
+		}
+		if err := w.post(ctx, webhookURL, payload); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", reviewerID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (w *Webhook) post(ctx context.Context, webhookURL string, payload webhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		reply, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook responded %d: %s", resp.StatusCode, reply)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifications(t *testing.T) {
+	var mu sync.Mutex
+	received := make(map[string]webhookPayload)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload webhookPayload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+
+		mu.Lock()
+		defer mu.Unlock()
+		received[r.URL.Path] = payload
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	prefs := fakePreferences{
+		testPreference("u2", func(p *models.NotificationPreferences) { p.WebhookURL = srv.URL + "/bob" }),
+		testPreference("u3", func(p *models.NotificationPreferences) {
+			p.WebhookURL = srv.URL + "/carol"
+			p.Channels = []models.NotificationChannel{models.ChannelChat}
+		}),
+		testPreference("u4", func(p *models.NotificationPreferences) { p.WebhookURL = srv.URL + "/broken" }),
+	}
+
+	n := &Notification{
+		Kind:        KindOverdue,
+		PullRequest: &models.PullRequest{ID: "pr-1", Name: "Fix", AuthorID: "u1"},
+		Reviewers:   []string{"u2", "u3", "u4", "u5"},
+		Age:         49 * time.Hour,
+		At:          time.Now(),
+	}
+	var err error
+	n.Preferences, err = loadPreferences(context.Background(), prefs, n.Reviewers)
+	require.NoError(t, err)
+
+	err = NewWebhook(http.DefaultClient).Send(context.Background(), n)
+	assert.ErrorContains(t, err, "u4: webhook responded 502")
+
+	// u3 выключил канал webhook, у u5 нет адреса
+	require.Len(t, received, 2)
+	assert.Equal(t, webhookPayload{
+		Kind:        KindOverdue,
+		UserID:      "u2",
+		PullRequest: n.PullRequest,
+		AgeSeconds:  49 * 60 * 60,
+	}, received["/bob"])
+}
+
+func TestWebhookClientRejectsInternalAddresses(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { hits++ }))
+	t.Cleanup(srv.Close)
+
+	n := &Notification{
+		Kind:        KindAssigned,
+		PullRequest: &models.PullRequest{ID: "pr-1", Name: "Fix", AuthorID: "u1"},
+		Reviewers:   []string{"u2"},
+		At:          time.Now(),
+	}
+	var err error
+	n.Preferences, err = loadPreferences(context.Background(), fakePreferences{
+		testPreference("u2", func(p *models.NotificationPreferences) { p.WebhookURL = srv.URL + "/bob" }),
+	}, n.Reviewers)
+	require.NoError(t, err)
+
+	// Сервер слушает на loopback, куда личные вебхуки не отправляются
+	err = NewWebhook(NewWebhookClient(time.Second)).Send(context.Background(), n)
+	assert.ErrorIs(t, err, errWebhookAddr)
+	assert.Zero(t, hits)
+}
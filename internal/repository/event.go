@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type eventRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewEventRepository(db *pgxpool.Pool) EventRepository {
+	return &eventRepository{db: db}
+}
+
+// Append записывает событие в журнал и заполняет его ID и CreatedAt
+func (r *eventRepository) Append(ctx context.Context, event *models.Event) error {
+	userIDsJSON, err := json.Marshal(event.UserIDs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event users: %w", err)
+	}
+
+	query := `
+		INSERT INTO events (type, team_name, user_ids, data)
+		VALUES ($1, NULLIF($2, ''), $3, $4)
+		RETURNING id, created_at
+	`
+
+	err = r.db.QueryRow(ctx, query, string(event.Type), event.TeamName, userIDsJSON, []byte(event.Data)).
+		Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to append event: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBefore удаляет события, записанные раньше before, и возвращает их число
+func (r *eventRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM events WHERE created_at < $1`
+
+	tag, err := r.db.Exec(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old events: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// ListAfter возвращает до limit событий с ID больше afterID в порядке записи
+func (r *eventRepository) ListAfter(ctx context.Context, afterID int64, filter models.EventFilter, limit int) ([]*models.Event, error) {
+	var where whereBuilder
+	where.add("id > " + where.arg(afterID))
+	if filter.TeamName != "" {
+		where.add("team_name = " + where.arg(filter.TeamName))
+	}
+	if filter.UserID != "" {
+		userJSON, err := json.Marshal([]string{filter.UserID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal user filter: %w", err)
+		}
+		where.add("user_ids @> " + where.arg(userJSON))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, type, COALESCE(team_name, ''), user_ids, data, created_at
+		FROM events
+		%s
+		ORDER BY id
+		LIMIT %s
+	`, where.sql(), where.arg(limit))
+
+	rows, err := r.db.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*models.Event
+	for rows.Next() {
+		var event models.Event
+		var userIDsJSON, data []byte
+		if err := rows.Scan(&event.ID, &event.Type, &event.TeamName, &userIDsJSON, &data, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		if err := json.Unmarshal(userIDsJSON, &event.UserIDs); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event users: %w", err)
+		}
+		event.Data = data
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating events: %w", err)
+	}
+
+	return events, nil
+}
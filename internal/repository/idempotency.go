@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// reserveAttempts ограничивает повторы Reserve, если чужой ключ освобождается
+// между попыткой занять его и чтением
+const reserveAttempts = 3
+
+type idempotencyRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewIdempotencyRepository(db *pgxpool.Pool) IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+// Reserve занимает ключ под новый запрос и возвращает true. Если ключ уже занят и
+// не истек, возвращает его запись и false; истекший ключ занимается заново.
+func (r *idempotencyRepository) Reserve(ctx context.Context, key, requestHash string, expiresAt time.Time) (*models.IdempotencyRecord, bool, error) {
+	insert := `
+		INSERT INTO idempotency_keys (key, request_hash, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (key) DO UPDATE SET
+			request_hash = EXCLUDED.request_hash,
+			status_code = NULL,
+			response_header = NULL,
+			response_body = NULL,
+			created_at = CURRENT_TIMESTAMP,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= CURRENT_TIMESTAMP
+		RETURNING key
+	`
+
+	query := `
+		SELECT key, request_hash, COALESCE(status_code, 0), COALESCE(response_header, '{}'),
+			COALESCE(response_body, ''), expires_at
+		FROM idempotency_keys
+		WHERE key = $1
+	`
+
+	for range reserveAttempts {
+		var reserved string
+		err := r.db.QueryRow(ctx, insert, key, requestHash, expiresAt).Scan(&reserved)
+		if err == nil {
+			return &models.IdempotencyRecord{Key: key, RequestHash: requestHash, ExpiresAt: expiresAt}, true, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+
+		var record models.IdempotencyRecord
+		var headerJSON []byte
+		err = r.db.QueryRow(ctx, query, key).Scan(&record.Key, &record.RequestHash, &record.StatusCode,
+			&headerJSON, &record.Body, &record.ExpiresAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get idempotency key: %w", err)
+		}
+
+		if err := json.Unmarshal(headerJSON, &record.Header); err != nil {
+			return nil, false, fmt.Errorf("failed to unmarshal response header: %w", err)
+		}
+		return &record, false, nil
+	}
+
+	return nil, false, fmt.Errorf("failed to reserve idempotency key %s: released concurrently", key)
+}
+
+// Complete сохраняет ответ на запрос, занявший ключ, и продлевает ключ до record.ExpiresAt
+func (r *idempotencyRepository) Complete(ctx context.Context, record *models.IdempotencyRecord) error {
+	headerJSON, err := json.Marshal(record.Header)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response header: %w", err)
+	}
+
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = $2, response_header = $3, response_body = $4, expires_at = $6
+		WHERE key = $1 AND request_hash = $5
+	`
+
+	_, err = r.db.Exec(ctx, query, record.Key, record.StatusCode, headerJSON, record.Body, record.RequestHash, record.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to save idempotent response: %w", err)
+	}
+
+	return nil
+}
+
+// Release освобождает ключ, ответ на который не сохранен, чтобы запрос можно было повторить
+func (r *idempotencyRepository) Release(ctx context.Context, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE key = $1 AND status_code IS NULL`
+
+	if _, err := r.db.Exec(ctx, query, key); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE expires_at <= CURRENT_TIMESTAMP`
+
+	tag, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -29,15 +29,16 @@ func (r *pullRequestRepository) Create(ctx context.Context, pr *models.PullReque
 	query := `
 		INSERT INTO pull_requests (id, name, author_id, status, assigned_reviewers)
 		VALUES ($1, $2, $3, $4, $5)
+		RETURNING version
 	`
 
-	_, err = r.db.Exec(ctx, query,
+	err = r.db.QueryRow(ctx, query,
 		pr.ID,
 		pr.Name,
 		pr.AuthorID,
 		pr.Status,
 		reviewersJSON,
-	)
+	).Scan(&pr.Version)
 
 	if err != nil {
 		if err.Error() == "ERROR: duplicate key value violates unique constraint \"pull_requests_pkey\" (SQLSTATE 23505)" {
@@ -51,7 +52,7 @@ func (r *pullRequestRepository) Create(ctx context.Context, pr *models.PullReque
 
 func (r *pullRequestRepository) GetByID(ctx context.Context, prID string) (*models.PullRequest, error) {
 	query := `
-		SELECT id, name, author_id, status, assigned_reviewers, created_at, merged_at, updated_at
+		SELECT id, name, author_id, status, assigned_reviewers, created_at, merged_at, updated_at, version
 		FROM pull_requests
 		WHERE id = $1
 	`
@@ -68,6 +69,7 @@ func (r *pullRequestRepository) GetByID(ctx context.Context, prID string) (*mode
 		&pr.CreatedAt,
 		&pr.MergedAt,
 		&pr.UpdatedAt,
+		&pr.Version,
 	)
 
 	if err != nil {
@@ -84,9 +86,21 @@ func (r *pullRequestRepository) GetByID(ctx context.Context, prID string) (*mode
 	return &pr, nil
 }
 
+// GetByIDs возвращает найденные PR из списка, отсутствующие пропускаются
+func (r *pullRequestRepository) GetByIDs(ctx context.Context, prIDs []string) ([]*models.PullRequest, error) {
+	query := `
+		SELECT id, name, author_id, status, assigned_reviewers, created_at, merged_at, updated_at, version
+		FROM pull_requests
+		WHERE id = ANY($1)
+		ORDER BY id
+	`
+
+	return r.queryPullRequests(ctx, query, prIDs)
+}
+
 func (r *pullRequestRepository) GetByAuthor(ctx context.Context, authorID string) ([]*models.PullRequest, error) {
 	query := `
-		SELECT id, name, author_id, status, assigned_reviewers, created_at, merged_at, updated_at
+		SELECT id, name, author_id, status, assigned_reviewers, created_at, merged_at, updated_at, version
 		FROM pull_requests
 		WHERE author_id = $1
 		ORDER BY created_at DESC
@@ -136,61 +150,112 @@ func (r *pullRequestRepository) GetByReviewer(ctx context.Context, reviewerID st
 	return prs, nil
 }
 
+// Update сохраняет PR, только если его версия в базе совпадает с pr.Version, и
+// увеличивает версию. Если PR успели изменить, возвращает ErrVersionConflict.
 func (r *pullRequestRepository) Update(ctx context.Context, pr *models.PullRequest) error {
 	reviewersJSON, err := json.Marshal(pr.AssignedReviewers)
 	if err != nil {
 		return fmt.Errorf("failed to marshal reviewers: %w", err)
 	}
 
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
 		UPDATE pull_requests
-		SET name = $2, status = $3, assigned_reviewers = $4, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $1
+		SET name = $2, status = $3, assigned_reviewers = $4, updated_at = CURRENT_TIMESTAMP,
+			version = version + 1
+		WHERE id = $1 AND version = $5
+		RETURNING version, updated_at
 	`
 
-	result, err := r.db.Exec(ctx, query,
+	err = tx.QueryRow(ctx, query,
 		pr.ID,
 		pr.Name,
 		pr.Status,
 		reviewersJSON,
-	)
+		pr.Version,
+	).Scan(&pr.Version, &pr.UpdatedAt)
 
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return r.versionError(ctx, pr.ID)
+		}
 		return fmt.Errorf("failed to update pull request: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return models.ErrNotFound
+	if err := deleteStaleReviews(ctx, tx, pr.ID, pr.AssignedReviewers); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
 }
 
-func (r *pullRequestRepository) Merge(ctx context.Context, prID string, mergedAt time.Time) error {
+// deleteStaleReviews удаляет ревью тех, кто больше не назначен на PR: иначе снятый
+// ревьювер продолжал бы числиться в очереди с прежним состоянием ревью
+func deleteStaleReviews(ctx context.Context, tx pgx.Tx, prID string, reviewers []string) error {
+	query := `
+		DELETE FROM pr_reviews
+		WHERE pull_request_id = $1 AND NOT (reviewer_id = ANY($2))
+	`
+
+	if reviewers == nil {
+		reviewers = []string{}
+	}
+	if _, err := tx.Exec(ctx, query, prID, reviewers); err != nil {
+		return fmt.Errorf("failed to delete stale reviews of %s: %w", prID, err)
+	}
+	return nil
+}
+
+// Merge переводит PR в MERGED. Если version не 0, PR должен быть этой версии,
+// иначе возвращается ErrVersionConflict. Повторный merge не меняет PR. merged
+// истинно только у вызова, который сам перевел PR в MERGED: из параллельных
+// merge одного PR таким будет ровно один.
+func (r *pullRequestRepository) Merge(ctx context.Context, prID string, version int, mergedAt time.Time) (bool, error) {
 	query := `
 		UPDATE pull_requests
-		SET status = 'MERGED', merged_at = $2, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $1 AND status != 'MERGED'
+		SET status = 'MERGED', merged_at = $2, updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id = $1 AND status != 'MERGED' AND ($3 = 0 OR version = $3)
 	`
 
-	result, err := r.db.Exec(ctx, query, prID, mergedAt)
+	result, err := r.db.Exec(ctx, query, prID, mergedAt, version)
 	if err != nil {
-		return fmt.Errorf("failed to merge pull request: %w", err)
+		return false, fmt.Errorf("failed to merge pull request: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-
-		exists, err := r.Exists(ctx, prID)
+		current, err := r.GetByID(ctx, prID)
 		if err != nil {
-			return err
+			return false, err
 		}
-		if !exists {
-			return models.ErrNotFound
+		if version != 0 && current.Version != version {
+			return false, models.ErrVersionConflict
 		}
+		return false, nil
+	}
 
+	return true, nil
+}
+
+// versionError объясняет, почему условное изменение PR не затронуло ни одной строки
+func (r *pullRequestRepository) versionError(ctx context.Context, prID string) error {
+	exists, err := r.Exists(ctx, prID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return models.ErrNotFound
 	}
 
-	return nil
+	return models.ErrVersionConflict
 }
 
 func (r *pullRequestRepository) Exists(ctx context.Context, prID string) (bool, error) {
@@ -209,7 +274,7 @@ func (r *pullRequestRepository) Exists(ctx context.Context, prID string) (bool,
 
 func (r *pullRequestRepository) GetOpenPRsWithReviewer(ctx context.Context, reviewerID string) ([]*models.PullRequest, error) {
 	query := `
-		SELECT id, name, author_id, status, assigned_reviewers, created_at, merged_at, updated_at
+		SELECT id, name, author_id, status, assigned_reviewers, created_at, merged_at, updated_at, version
 		FROM pull_requests
 		WHERE status = 'OPEN' AND assigned_reviewers @> $1
 		ORDER BY created_at DESC
@@ -223,8 +288,376 @@ func (r *pullRequestRepository) GetOpenPRsWithReviewer(ctx context.Context, revi
 	return r.queryPullRequests(ctx, query, reviewerJSON)
 }
 
+func (r *pullRequestRepository) GetOpen(ctx context.Context) ([]*models.PullRequest, error) {
+	query := `
+		SELECT id, name, author_id, status, assigned_reviewers, created_at, merged_at, updated_at, version
+		FROM pull_requests
+		WHERE status = 'OPEN'
+		ORDER BY created_at
+	`
+
+	return r.queryPullRequests(ctx, query)
+}
+
+// ClaimOverdue отмечает открытые PR, созданные не позже createdBefore и еще не
+// отмеченные, и возвращает их. Каждый PR возвращается одному вызову, в том числе
+// при одновременных вызовах с разных реплик.
+func (r *pullRequestRepository) ClaimOverdue(ctx context.Context, createdBefore time.Time) ([]*models.PullRequest, error) {
+	query := `
+		WITH claimed AS (
+			INSERT INTO overdue_notifications (pull_request_id)
+			SELECT id FROM pull_requests
+			WHERE status = 'OPEN' AND created_at <= $1
+			ON CONFLICT (pull_request_id) DO NOTHING
+			RETURNING pull_request_id
+		)
+		SELECT pr.id, pr.name, pr.author_id, pr.status, pr.assigned_reviewers, pr.created_at, pr.merged_at, pr.updated_at, pr.version
+		FROM pull_requests pr
+		JOIN claimed c ON c.pull_request_id = pr.id
+		ORDER BY pr.created_at
+	`
+
+	return r.queryPullRequests(ctx, query, createdBefore)
+}
+
+// List возвращает страницу PR, отсортированных по (created_at, id)
+func (r *pullRequestRepository) List(ctx context.Context, filter models.PRFilter, page models.PageRequest) (*models.PRPage, error) {
+	page.Normalize()
+
+	var where whereBuilder
+	if filter.AuthorID != "" {
+		where.add("pr.author_id = " + where.arg(filter.AuthorID))
+	}
+	if filter.ReviewerID != "" {
+		reviewerJSON, err := json.Marshal([]string{filter.ReviewerID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal reviewer filter: %w", err)
+		}
+		where.add("pr.assigned_reviewers @> " + where.arg(reviewerJSON))
+	}
+	if filter.TeamName != "" {
+		where.add("u.team_name = " + where.arg(filter.TeamName))
+	}
+	if filter.Status != "" {
+		where.add("pr.status = " + where.arg(string(filter.Status)))
+	}
+	if filter.CreatedFrom != nil {
+		where.add("pr.created_at >= " + where.arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		where.add("pr.created_at < " + where.arg(*filter.CreatedTo))
+	}
+
+	direction, comparison := "DESC", "<"
+	if page.Order == models.SortAsc {
+		direction, comparison = "ASC", ">"
+	}
+
+	if page.Cursor != nil {
+		createdAt, err := page.Cursor.Time()
+		if err != nil {
+			return nil, err
+		}
+		where.add(fmt.Sprintf("(pr.created_at, pr.id) %s (%s, %s)", comparison, where.arg(createdAt), where.arg(page.Cursor.ID)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT pr.id, pr.name, pr.author_id, pr.status, pr.assigned_reviewers, pr.created_at, pr.merged_at, pr.updated_at, pr.version
+		FROM pull_requests pr
+		LEFT JOIN users u ON u.id = pr.author_id
+		%s
+		ORDER BY pr.created_at %s, pr.id %s
+		LIMIT %s
+	`, where.sql(), direction, direction, where.arg(page.Limit+1))
+
+	prs, err := r.queryPullRequests(ctx, query, where.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.PRPage{PullRequests: prs}
+	if len(prs) > page.Limit {
+		result.PullRequests = prs[:page.Limit]
+		last := result.PullRequests[page.Limit-1]
+		result.NextCursor = models.TimeCursor(last.CreatedAt, last.ID).Encode()
+	}
+	if result.PullRequests == nil {
+		result.PullRequests = []*models.PullRequest{}
+	}
+
+	return result, nil
+}
+
+// GetReviews возвращает страницу PR, где пользователь назначен ревьювером, вместе с его
+// состоянием ревью. Порядок и курсор те же, что у List. Пустой status - любой статус.
+// Смерженные PR без ревью не попадают ни в страницу, ни в счетчики. Счетчики считаются
+// по всем PR ревьювера в той же транзакции только для чтения, что и страница.
+func (r *pullRequestRepository) GetReviews(
+	ctx context.Context,
+	reviewerID string,
+	status models.PullRequestStatus,
+	page models.PageRequest,
+) (*models.ReviewPage, error) {
+	page.Normalize()
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := reviewPage(ctx, tx, reviewerID, status, page)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := reviewCounts(ctx, tx, reviewerID, status)
+	if err != nil {
+		return nil, err
+	}
+	result.Counts = *counts
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return result, nil
+}
+
+// reviewFilter - общие условия выборки очереди ревьювера: он назначен на PR, PR нужного
+// статуса и это не смерженный PR, который ревьювер так и не посмотрел
+func reviewFilter(where *whereBuilder, reviewerID string, status models.PullRequestStatus) error {
+	reviewerJSON, err := json.Marshal([]string{reviewerID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal reviewer filter: %w", err)
+	}
+
+	where.add("pr.assigned_reviewers @> " + where.arg(reviewerJSON))
+	where.add("NOT (pr.status = 'MERGED' AND rv.state IS NULL)")
+	if status != "" {
+		where.add("pr.status = " + where.arg(string(status)))
+	}
+	return nil
+}
+
+func reviewPage(
+	ctx context.Context,
+	q querier,
+	reviewerID string,
+	status models.PullRequestStatus,
+	page models.PageRequest,
+) (*models.ReviewPage, error) {
+	var where whereBuilder
+	pending := where.arg(string(models.ReviewPending))
+	reviewer := where.arg(reviewerID)
+	if err := reviewFilter(&where, reviewerID, status); err != nil {
+		return nil, err
+	}
+
+	direction, comparison := "DESC", "<"
+	if page.Order == models.SortAsc {
+		direction, comparison = "ASC", ">"
+	}
+
+	if page.Cursor != nil {
+		createdAt, err := page.Cursor.Time()
+		if err != nil {
+			return nil, err
+		}
+		where.add(fmt.Sprintf("(pr.created_at, pr.id) %s (%s, %s)", comparison, where.arg(createdAt), where.arg(page.Cursor.ID)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT pr.id, pr.name, pr.author_id, pr.status, COALESCE(rv.state, %s), pr.created_at
+		FROM pull_requests pr
+		LEFT JOIN pr_reviews rv ON rv.pull_request_id = pr.id AND rv.reviewer_id = %s
+		%s
+		ORDER BY pr.created_at %s, pr.id %s
+		LIMIT %s
+	`, pending, reviewer, where.sql(), direction, direction, where.arg(page.Limit+1))
+
+	rows, err := q.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		prs       []*models.ReviewPR
+		createdAt []time.Time
+	)
+	for rows.Next() {
+		var pr models.ReviewPR
+		var created time.Time
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.ReviewState, &created); err != nil {
+			return nil, fmt.Errorf("failed to scan review: %w", err)
+		}
+		prs = append(prs, &pr)
+		createdAt = append(createdAt, created)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reviews: %w", err)
+	}
+
+	result := &models.ReviewPage{PullRequests: prs}
+	if len(prs) > page.Limit {
+		result.PullRequests = prs[:page.Limit]
+		last := page.Limit - 1
+		result.NextCursor = models.TimeCursor(createdAt[last], prs[last].ID).Encode()
+	}
+	if result.PullRequests == nil {
+		result.PullRequests = []*models.ReviewPR{}
+	}
+
+	return result, nil
+}
+
+func reviewCounts(ctx context.Context, q querier, reviewerID string, status models.PullRequestStatus) (*models.ReviewCounts, error) {
+	var where whereBuilder
+	reviewer := where.arg(reviewerID)
+	if err := reviewFilter(&where, reviewerID, status); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			COUNT(*) FILTER (WHERE rv.state IS NULL),
+			COUNT(*) FILTER (WHERE rv.state = 'APPROVED'),
+			COUNT(*) FILTER (WHERE rv.state = 'CHANGES_REQUESTED')
+		FROM pull_requests pr
+		LEFT JOIN pr_reviews rv ON rv.pull_request_id = pr.id AND rv.reviewer_id = %s
+		%s
+	`, reviewer, where.sql())
+
+	var counts models.ReviewCounts
+	err := q.QueryRow(ctx, query, where.args...).Scan(&counts.NeedsReview, &counts.Approved, &counts.ChangesRequested)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reviews: %w", err)
+	}
+	counts.Total = counts.NeedsReview + counts.Approved + counts.ChangesRequested
+
+	return &counts, nil
+}
+
+// GetReviewsByReviewers - пакетный вариант GetReviews без постраничной выдачи: очереди
+// всех ревьюверов из списка одним запросом, сначала открытые PR, затем от новых к старым.
+func (r *pullRequestRepository) GetReviewsByReviewers(
+	ctx context.Context,
+	reviewerIDs []string,
+	status models.PullRequestStatus,
+) (map[string][]*models.ReviewPR, error) {
+	var where whereBuilder
+	where.add("reviewer.id = ANY(" + where.arg(reviewerIDs) + ")")
+	if status != "" {
+		where.add("pr.status = " + where.arg(string(status)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT reviewer.id, pr.id, pr.name, pr.author_id, pr.status, COALESCE(rv.state, %s)
+		FROM pull_requests pr
+		CROSS JOIN LATERAL jsonb_array_elements_text(pr.assigned_reviewers) AS reviewer(id)
+		LEFT JOIN pr_reviews rv ON rv.pull_request_id = pr.id AND rv.reviewer_id = reviewer.id
+		%s
+		ORDER BY reviewer.id, pr.status = 'OPEN' DESC, pr.created_at DESC, pr.id
+	`, where.arg(string(models.ReviewPending)), where.sql())
+
+	rows, err := r.db.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := make(map[string][]*models.ReviewPR, len(reviewerIDs))
+	for rows.Next() {
+		var reviewerID string
+		var pr models.ReviewPR
+		if err := rows.Scan(&reviewerID, &pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.ReviewState); err != nil {
+			return nil, fmt.Errorf("failed to scan review: %w", err)
+		}
+		reviews[reviewerID] = append(reviews[reviewerID], &pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reviews: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// SaveReview записывает ревью; повторное ревью того же ревьювера заменяет предыдущее
+func (r *pullRequestRepository) SaveReview(ctx context.Context, review *models.PRReview) error {
+	query := `
+		INSERT INTO pr_reviews (pull_request_id, reviewer_id, state)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (pull_request_id, reviewer_id) DO UPDATE SET state = EXCLUDED.state
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, review.PullRequestID, review.ReviewerID, string(review.State)).Scan(&review.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save review: %w", err)
+	}
+
+	return nil
+}
+
+// Search ищет PR по названию полнотекстовым поиском и сортирует по релевантности.
+// Выражение to_tsvector совпадает с индексом idx_pull_requests_name_fts.
+func (r *pullRequestRepository) Search(ctx context.Context, search models.PRSearch) ([]*models.PullRequest, error) {
+	limit := search.Limit
+	if limit <= 0 {
+		limit = models.DefaultPageLimit
+	}
+	if limit > models.MaxPageLimit {
+		limit = models.MaxPageLimit
+	}
+
+	var where whereBuilder
+	tsquery := "websearch_to_tsquery('simple', " + where.arg(search.Query) + ")"
+	where.add("to_tsvector('simple', pr.name) @@ " + tsquery)
+
+	if search.Filter.ReviewerID != "" {
+		reviewerJSON, err := json.Marshal([]string{search.Filter.ReviewerID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal reviewer filter: %w", err)
+		}
+		where.add("pr.assigned_reviewers @> " + where.arg(reviewerJSON))
+	}
+	if search.Filter.TeamName != "" {
+		where.add("u.team_name = " + where.arg(search.Filter.TeamName))
+	}
+	if search.Filter.Status != "" {
+		where.add("pr.status = " + where.arg(string(search.Filter.Status)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT pr.id, pr.name, pr.author_id, pr.status, pr.assigned_reviewers, pr.created_at, pr.merged_at, pr.updated_at, pr.version
+		FROM pull_requests pr
+		LEFT JOIN users u ON u.id = pr.author_id
+		%s
+		ORDER BY ts_rank(to_tsvector('simple', pr.name), %s) DESC, pr.created_at DESC, pr.id
+		LIMIT %s
+	`, where.sql(), tsquery, where.arg(limit))
+
+	prs, err := r.queryPullRequests(ctx, query, where.args...)
+	if err != nil {
+		return nil, err
+	}
+	if prs == nil {
+		prs = []*models.PullRequest{}
+	}
+
+	return prs, nil
+}
+
 func (r *pullRequestRepository) queryPullRequests(ctx context.Context, query string, args ...interface{}) ([]*models.PullRequest, error) {
-	rows, err := r.db.Query(ctx, query, args...)
+	return selectPullRequests(ctx, r.db, query, args...)
+}
+
+// selectPullRequests выполняет запрос в пуле или транзакции и читает PR в порядке выборки
+func selectPullRequests(ctx context.Context, q querier, query string, args ...interface{}) ([]*models.PullRequest, error) {
+	rows, err := q.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to failed to query pull request: %w", err)
 	}
@@ -244,6 +677,7 @@ func (r *pullRequestRepository) queryPullRequests(ctx context.Context, query str
 			&pr.CreatedAt,
 			&pr.MergedAt,
 			&pr.UpdatedAt,
+			&pr.Version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan pull request: %w", err)
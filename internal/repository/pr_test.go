@@ -77,7 +77,8 @@ func runMigrations(ctx context.Context, db *pgxpool.Pool) error {
 			assigned_reviewers JSONB NOT NULL,
 			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
 			merged_at TIMESTAMP WITH TIME ZONE,
-			updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+			updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			version INTEGER NOT NULL DEFAULT 1
 		);
 
 		CREATE TABLE IF NOT EXISTS users (
@@ -95,6 +96,19 @@ func runMigrations(ctx context.Context, db *pgxpool.Pool) error {
 			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 		);
+
+		CREATE TABLE IF NOT EXISTS pr_reviews (
+			pull_request_id VARCHAR(255) NOT NULL,
+			reviewer_id VARCHAR(255) NOT NULL,
+			state VARCHAR(50) NOT NULL,
+			updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (pull_request_id, reviewer_id)
+		);
+
+		CREATE TABLE IF NOT EXISTS overdue_notifications (
+			pull_request_id VARCHAR(255) PRIMARY KEY REFERENCES pull_requests(id) ON DELETE CASCADE,
+			notified_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
 	`)
 	return err
 }
@@ -368,6 +382,7 @@ func TestPullRequestRepository_Update(t *testing.T) {
 				AuthorID:          "user-1",
 				Status:            models.StatusMerged,
 				AssignedReviewers: []string{"user-4", "user-5"},
+				Version:           1,
 			},
 			wantErr: false,
 			prepare: func(ctx context.Context, db *pgxpool.Pool) {
@@ -382,14 +397,16 @@ func TestPullRequestRepository_Update(t *testing.T) {
 				var (
 					name, status  string
 					reviewersJSON []byte
+					version       int
 				)
 				err := db.QueryRow(ctx,
-					"SELECT name, status, assigned_reviewers FROM pull_requests WHERE id = $1",
+					"SELECT name, status, assigned_reviewers, version FROM pull_requests WHERE id = $1",
 					"pr-1",
-				).Scan(&name, &status, &reviewersJSON)
+				).Scan(&name, &status, &reviewersJSON, &version)
 				require.NoError(t, err)
 				require.Equal(t, "Updated PR", name)
 				require.Equal(t, "MERGED", status)
+				require.Equal(t, 2, version)
 
 				var reviewers []string
 				err = json.Unmarshal(reviewersJSON, &reviewers)
@@ -397,6 +414,69 @@ func TestPullRequestRepository_Update(t *testing.T) {
 				require.Equal(t, []string{"user-4", "user-5"}, reviewers)
 			},
 		},
+		{
+			name: "Stale version",
+			input: &models.PullRequest{
+				ID:                "pr-1",
+				Name:              "Updated PR",
+				AuthorID:          "user-1",
+				Status:            models.StatusOpen,
+				AssignedReviewers: []string{"user-4"},
+				Version:           1,
+			},
+			wantErr: true,
+			prepare: func(ctx context.Context, db *pgxpool.Pool) {
+				reviewersJSON, _ := json.Marshal([]string{"user-2", "user-3"})
+				_, err := db.Exec(ctx,
+					"INSERT INTO pull_requests (id, name, author_id, status, assigned_reviewers, version) VALUES ($1, $2, $3, $4, $5, $6)",
+					"pr-1", "Original PR", "user-1", "OPEN", reviewersJSON, 2,
+				)
+				require.NoError(t, err)
+			},
+			assert: func(ctx context.Context, t *testing.T, db *pgxpool.Pool) {
+				var name string
+				err := db.QueryRow(ctx, "SELECT name FROM pull_requests WHERE id = $1", "pr-1").Scan(&name)
+				require.NoError(t, err)
+				require.Equal(t, "Original PR", name)
+			},
+		},
+		{
+			name: "Removed reviewer loses review",
+			input: &models.PullRequest{
+				ID:                "pr-1",
+				Name:              "Original PR",
+				AuthorID:          "user-1",
+				Status:            models.StatusOpen,
+				AssignedReviewers: []string{"user-2", "user-4"},
+				Version:           1,
+			},
+			wantErr: false,
+			prepare: func(ctx context.Context, db *pgxpool.Pool) {
+				reviewersJSON, _ := json.Marshal([]string{"user-2", "user-3"})
+				_, err := db.Exec(ctx,
+					"INSERT INTO pull_requests (id, name, author_id, status, assigned_reviewers) VALUES ($1, $2, $3, $4, $5)",
+					"pr-1", "Original PR", "user-1", "OPEN", reviewersJSON,
+				)
+				require.NoError(t, err)
+				_, err = db.Exec(ctx,
+					"INSERT INTO pr_reviews (pull_request_id, reviewer_id, state) VALUES ('pr-1', 'user-2', 'APPROVED'), ('pr-1', 'user-3', 'CHANGES_REQUESTED')",
+				)
+				require.NoError(t, err)
+			},
+			assert: func(ctx context.Context, t *testing.T, db *pgxpool.Pool) {
+				var reviewers []string
+				rows, err := db.Query(ctx, "SELECT reviewer_id FROM pr_reviews WHERE pull_request_id = 'pr-1'")
+				require.NoError(t, err)
+				defer rows.Close()
+				for rows.Next() {
+					var id string
+					require.NoError(t, rows.Scan(&id))
+					reviewers = append(reviewers, id)
+				}
+				require.NoError(t, rows.Err())
+				require.Equal(t, []string{"user-2"}, reviewers)
+			},
+		},
 		{
 			name: "Not found",
 			input: &models.PullRequest{
@@ -405,6 +485,7 @@ func TestPullRequestRepository_Update(t *testing.T) {
 				AuthorID:          "user-1",
 				Status:            models.StatusOpen,
 				AssignedReviewers: []string{"user-2"},
+				Version:           1,
 			},
 			wantErr: true,
 			prepare: nil,
@@ -444,7 +525,9 @@ func TestPullRequestRepository_Merge(t *testing.T) {
 	tests := []struct {
 		name     string
 		prID     string
+		version  int
 		mergedAt time.Time
+		merged   bool
 		wantErr  bool
 		prepare  func(ctx context.Context, db *pgxpool.Pool)
 		assert   func(ctx context.Context, t *testing.T, db *pgxpool.Pool)
@@ -453,6 +536,7 @@ func TestPullRequestRepository_Merge(t *testing.T) {
 			name:     "Happy path",
 			prID:     "pr-1",
 			mergedAt: time.Now(),
+			merged:   true,
 			wantErr:  false,
 			prepare: func(ctx context.Context, db *pgxpool.Pool) {
 				reviewersJSON, _ := json.Marshal([]string{"user-2"})
@@ -505,6 +589,27 @@ func TestPullRequestRepository_Merge(t *testing.T) {
 				require.True(t, mergedAt.Before(time.Now().Add(-30*time.Minute)))
 			},
 		},
+		{
+			name:     "Stale version",
+			prID:     "pr-1",
+			version:  1,
+			mergedAt: time.Now(),
+			wantErr:  true,
+			prepare: func(ctx context.Context, db *pgxpool.Pool) {
+				reviewersJSON, _ := json.Marshal([]string{"user-2"})
+				_, err := db.Exec(ctx,
+					"INSERT INTO pull_requests (id, name, author_id, status, assigned_reviewers, version) VALUES ($1, $2, $3, $4, $5, $6)",
+					"pr-1", "Test PR", "user-1", "OPEN", reviewersJSON, 2,
+				)
+				require.NoError(t, err)
+			},
+			assert: func(ctx context.Context, t *testing.T, db *pgxpool.Pool) {
+				var status string
+				err := db.QueryRow(ctx, "SELECT status FROM pull_requests WHERE id = $1", "pr-1").Scan(&status)
+				require.NoError(t, err)
+				require.Equal(t, "OPEN", status)
+			},
+		},
 		{
 			name:     "Not found",
 			prID:     "pr-999",
@@ -528,13 +633,14 @@ func TestPullRequestRepository_Merge(t *testing.T) {
 			}
 
 			repo := NewPullRequestRepository(testDB)
-			err := repo.Merge(ctx, tt.prID, tt.mergedAt)
+			merged, err := repo.Merge(ctx, tt.prID, tt.version, tt.mergedAt)
 
 			if tt.wantErr {
 				require.Error(t, err)
 			} else {
 				require.NoError(t, err)
 			}
+			require.Equal(t, tt.merged, merged)
 
 			if tt.assert != nil {
 				tt.assert(ctx, t, testDB)
@@ -543,6 +649,92 @@ func TestPullRequestRepository_Merge(t *testing.T) {
 	}
 }
 
+// TestPullRequestRepository_ConcurrentUpdate проверяет, что из параллельных изменений
+// одной версии PR применяется ровно одно, а остальные получают ErrVersionConflict
+func TestPullRequestRepository_ConcurrentUpdate(t *testing.T) {
+	ctx := context.Background()
+	testDB, cleanup := SetupTestContainer(t)
+	defer cleanup()
+
+	repo := NewPullRequestRepository(testDB)
+	require.NoError(t, repo.Create(ctx, &models.PullRequest{
+		ID:                "pr-1",
+		Name:              "Test PR",
+		AuthorID:          "user-1",
+		Status:            models.StatusOpen,
+		AssignedReviewers: []string{"user-2"},
+	}))
+
+	read, err := repo.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, 1, read.Version)
+
+	const writers = 10
+	errs := make(chan error, writers)
+	for i := range writers {
+		go func() {
+			pr := *read
+			pr.AssignedReviewers = []string{fmt.Sprintf("user-%d", 10+i)}
+			errs <- repo.Update(ctx, &pr)
+		}()
+	}
+
+	var applied int
+	for range writers {
+		err := <-errs
+		if err == nil {
+			applied++
+			continue
+		}
+		require.ErrorIs(t, err, models.ErrVersionConflict)
+	}
+	require.Equal(t, 1, applied)
+
+	updated, err := repo.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, 2, updated.Version)
+	require.NotEqual(t, []string{"user-2"}, updated.AssignedReviewers)
+
+	// Синхронизация состава и обычное изменение PR, составленные по одной версии:
+	// применяется только одно, синхронизация при конфликте отменяется целиком
+	rosterRepo := NewRosterRepository(testDB)
+	reconciled := make(chan error, 1)
+	go func() {
+		_, err := rosterRepo.Sync(ctx, false, func(*models.Roster, []*models.PullRequest) (*models.ReconcilePlan, error) {
+			return &models.ReconcilePlan{
+				Reassignments: []*models.PRReviewerChange{{
+					PullRequestID:     "pr-1",
+					Version:           updated.Version,
+					AssignedReviewers: []string{"user-99"},
+				}},
+			}, nil
+		})
+		reconciled <- err
+	}()
+	pr := *updated
+	pr.AssignedReviewers = []string{"user-98"}
+	updateErr := repo.Update(ctx, &pr)
+	reconcileErr := <-reconciled
+
+	if updateErr == nil {
+		require.ErrorIs(t, reconcileErr, models.ErrVersionConflict)
+	} else {
+		require.ErrorIs(t, updateErr, models.ErrVersionConflict)
+		require.NoError(t, reconcileErr)
+	}
+
+	updated, err = repo.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, 3, updated.Version)
+
+	// Merge по устаревшей версии тоже отклоняется
+	_, err = repo.Merge(ctx, "pr-1", read.Version, time.Now())
+	require.ErrorIs(t, err, models.ErrVersionConflict)
+	merged, err := repo.Merge(ctx, "pr-1", updated.Version, time.Now())
+	require.NoError(t, err)
+	require.True(t, merged)
+}
+
 func TestPullRequestRepository_GetOpenPRsWithReviewer(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -630,3 +822,323 @@ func TestPullRequestRepository_GetOpenPRsWithReviewer(t *testing.T) {
 		})
 	}
 }
+
+func TestPullRequestRepository_List(t *testing.T) {
+	prepare := func(ctx context.Context, db *pgxpool.Pool) {
+		_, err := db.Exec(ctx,
+			"INSERT INTO users (id, name, team_name) VALUES ($1, $2, $3), ($4, $5, $6)",
+			"user-1", "Alice", "backend", "user-2", "Bob", "frontend",
+		)
+		require.NoError(t, err)
+
+		base := time.Now().Add(-10 * time.Hour)
+		prs := []struct {
+			id        string
+			authorID  string
+			status    string
+			reviewers []string
+		}{
+			{"pr-1", "user-1", "OPEN", []string{"user-2"}},
+			{"pr-2", "user-1", "MERGED", []string{"user-2"}},
+			{"pr-3", "user-2", "OPEN", []string{"user-1"}},
+			{"pr-4", "user-1", "OPEN", []string{"user-3"}},
+			{"pr-5", "user-2", "OPEN", []string{"user-2"}},
+		}
+		for i, pr := range prs {
+			reviewers, _ := json.Marshal(pr.reviewers)
+			_, err := db.Exec(ctx,
+				"INSERT INTO pull_requests (id, name, author_id, status, assigned_reviewers, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
+				pr.id, pr.id, pr.authorID, pr.status, reviewers, base.Add(time.Duration(i)*time.Hour),
+			)
+			require.NoError(t, err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		filter  models.PRFilter
+		order   models.SortOrder
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "All PRs newest first",
+			want: []string{"pr-5", "pr-4", "pr-3", "pr-2", "pr-1"},
+		},
+		{
+			name:  "All PRs oldest first",
+			order: models.SortAsc,
+			want:  []string{"pr-1", "pr-2", "pr-3", "pr-4", "pr-5"},
+		},
+		{
+			name:   "Filter by reviewer and status",
+			filter: models.PRFilter{ReviewerID: "user-2", Status: models.StatusOpen},
+			want:   []string{"pr-5", "pr-1"},
+		},
+		{
+			name:   "Filter by author team",
+			filter: models.PRFilter{TeamName: "frontend"},
+			want:   []string{"pr-5", "pr-3"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			testDB, cleanup := SetupTestContainer(t)
+			defer cleanup()
+
+			prepare(ctx, testDB)
+
+			repo := NewPullRequestRepository(testDB)
+
+			// Страницы по два PR: курсор последней страницы должен быть пустым
+			var got []string
+			page := models.PageRequest{Limit: 2, Order: tt.order}
+			for {
+				result, err := repo.List(ctx, tt.filter, page)
+				if tt.wantErr {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+
+				for _, pr := range result.PullRequests {
+					got = append(got, pr.ID)
+				}
+				if result.NextCursor == "" {
+					break
+				}
+
+				page.Cursor, err = models.DecodeCursor(result.NextCursor)
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPullRequestRepository_Search(t *testing.T) {
+	tests := []struct {
+		name   string
+		search models.PRSearch
+		want   []string
+	}{
+		{
+			name:   "Match by word",
+			search: models.PRSearch{Query: "search"},
+			want:   []string{"pr-3", "pr-1"},
+		},
+		{
+			name:   "Phrase and status",
+			search: models.PRSearch{Query: `"search index"`, Filter: models.PRFilter{Status: models.StatusOpen}},
+			want:   []string{"pr-1"},
+		},
+		{
+			name:   "Author team and reviewer",
+			search: models.PRSearch{Query: "search", Filter: models.PRFilter{TeamName: "frontend", ReviewerID: "user-1"}},
+			want:   []string{"pr-3"},
+		},
+		{
+			name:   "No matches",
+			search: models.PRSearch{Query: "billing"},
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			testDB, cleanup := SetupTestContainer(t)
+			defer cleanup()
+
+			_, err := testDB.Exec(ctx,
+				"INSERT INTO users (id, name, team_name) VALUES ($1, $2, $3), ($4, $5, $6)",
+				"user-1", "Alice", "backend", "user-2", "Bob", "frontend",
+			)
+			require.NoError(t, err)
+
+			reviewers, _ := json.Marshal([]string{"user-1"})
+			for i, pr := range [][]string{
+				{"pr-1", "Add search index", "user-1", "OPEN"},
+				{"pr-2", "Fix login form", "user-2", "OPEN"},
+				{"pr-3", "Search page layout", "user-2", "MERGED"},
+			} {
+				_, err := testDB.Exec(ctx,
+					"INSERT INTO pull_requests (id, name, author_id, status, assigned_reviewers, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
+					pr[0], pr[1], pr[2], pr[3], reviewers, time.Now().Add(time.Duration(i)*time.Minute),
+				)
+				require.NoError(t, err)
+			}
+
+			repo := NewPullRequestRepository(testDB)
+			result, err := repo.Search(ctx, tt.search)
+			require.NoError(t, err)
+
+			got := make([]string, 0, len(result))
+			for _, pr := range result {
+				got = append(got, pr.ID)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPullRequestRepository_GetByIDs(t *testing.T) {
+	ctx := context.Background()
+	testDB, cleanup := SetupTestContainer(t)
+	defer cleanup()
+
+	reviewers, _ := json.Marshal([]string{"user-2"})
+	for _, id := range []string{"pr-2", "pr-1", "pr-3"} {
+		_, err := testDB.Exec(ctx,
+			"INSERT INTO pull_requests (id, name, author_id, status, assigned_reviewers) VALUES ($1, $2, $3, $4, $5)",
+			id, "PR "+id, "user-1", "OPEN", reviewers,
+		)
+		require.NoError(t, err)
+	}
+
+	repo := NewPullRequestRepository(testDB)
+	result, err := repo.GetByIDs(ctx, []string{"pr-3", "pr-1", "pr-404"})
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	require.Equal(t, "pr-1", result[0].ID)
+	require.Equal(t, "pr-3", result[1].ID)
+	require.Equal(t, []string{"user-2"}, result[1].AssignedReviewers)
+}
+
+func TestPullRequestRepository_ClaimOverdue(t *testing.T) {
+	ctx := context.Background()
+	testDB, cleanup := SetupTestContainer(t)
+	defer cleanup()
+
+	now := time.Now()
+	reviewers, _ := json.Marshal([]string{"user-2"})
+	for _, pr := range []struct {
+		id        string
+		status    string
+		createdAt time.Time
+	}{
+		{"pr-old", "OPEN", now.Add(-3 * time.Hour)},
+		{"pr-due", "OPEN", now.Add(-2 * time.Hour)},
+		{"pr-new", "OPEN", now},
+		{"pr-merged", "MERGED", now.Add(-3 * time.Hour)},
+	} {
+		_, err := testDB.Exec(ctx,
+			"INSERT INTO pull_requests (id, name, author_id, status, assigned_reviewers, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
+			pr.id, "PR "+pr.id, "user-1", pr.status, reviewers, pr.createdAt,
+		)
+		require.NoError(t, err)
+	}
+
+	repo := NewPullRequestRepository(testDB)
+	claimed, err := repo.ClaimOverdue(ctx, now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+	require.Equal(t, "pr-old", claimed[0].ID)
+	require.Equal(t, "pr-due", claimed[1].ID)
+	require.Equal(t, []string{"user-2"}, claimed[0].AssignedReviewers)
+
+	claimed, err = repo.ClaimOverdue(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, claimed, 1, "already claimed PRs are not returned again")
+	require.Equal(t, "pr-new", claimed[0].ID)
+}
+
+func TestPullRequestRepository_GetReviewsByReviewers(t *testing.T) {
+	ctx := context.Background()
+	testDB, cleanup := SetupTestContainer(t)
+	defer cleanup()
+
+	for i, pr := range []struct {
+		id, status string
+		reviewers  []string
+	}{
+		{"pr-1", "OPEN", []string{"user-1", "user-2"}},
+		{"pr-2", "MERGED", []string{"user-1"}},
+		{"pr-3", "OPEN", []string{"user-2"}},
+	} {
+		reviewers, _ := json.Marshal(pr.reviewers)
+		_, err := testDB.Exec(ctx,
+			"INSERT INTO pull_requests (id, name, author_id, status, assigned_reviewers, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
+			pr.id, "PR "+pr.id, "user-9", pr.status, reviewers, time.Now().Add(time.Duration(i)*time.Minute),
+		)
+		require.NoError(t, err)
+	}
+	_, err := testDB.Exec(ctx,
+		"INSERT INTO pr_reviews (pull_request_id, reviewer_id, state) VALUES ('pr-1', 'user-2', 'APPROVED')",
+	)
+	require.NoError(t, err)
+
+	repo := NewPullRequestRepository(testDB)
+
+	all, err := repo.GetReviewsByReviewers(ctx, []string{"user-1", "user-2", "user-3"}, "")
+	require.NoError(t, err)
+	require.Len(t, all["user-1"], 2)
+	require.Equal(t, "pr-1", all["user-1"][0].ID, "open PRs come first")
+	require.Equal(t, models.ReviewPending, all["user-1"][0].ReviewState)
+	require.Equal(t, "pr-2", all["user-1"][1].ID)
+	require.Len(t, all["user-2"], 2)
+	require.Equal(t, "pr-3", all["user-2"][0].ID)
+	require.Equal(t, models.ReviewApproved, all["user-2"][1].ReviewState)
+	require.Empty(t, all["user-3"])
+
+	open, err := repo.GetReviewsByReviewers(ctx, []string{"user-1"}, models.StatusOpen)
+	require.NoError(t, err)
+	require.Len(t, open["user-1"], 1)
+	require.Equal(t, "pr-1", open["user-1"][0].ID)
+}
+
+func TestPullRequestRepository_GetReviewsPaging(t *testing.T) {
+	ctx := context.Background()
+	testDB, cleanup := SetupTestContainer(t)
+	defer cleanup()
+
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, id := range []string{"pr-1", "pr-2", "pr-3"} {
+		_, err := testDB.Exec(ctx,
+			"INSERT INTO pull_requests (id, name, author_id, status, assigned_reviewers, created_at) VALUES ($1, $2, 'user-9', 'OPEN', '[\"user-1\"]', $3)",
+			id, "PR "+id, base.Add(time.Duration(i)*time.Minute),
+		)
+		require.NoError(t, err)
+	}
+	// Смерженный PR без ревью не должен занимать место на странице
+	_, err := testDB.Exec(ctx,
+		"INSERT INTO pull_requests (id, name, author_id, status, assigned_reviewers, created_at) VALUES ('pr-0', 'PR pr-0', 'user-9', 'MERGED', '[\"user-1\"]', $1)",
+		base.Add(90*time.Second),
+	)
+	require.NoError(t, err)
+	_, err = testDB.Exec(ctx,
+		"INSERT INTO pr_reviews (pull_request_id, reviewer_id, state) VALUES ('pr-2', 'user-1', 'APPROVED')",
+	)
+	require.NoError(t, err)
+
+	repo := NewPullRequestRepository(testDB)
+	counts := models.ReviewCounts{NeedsReview: 2, Approved: 1, Total: 3}
+
+	first, err := repo.GetReviews(ctx, "user-1", "", models.PageRequest{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, first.PullRequests, 2)
+	require.Equal(t, "pr-3", first.PullRequests[0].ID)
+	require.Equal(t, "pr-2", first.PullRequests[1].ID)
+	require.Equal(t, models.ReviewApproved, first.PullRequests[1].ReviewState)
+	require.Equal(t, counts, first.Counts)
+	require.NotEmpty(t, first.NextCursor)
+
+	cursor, err := models.DecodeCursor(first.NextCursor)
+	require.NoError(t, err)
+	second, err := repo.GetReviews(ctx, "user-1", "", models.PageRequest{Limit: 2, Cursor: cursor})
+	require.NoError(t, err)
+	require.Len(t, second.PullRequests, 1)
+	require.Equal(t, "pr-1", second.PullRequests[0].ID)
+	require.Equal(t, models.ReviewPending, second.PullRequests[0].ReviewState)
+	require.Equal(t, counts, second.Counts)
+	require.Empty(t, second.NextCursor)
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const preferencesColumns = `user_id, channels, event_types, quiet_hours_start, quiet_hours_end,
+		timezone, delivery, COALESCE(email, ''), COALESCE(webhook_url, '')`
+
+type preferencesRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPreferencesRepository(db *pgxpool.Pool) PreferencesRepository {
+	return &preferencesRepository{db: db}
+}
+
+func (r *preferencesRepository) Get(ctx context.Context, userID string) (*models.NotificationPreferences, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM notification_preferences
+		WHERE user_id = $1
+	`, preferencesColumns)
+
+	preferences, err := scanPreferences(r.db.QueryRow(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return preferences, nil
+}
+
+// GetByUserIDs возвращает сохраненные настройки пользователей; у кого их нет, пропускаются
+func (r *preferencesRepository) GetByUserIDs(ctx context.Context, userIDs []string) ([]*models.NotificationPreferences, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM notification_preferences
+		WHERE user_id = ANY($1)
+	`, preferencesColumns)
+
+	rows, err := r.db.Query(ctx, query, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notification preferences: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*models.NotificationPreferences
+	for rows.Next() {
+		preferences, err := scanPreferences(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification preferences: %w", err)
+		}
+		result = append(result, preferences)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating notification preferences: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *preferencesRepository) Upsert(ctx context.Context, p *models.NotificationPreferences) error {
+	channelsJSON, err := json.Marshal(p.Channels)
+	if err != nil {
+		return fmt.Errorf("failed to marshal channels: %w", err)
+	}
+	eventTypesJSON, err := json.Marshal(p.EventTypes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event types: %w", err)
+	}
+
+	var quietStart, quietEnd *string
+	if p.QuietHours != nil {
+		quietStart, quietEnd = &p.QuietHours.Start, &p.QuietHours.End
+	}
+
+	query := `
+		INSERT INTO notification_preferences
+			(user_id, channels, event_types, quiet_hours_start, quiet_hours_end, timezone, delivery, email, webhook_url)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), NULLIF($9, ''))
+		ON CONFLICT (user_id) DO UPDATE SET
+			channels = EXCLUDED.channels,
+			event_types = EXCLUDED.event_types,
+			quiet_hours_start = EXCLUDED.quiet_hours_start,
+			quiet_hours_end = EXCLUDED.quiet_hours_end,
+			timezone = EXCLUDED.timezone,
+			delivery = EXCLUDED.delivery,
+			email = EXCLUDED.email,
+			webhook_url = EXCLUDED.webhook_url
+	`
+
+	_, err = r.db.Exec(ctx, query, p.UserID, channelsJSON, eventTypesJSON, quietStart, quietEnd,
+		p.Timezone, string(p.Delivery), p.Email, p.WebhookURL)
+	if err != nil {
+		return fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDigest отмечает сводку за день day отправленной пользователю. Возвращает false,
+// если сводка за этот или более поздний день уже отмечена, в том числе другой репликой.
+func (r *preferencesRepository) ClaimDigest(ctx context.Context, userID string, day time.Time) (bool, error) {
+	query := `
+		INSERT INTO digest_deliveries (user_id, day)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET
+			day = EXCLUDED.day,
+			sent_at = CURRENT_TIMESTAMP
+		WHERE digest_deliveries.day < EXCLUDED.day
+		RETURNING user_id
+	`
+
+	var claimed string
+	err := r.db.QueryRow(ctx, query, userID, day).Scan(&claimed)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim digest: %w", err)
+	}
+
+	return true, nil
+}
+
+// ReleaseDigest снимает отметку ClaimDigest, если сводку не удалось отправить
+func (r *preferencesRepository) ReleaseDigest(ctx context.Context, userID string, day time.Time) error {
+	query := `
+		UPDATE digest_deliveries SET day = day - 1
+		WHERE user_id = $1 AND day = $2
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, day); err != nil {
+		return fmt.Errorf("failed to release digest: %w", err)
+	}
+
+	return nil
+}
+
+func scanPreferences(row pgx.Row) (*models.NotificationPreferences, error) {
+	var p models.NotificationPreferences
+	var channelsJSON, eventTypesJSON []byte
+	var quietStart, quietEnd *string
+
+	err := row.Scan(&p.UserID, &channelsJSON, &eventTypesJSON, &quietStart, &quietEnd,
+		&p.Timezone, &p.Delivery, &p.Email, &p.WebhookURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(channelsJSON, &p.Channels); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal channels: %w", err)
+	}
+	if err := json.Unmarshal(eventTypesJSON, &p.EventTypes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event types: %w", err)
+	}
+	if quietStart != nil && quietEnd != nil {
+		p.QuietHours = &models.QuietHours{Start: *quietStart, End: *quietEnd}
+	}
+
+	return &p, nil
+}
@@ -9,7 +9,11 @@ import (
 type UserRepository interface {
 	Create(ctx context.Context, user *models.User) error
 	GetByID(ctx context.Context, userID string) (*models.User, error)
+	GetByIDs(ctx context.Context, userIDs []string) ([]*models.User, error)
 	GetByTeam(ctx context.Context, teamName string) ([]*models.User, error)
+	List(ctx context.Context) ([]*models.User, error)
+	Find(ctx context.Context, filter models.UserFilter, page models.OffsetPage) ([]*models.User, int, error)
+	SetDeprovisioned(ctx context.Context, userID string, deprovisioned bool) error
 	Update(ctx context.Context, user *models.User) error
 	SetActive(ctx context.Context, userID string, isActive bool) error
 	GetActiveTeamMembers(ctx context.Context, teamName string) ([]*models.User, error)
@@ -19,25 +23,72 @@ type UserRepository interface {
 type TeamRepository interface {
 	Create(ctx context.Context, team *models.Team) error
 	GetByName(ctx context.Context, teamName string) (*models.Team, error)
+	GetByNames(ctx context.Context, teamNames []string) ([]*models.Team, error)
 	Exists(ctx context.Context, teamName string) (bool, error)
 	Update(ctx context.Context, team *models.Team) error
+	List(ctx context.Context) ([]*models.Team, error)
+	Find(ctx context.Context, teamName string, page models.OffsetPage) ([]*models.Team, int, error)
+	Delete(ctx context.Context, teamName string) error
 }
 
 type PullRequestRepository interface {
 	Create(ctx context.Context, pr *models.PullRequest) error
 	GetByID(ctx context.Context, prID string) (*models.PullRequest, error)
+	GetByIDs(ctx context.Context, prIDs []string) ([]*models.PullRequest, error)
 	GetByAuthor(ctx context.Context, authorID string) ([]*models.PullRequest, error)
 	GetByReviewer(ctx context.Context, reviewerID string) ([]*models.PullRequestShort, error)
+	// Update и Merge применяются, только если версия PR не изменилась, иначе
+	// возвращают models.ErrVersionConflict
 	Update(ctx context.Context, pr *models.PullRequest) error
-	Merge(ctx context.Context, prID string, mergedAt time.Time) error
+	Merge(ctx context.Context, prID string, version int, mergedAt time.Time) (bool, error)
 	Exists(ctx context.Context, prID string) (bool, error)
 	GetOpenPRsWithReviewer(ctx context.Context, reviewerID string) ([]*models.PullRequest, error)
+	GetOpen(ctx context.Context) ([]*models.PullRequest, error)
+	ClaimOverdue(ctx context.Context, createdBefore time.Time) ([]*models.PullRequest, error)
+	List(ctx context.Context, filter models.PRFilter, page models.PageRequest) (*models.PRPage, error)
+	Search(ctx context.Context, search models.PRSearch) ([]*models.PullRequest, error)
+	GetReviews(ctx context.Context, reviewerID string, status models.PullRequestStatus, page models.PageRequest) (*models.ReviewPage, error)
+	GetReviewsByReviewers(ctx context.Context, reviewerIDs []string, status models.PullRequestStatus) (map[string][]*models.ReviewPR, error)
+	SaveReview(ctx context.Context, review *models.PRReview) error
 }
 
 type StatsRepository interface {
-	GetAssignmentStats(ctx context.Context) ([]*models.UserAssignmentStats, error)
-	GetPRAssignmentStats(ctx context.Context) (*models.PRAssignmentStats, error)
+	GetAssignmentStats(ctx context.Context, filter models.AssignmentStatsFilter, page models.PageRequest) (*models.AssignmentStatsResponse, error)
 	GetUserAssignmentCount(ctx context.Context, userID string) (int, error)
+	GetMemberAssignments(ctx context.Context) ([]*models.MemberAssignment, error)
+	GetOpenPRCounts(ctx context.Context) (*models.OpenPRCounts, error)
+	GetActiveUsersByTeam(ctx context.Context) (map[string]int, error)
+}
+
+// RosterPlanner составляет план по составу и открытым PR, прочитанным в транзакции применения
+type RosterPlanner func(current *models.Roster, openPRs []*models.PullRequest) (*models.ReconcilePlan, error)
+
+type RosterRepository interface {
+	Load(ctx context.Context) (*models.Roster, error)
+	// Sync читает текущее состояние, составляет по нему план и применяет его в одной
+	// транзакции. При dryRun план только составляется.
+	Sync(ctx context.Context, dryRun bool, planner RosterPlanner) (*models.ReconcilePlan, error)
+}
+
+type EventRepository interface {
+	Append(ctx context.Context, event *models.Event) error
+	ListAfter(ctx context.Context, afterID int64, filter models.EventFilter, limit int) ([]*models.Event, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type PreferencesRepository interface {
+	Get(ctx context.Context, userID string) (*models.NotificationPreferences, error)
+	GetByUserIDs(ctx context.Context, userIDs []string) ([]*models.NotificationPreferences, error)
+	Upsert(ctx context.Context, preferences *models.NotificationPreferences) error
+	ClaimDigest(ctx context.Context, userID string, day time.Time) (bool, error)
+	ReleaseDigest(ctx context.Context, userID string, day time.Time) error
+}
+
+type IdempotencyRepository interface {
+	Reserve(ctx context.Context, key, requestHash string, expiresAt time.Time) (*models.IdempotencyRecord, bool, error)
+	Complete(ctx context.Context, record *models.IdempotencyRecord) error
+	Release(ctx context.Context, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
 }
 
 type Repository struct {
@@ -45,4 +96,8 @@ type Repository struct {
 	Team        TeamRepository
 	PullRequest PullRequestRepository
 	Stats       StatsRepository
+	Roster      RosterRepository
+	Event       EventRepository
+	Preferences PreferencesRepository
+	Idempotency IdempotencyRepository
 }
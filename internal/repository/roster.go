@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type rosterRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRosterRepository(db *pgxpool.Pool) RosterRepository {
+	return &rosterRepository{db: db}
+}
+
+func (r *rosterRepository) Load(ctx context.Context) (*models.Roster, error) {
+	return loadRoster(ctx, r.db)
+}
+
+func loadRoster(ctx context.Context, q querier) (*models.Roster, error) {
+	rows, err := q.Query(ctx, `SELECT name FROM teams ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query teams: %w", err)
+	}
+	defer rows.Close()
+
+	roster := &models.Roster{Teams: []*models.RosterTeam{}}
+	teams := make(map[string]*models.RosterTeam)
+
+	for rows.Next() {
+		team := &models.RosterTeam{Members: []*models.RosterMember{}}
+		if err := rows.Scan(&team.Name); err != nil {
+			return nil, fmt.Errorf("failed to scan team: %w", err)
+		}
+		teams[team.Name] = team
+		roster.Teams = append(roster.Teams, team)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating teams: %w", err)
+	}
+
+	usersQuery := `
+		SELECT id, username, team_name, is_active
+		FROM users
+		WHERE team_name IS NOT NULL
+		ORDER BY team_name, username
+	`
+
+	userRows, err := q.Query(ctx, usersQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query users: %w", err)
+	}
+	defer userRows.Close()
+
+	for userRows.Next() {
+		var member models.RosterMember
+		var teamName string
+		if err := userRows.Scan(&member.UserID, &member.Username, &teamName, &member.IsActive); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		if team, ok := teams[teamName]; ok {
+			team.Members = append(team.Members, &member)
+		}
+	}
+
+	if err := userRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating users: %w", err)
+	}
+
+	return roster, nil
+}
+
+// Sync блокирует запись в команды и пользователей и открытые PR до конца транзакции,
+// поэтому план составляется и применяется по одному и тому же состоянию
+func (r *rosterRepository) Sync(ctx context.Context, dryRun bool, planner RosterPlanner) (*models.ReconcilePlan, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `LOCK TABLE teams, users IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return nil, fmt.Errorf("failed to lock roster: %w", err)
+	}
+
+	current, err := loadRoster(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	openQuery := `
+		SELECT id, name, author_id, status, assigned_reviewers, created_at, merged_at, updated_at, version
+		FROM pull_requests
+		WHERE status = 'OPEN'
+		ORDER BY created_at, id
+		FOR UPDATE
+	`
+
+	openPRs, err := selectPullRequests(ctx, tx, openQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := planner(current, openPRs)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun || plan.Empty() {
+		return plan, nil
+	}
+
+	if err := applyPlan(ctx, tx, plan); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return plan, nil
+}
+
+func applyPlan(ctx context.Context, tx pgx.Tx, plan *models.ReconcilePlan) error {
+	if err := createTeams(ctx, tx, plan.TeamsCreated); err != nil {
+		return err
+	}
+
+	if err := upsertUsers(ctx, tx, plan.UserChanges()); err != nil {
+		return err
+	}
+
+	// PR, измененный после составления плана, отменяет всю синхронизацию:
+	// замена ревьюверов рассчитана по его прежнему состоянию
+	prQuery := `
+		UPDATE pull_requests
+		SET assigned_reviewers = $2, updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id = $1 AND status = 'OPEN' AND version = $3
+	`
+
+	for _, change := range plan.Reassignments {
+		reviewersJSON, err := json.Marshal(change.AssignedReviewers)
+		if err != nil {
+			return fmt.Errorf("failed to marshal reviewers: %w", err)
+		}
+
+		result, err := tx.Exec(ctx, prQuery, change.PullRequestID, reviewersJSON, change.Version)
+		if err != nil {
+			return fmt.Errorf("failed to reassign reviewers of %s: %w", change.PullRequestID, err)
+		}
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("failed to reassign reviewers of %s: %w", change.PullRequestID, models.ErrVersionConflict)
+		}
+		if err := deleteStaleReviews(ctx, tx, change.PullRequestID, change.AssignedReviewers); err != nil {
+			return err
+		}
+	}
+
+	// Удаляем только опустевшие команды, иначе у пользователей обнулится team_name
+	deleteQuery := `
+		DELETE FROM teams
+		WHERE name = $1 AND NOT EXISTS (SELECT 1 FROM users WHERE team_name = $1)
+	`
+
+	for _, teamName := range plan.TeamsDeleted {
+		result, err := tx.Exec(ctx, deleteQuery, teamName)
+		if err != nil {
+			return fmt.Errorf("failed to delete team %s: %w", teamName, err)
+		}
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("team %s is not empty and cannot be deleted", teamName)
+		}
+	}
+
+	return nil
+}
+
+func createTeams(ctx context.Context, tx pgx.Tx, teamNames []string) error {
+	query := `
+		INSERT INTO teams (name)
+		VALUES ($1)
+		ON CONFLICT (name) DO NOTHING
+	`
+
+	for _, teamName := range teamNames {
+		if _, err := tx.Exec(ctx, query, teamName); err != nil {
+			return fmt.Errorf("failed to create team %s: %w", teamName, err)
+		}
+	}
+
+	return nil
+}
+
+func upsertUsers(ctx context.Context, tx pgx.Tx, changes []*models.RosterUserChange) error {
+	query := `
+		INSERT INTO users (id, username, team_name, is_active)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET
+			username = EXCLUDED.username,
+			team_name = EXCLUDED.team_name,
+			is_active = EXCLUDED.is_active,
+			updated_at = CURRENT_TIMESTAMP
+	`
+
+	for _, change := range changes {
+		_, err := tx.Exec(ctx, query, change.UserID, change.After.Username, change.After.TeamName, change.After.IsActive)
+		if err != nil {
+			return fmt.Errorf("failed to upsert user %s: %w", change.UserID, err)
+		}
+	}
+
+	return nil
+}
@@ -5,7 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -17,28 +19,146 @@ func NewStatsRepository(db *pgxpool.Pool) StatsRepository {
 	return &statsRepository{db: db}
 }
 
-func (r *statsRepository) GetAssignmentStats(ctx context.Context) ([]*models.UserAssignmentStats, error) {
+// GetAssignmentStats читает сводку, страницу статистики пользователей и статистику PR
+// в одной транзакции только для чтения, поэтому все части ответа видят один снимок данных
+func (r *statsRepository) GetAssignmentStats(ctx context.Context, filter models.AssignmentStatsFilter, page models.PageRequest) (*models.AssignmentStatsResponse, error) {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	summary, err := statsSummary(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	userPage, err := listAssignmentStats(ctx, tx, filter, page)
+	if err != nil {
+		return nil, err
+	}
+
+	prStats, err := prAssignmentStats(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &models.AssignmentStatsResponse{
+		UserStats:  userPage.UserStats,
+		PRStats:    prStats,
+		Summary:    summary,
+		NextCursor: userPage.NextCursor,
+	}, nil
+}
+
+// statsSummary считает сводку по всем активным пользователям одним агрегатным
+// запросом, не выгружая строки пользователей
+func statsSummary(ctx context.Context, q querier) (*models.StatsSummary, error) {
 	query := `
-		SELECT 
-			u.id as user_id,
-			u.username,
-			u.team_name,
-			u.is_active,
-			COUNT(pr.id) as assignment_count
-		FROM users u
-		LEFT JOIN pull_requests pr ON pr.assigned_reviewers @> jsonb_build_array(u.id)
-		WHERE u.is_active = true
-		GROUP BY u.id, u.username, u.team_name, u.is_active
-		ORDER BY assignment_count DESC, u.username
+		WITH stats AS (
+			SELECT
+				u.username,
+				u.is_active,
+				COUNT(pr.id) as assignment_count
+			FROM users u
+			LEFT JOIN pull_requests pr ON pr.assigned_reviewers @> jsonb_build_array(u.id)
+			WHERE u.is_active = true
+			GROUP BY u.id, u.username, u.is_active
+		)
+		SELECT
+			COUNT(*) as total_users,
+			COUNT(*) FILTER (WHERE is_active) as active_users,
+			COALESCE(SUM(assignment_count), 0) as total_assignments,
+			COALESCE((
+				SELECT username FROM stats
+				WHERE assignment_count > 0
+				ORDER BY assignment_count DESC, username
+				LIMIT 1
+			), '') as most_assigned_user,
+			COALESCE(MAX(assignment_count), 0) as most_assignments
+		FROM stats
 	`
 
-	rows, err := r.db.Query(ctx, query)
+	var summary models.StatsSummary
+	err := q.QueryRow(ctx, query).Scan(
+		&summary.TotalUsers,
+		&summary.ActiveUsers,
+		&summary.TotalAssignments,
+		&summary.MostAssignedUser,
+		&summary.MostAssignments,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats summary: %w", err)
+	}
+
+	return &summary, nil
+}
+
+// listAssignmentStats возвращает страницу статистики по активным пользователям.
+// Курсор хранит значение ключа сортировки (число назначений или имя) и id пользователя.
+func listAssignmentStats(ctx context.Context, q querier, filter models.AssignmentStatsFilter, page models.PageRequest) (*models.AssignmentStatsPage, error) {
+	page.Normalize()
+
+	var inner whereBuilder
+	inner.add("u.is_active = true")
+	if filter.TeamName != "" {
+		inner.add("u.team_name = " + inner.arg(filter.TeamName))
+	}
+
+	// Параметры нумеруются подряд, поэтому внешние условия используют тот же builder
+	outer := whereBuilder{args: inner.args}
+	var orderBy string
+	switch filter.Sort {
+	case "", models.StatsSortAssignments:
+		orderBy = "assignment_count DESC, user_id"
+		if page.Cursor != nil {
+			count, err := strconv.Atoi(page.Cursor.Value)
+			if err != nil {
+				return nil, models.ErrInvalidCursor
+			}
+			countArg, idArg := outer.arg(count), outer.arg(page.Cursor.ID)
+			outer.add(fmt.Sprintf("(assignment_count < %s OR (assignment_count = %s AND user_id > %s))", countArg, countArg, idArg))
+		}
+	case models.StatsSortUsername:
+		orderBy = "username, user_id"
+		if page.Cursor != nil {
+			outer.add(fmt.Sprintf("(username, user_id) > (%s, %s)", outer.arg(page.Cursor.Value), outer.arg(page.Cursor.ID)))
+		}
+	default:
+		return nil, models.ErrInvalidSort
+	}
+
+	query := fmt.Sprintf(`
+		WITH stats AS (
+			SELECT
+				u.id as user_id,
+				u.username,
+				u.team_name,
+				u.is_active,
+				COUNT(pr.id) as assignment_count
+			FROM users u
+			LEFT JOIN pull_requests pr ON pr.assigned_reviewers @> jsonb_build_array(u.id)
+			%s
+			GROUP BY u.id, u.username, u.team_name, u.is_active
+		)
+		SELECT user_id, username, team_name, is_active, assignment_count
+		FROM stats
+		%s
+		ORDER BY %s
+		LIMIT %s
+	`, inner.sql(), outer.sql(), orderBy, outer.arg(page.Limit+1))
+
+	rows, err := q.Query(ctx, query, outer.args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query assignment stats: %w", err)
 	}
 	defer rows.Close()
 
-	var stats []*models.UserAssignmentStats
+	stats := []*models.UserAssignmentStats{}
 	for rows.Next() {
 		var stat models.UserAssignmentStats
 		err := rows.Scan(
@@ -58,10 +178,21 @@ func (r *statsRepository) GetAssignmentStats(ctx context.Context) ([]*models.Use
 		return nil, fmt.Errorf("error iterating assignment stats: %w", err)
 	}
 
-	return stats, nil
+	result := &models.AssignmentStatsPage{UserStats: stats}
+	if len(stats) > page.Limit {
+		result.UserStats = stats[:page.Limit]
+		last := result.UserStats[page.Limit-1]
+		cursor := &models.Cursor{Value: strconv.Itoa(last.AssignmentCount), ID: last.UserID}
+		if filter.Sort == models.StatsSortUsername {
+			cursor.Value = last.Username
+		}
+		result.NextCursor = cursor.Encode()
+	}
+
+	return result, nil
 }
 
-func (r *statsRepository) GetPRAssignmentStats(ctx context.Context) (*models.PRAssignmentStats, error) {
+func prAssignmentStats(ctx context.Context, q querier) (*models.PRAssignmentStats, error) {
 	query := `
 		SELECT 
 			COUNT(*) as total_prs,
@@ -75,7 +206,7 @@ func (r *statsRepository) GetPRAssignmentStats(ctx context.Context) (*models.PRA
 	`
 
 	var stats models.PRAssignmentStats
-	err := r.db.QueryRow(ctx, query).Scan(
+	err := q.QueryRow(ctx, query).Scan(
 		&stats.TotalPRs,
 		&stats.OpenPRs,
 		&stats.MergedPRs,
@@ -112,3 +243,96 @@ func (r *statsRepository) GetUserAssignmentCount(ctx context.Context, userID str
 
 	return count, nil
 }
+
+func (r *statsRepository) GetMemberAssignments(ctx context.Context) ([]*models.MemberAssignment, error) {
+	query := `
+		SELECT
+			u.id,
+			u.username,
+			u.team_name,
+			COUNT(pr.id) as assignment_count,
+			u.created_at
+		FROM users u
+		LEFT JOIN pull_requests pr ON pr.assigned_reviewers @> jsonb_build_array(u.id)
+		WHERE u.is_active = true
+		GROUP BY u.id, u.username, u.team_name, u.created_at
+		ORDER BY u.team_name, u.username
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query member assignments: %w", err)
+	}
+	defer rows.Close()
+
+	var members []*models.MemberAssignment
+	for rows.Next() {
+		var member models.MemberAssignment
+		err := rows.Scan(
+			&member.UserID,
+			&member.Username,
+			&member.TeamName,
+			&member.AssignmentCount,
+			&member.ActiveSince,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan member assignment: %w", err)
+		}
+		members = append(members, &member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating member assignments: %w", err)
+	}
+
+	return members, nil
+}
+
+func (r *statsRepository) GetOpenPRCounts(ctx context.Context) (*models.OpenPRCounts, error) {
+	query := `
+		SELECT
+			COUNT(*) as open_prs,
+			COUNT(*) FILTER (WHERE jsonb_array_length(assigned_reviewers) = 0) as without_reviewers
+		FROM pull_requests
+		WHERE status = 'OPEN'
+	`
+
+	var counts models.OpenPRCounts
+	err := r.db.QueryRow(ctx, query).Scan(&counts.Open, &counts.WithoutReviewers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open PR counts: %w", err)
+	}
+
+	return &counts, nil
+}
+
+func (r *statsRepository) GetActiveUsersByTeam(ctx context.Context) (map[string]int, error) {
+	query := `
+		SELECT t.name, COUNT(u.id)
+		FROM teams t
+		LEFT JOIN users u ON u.team_name = t.name AND u.is_active = true
+		GROUP BY t.name
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query active users by team: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var team string
+		var count int
+		if err := rows.Scan(&team, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan active users by team: %w", err)
+		}
+		counts[team] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating active users by team: %w", err)
+	}
+
+	return counts, nil
+}
@@ -165,3 +165,149 @@ func (r *teamRepository) Update(ctx context.Context, team *models.Team) error {
 
 	return nil
 }
+
+func (r *teamRepository) List(ctx context.Context) ([]*models.Team, error) {
+	return r.queryTeams(ctx, "")
+}
+
+// GetByNames возвращает найденные команды из списка вместе с участниками,
+// отсутствующие пропускаются
+func (r *teamRepository) GetByNames(ctx context.Context, teamNames []string) ([]*models.Team, error) {
+	return r.queryTeams(ctx, "WHERE t.name = ANY($1)", teamNames)
+}
+
+// Find возвращает страницу команд с участниками и общее число команд.
+// Непустой teamName ограничивает выборку одной командой.
+func (r *teamRepository) Find(ctx context.Context, teamName string, page models.OffsetPage) ([]*models.Team, int, error) {
+	var where whereBuilder
+	if teamName != "" {
+		where.add("name = " + where.arg(teamName))
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM teams "+where.sql(), where.args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count teams: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT name
+		FROM teams
+		%s
+		ORDER BY name
+		OFFSET %s LIMIT %s
+	`, where.sql(), where.arg(page.Offset), where.arg(page.Limit))
+
+	rows, err := r.db.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query teams: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan team: %w", err)
+		}
+		names = append(names, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating teams: %w", err)
+	}
+
+	if len(names) == 0 {
+		return []*models.Team{}, total, nil
+	}
+
+	teams, err := r.GetByNames(ctx, names)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return teams, total, nil
+}
+
+// queryTeams выбирает команды с участниками одним запросом
+func (r *teamRepository) queryTeams(ctx context.Context, where string, args ...interface{}) ([]*models.Team, error) {
+	query := fmt.Sprintf(`
+		SELECT t.name, t.created_at, t.updated_at, u.id, u.username, u.is_active
+		FROM teams t
+		LEFT JOIN users u ON u.team_name = t.name
+		%s
+		ORDER BY t.name, u.username
+	`, where)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query teams: %w", err)
+	}
+	defer rows.Close()
+
+	var teams []*models.Team
+	var current *models.Team
+	for rows.Next() {
+		var team models.Team
+		var memberID, memberName *string
+		var memberActive *bool
+		err := rows.Scan(
+			&team.Name,
+			&team.CreatedAt,
+			&team.UpdatedAt,
+			&memberID,
+			&memberName,
+			&memberActive,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan team: %w", err)
+		}
+
+		if current == nil || current.Name != team.Name {
+			team.Members = []*models.User{}
+			current = &team
+			teams = append(teams, current)
+		}
+
+		if memberID != nil {
+			current.Members = append(current.Members, &models.User{
+				ID:       *memberID,
+				Username: *memberName,
+				TeamName: current.Name,
+				IsActive: memberActive != nil && *memberActive,
+			})
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating teams: %w", err)
+	}
+
+	return teams, nil
+}
+
+// Delete удаляет команду без участников. Непустую команду удалить нельзя,
+// иначе у ее участников обнулится team_name.
+func (r *teamRepository) Delete(ctx context.Context, teamName string) error {
+	query := `
+		DELETE FROM teams
+		WHERE name = $1 AND NOT EXISTS (SELECT 1 FROM users WHERE team_name = $1)
+	`
+
+	result, err := r.db.Exec(ctx, query, teamName)
+	if err != nil {
+		return fmt.Errorf("failed to delete team: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		exists, err := r.Exists(ctx, teamName)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return models.ErrNotFound
+		}
+		return models.ErrTeamNotEmpty
+	}
+
+	return nil
+}
@@ -102,6 +102,88 @@ func (r *userRepository) GetByTeam(ctx context.Context, teamName string) ([]*mod
 	return users, nil
 }
 
+// GetByIDs возвращает найденных пользователей из списка, отсутствующие пропускаются
+func (r *userRepository) GetByIDs(ctx context.Context, userIDs []string) ([]*models.User, error) {
+	query := `
+		SELECT id, username, team_name, is_active, created_at, updated_at
+		FROM users
+		WHERE id = ANY($1)
+		ORDER BY id
+	`
+
+	return r.queryUsers(ctx, query, userIDs)
+}
+
+// List возвращает всех пользователей, состоящих в команде
+func (r *userRepository) List(ctx context.Context) ([]*models.User, error) {
+	query := `
+		SELECT id, username, team_name, is_active, created_at, updated_at
+		FROM users
+		WHERE team_name IS NOT NULL
+		ORDER BY id
+	`
+
+	return r.queryUsers(ctx, query)
+}
+
+// Find возвращает страницу пользователей по условиям и их общее число.
+// Пользователи, удаленные через SCIM, не выбираются.
+func (r *userRepository) Find(ctx context.Context, filter models.UserFilter, page models.OffsetPage) ([]*models.User, int, error) {
+	var where whereBuilder
+	where.add("team_name IS NOT NULL")
+	where.add("NOT EXISTS (SELECT 1 FROM deprovisioned_users d WHERE d.user_id = users.id)")
+	if filter.ID != "" {
+		where.add("id = " + where.arg(filter.ID))
+	}
+	if filter.Username != "" {
+		where.add("lower(username) = lower(" + where.arg(filter.Username) + ")")
+	}
+	if filter.TeamName != "" {
+		where.add("team_name = " + where.arg(filter.TeamName))
+	}
+	if filter.IsActive != nil {
+		where.add("is_active = " + where.arg(*filter.IsActive))
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM users "+where.sql(), where.args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, username, team_name, is_active, created_at, updated_at
+		FROM users
+		%s
+		ORDER BY id
+		OFFSET %s LIMIT %s
+	`, where.sql(), where.arg(page.Offset), where.arg(page.Limit))
+
+	users, err := r.queryUsers(ctx, query, where.args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// SetDeprovisioned отмечает пользователя удаленным через SCIM или снимает отметку
+func (r *userRepository) SetDeprovisioned(ctx context.Context, userID string, deprovisioned bool) error {
+	query := `DELETE FROM deprovisioned_users WHERE user_id = $1`
+	if deprovisioned {
+		query = `
+			INSERT INTO deprovisioned_users (user_id)
+			VALUES ($1)
+			ON CONFLICT (user_id) DO NOTHING
+		`
+	}
+
+	if _, err := r.db.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to set user deprovisioned: %w", err)
+	}
+
+	return nil
+}
+
 func (r *userRepository) Update(ctx context.Context, user *models.User) error {
 	query := `
 		UPDATE users
@@ -0,0 +1,223 @@
+// Package roster кодирует состав команд в CSV, YAML и JSON
+package roster
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+	FormatCSV  = "csv"
+)
+
+var csvHeader = []string{"team_name", "user_id", "username", "is_active"}
+
+// FormatFromContentType определяет формат по заголовку Content-Type
+func FormatFromContentType(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+
+	switch strings.TrimSpace(strings.ToLower(mediaType)) {
+	case "text/csv":
+		return FormatCSV
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		return FormatYAML
+	default:
+		return FormatJSON
+	}
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatYAML:
+		return "application/yaml; charset=utf-8"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// wireRoster повторяет models.Roster, но отличает отсутствующий is_active от false:
+// пропущенное поле означает активного участника, как и пустая колонка в CSV
+type wireRoster struct {
+	Teams []struct {
+		Name    string `json:"team_name" yaml:"team_name"`
+		Members []struct {
+			UserID   string `json:"user_id" yaml:"user_id"`
+			Username string `json:"username" yaml:"username"`
+			IsActive *bool  `json:"is_active" yaml:"is_active"`
+		} `json:"members" yaml:"members"`
+	} `json:"teams" yaml:"teams"`
+}
+
+func (w *wireRoster) toModel() []*models.RosterTeam {
+	teams := make([]*models.RosterTeam, 0, len(w.Teams))
+	for _, wireTeam := range w.Teams {
+		team := &models.RosterTeam{Name: wireTeam.Name, Members: []*models.RosterMember{}}
+		for _, wireMember := range wireTeam.Members {
+			isActive := true
+			if wireMember.IsActive != nil {
+				isActive = *wireMember.IsActive
+			}
+			team.Members = append(team.Members, &models.RosterMember{
+				UserID:   wireMember.UserID,
+				Username: wireMember.Username,
+				IsActive: isActive,
+			})
+		}
+		teams = append(teams, team)
+	}
+	return teams
+}
+
+func Decode(format string, r io.Reader) (*models.Roster, error) {
+	var roster models.Roster
+	var wire wireRoster
+
+	switch format {
+	case FormatJSON:
+		if err := json.NewDecoder(r).Decode(&wire); err != nil {
+			return nil, fmt.Errorf("failed to decode json roster: %w", err)
+		}
+		roster.Teams = wire.toModel()
+	case FormatYAML:
+		if err := yaml.NewDecoder(r).Decode(&wire); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to decode yaml roster: %w", err)
+		}
+		roster.Teams = wire.toModel()
+	case FormatCSV:
+		teams, err := decodeCSV(r)
+		if err != nil {
+			return nil, err
+		}
+		roster.Teams = teams
+	default:
+		return nil, models.ErrInvalidRosterFormat
+	}
+
+	if err := roster.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &roster, nil
+}
+
+func Encode(format string, w io.Writer, roster *models.Roster) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(roster)
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(roster); err != nil {
+			return err
+		}
+		return enc.Close()
+	case FormatCSV:
+		return encodeCSV(w, roster)
+	default:
+		return models.ErrInvalidRosterFormat
+	}
+}
+
+// decodeCSV читает строки team_name,user_id,username,is_active.
+// Строка с пустым user_id описывает команду без участников.
+func decodeCSV(r io.Reader) ([]*models.RosterTeam, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(csvHeader)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+	for i, column := range csvHeader {
+		if strings.TrimSpace(header[i]) != column {
+			return nil, fmt.Errorf("unexpected csv header %q, expected %q", strings.Join(header, ","), strings.Join(csvHeader, ","))
+		}
+	}
+
+	var teams []*models.RosterTeam
+	byName := make(map[string]*models.RosterTeam)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+
+		teamName := record[0]
+		team, ok := byName[teamName]
+		if !ok {
+			team = &models.RosterTeam{Name: teamName, Members: []*models.RosterMember{}}
+			byName[teamName] = team
+			teams = append(teams, team)
+		}
+
+		if record[1] == "" {
+			continue
+		}
+
+		isActive := true
+		if record[3] != "" {
+			isActive, err = strconv.ParseBool(record[3])
+			if err != nil {
+				line, _ := reader.FieldPos(3)
+				return nil, fmt.Errorf("invalid is_active %q on line %d", record[3], line)
+			}
+		}
+
+		team.Members = append(team.Members, &models.RosterMember{
+			UserID:   record[1],
+			Username: record[2],
+			IsActive: isActive,
+		})
+	}
+
+	return teams, nil
+}
+
+func encodeCSV(w io.Writer, roster *models.Roster) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, team := range roster.Teams {
+		if len(team.Members) == 0 {
+			if err := writer.Write([]string{team.Name, "", "", ""}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, member := range team.Members {
+			record := []string{team.Name, member.UserID, member.Username, strconv.FormatBool(member.IsActive)}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,88 @@
+package roster
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleRoster() *models.Roster {
+	return &models.Roster{Teams: []*models.RosterTeam{
+		{Name: "backend", Members: []*models.RosterMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob, Jr.", IsActive: false},
+		}},
+		{Name: "empty", Members: []*models.RosterMember{}},
+	}}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []string{FormatJSON, FormatYAML, FormatCSV} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, Encode(format, &buf, sampleRoster()))
+
+			decoded, err := Decode(format, &buf)
+			require.NoError(t, err)
+			assert.Equal(t, sampleRoster(), decoded)
+		})
+	}
+}
+
+func TestDecode_DefaultsToActive(t *testing.T) {
+	inputs := map[string]string{
+		FormatJSON: `{"teams":[{"team_name":"backend","members":[{"user_id":"u1","username":"Alice"}]}]}`,
+		FormatYAML: "teams:\n  - team_name: backend\n    members:\n      - user_id: u1\n        username: Alice\n",
+		FormatCSV:  "team_name,user_id,username,is_active\nbackend,u1,Alice,\n",
+	}
+
+	for format, input := range inputs {
+		t.Run(format, func(t *testing.T) {
+			decoded, err := Decode(format, strings.NewReader(input))
+			require.NoError(t, err)
+			require.Len(t, decoded.Teams, 1)
+			require.Len(t, decoded.Teams[0].Members, 1)
+			assert.True(t, decoded.Teams[0].Members[0].IsActive)
+		})
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		input  string
+		want   error
+	}{
+		{name: "Unknown format", format: "xml", input: "", want: models.ErrInvalidRosterFormat},
+		{
+			name:   "Duplicate user across teams",
+			format: FormatCSV,
+			input:  "team_name,user_id,username,is_active\na,u1,Alice,true\nb,u1,Alice,true\n",
+			want:   models.ErrDuplicateRosterUser,
+		},
+		{
+			name:   "Missing username",
+			format: FormatJSON,
+			input:  `{"teams":[{"team_name":"a","members":[{"user_id":"u1"}]}]}`,
+			want:   models.ErrInvalidUsername,
+		},
+		{name: "Bad csv header", format: FormatCSV, input: "team,user,name,active\n"},
+		{name: "Bad is_active", format: FormatCSV, input: "team_name,user_id,username,is_active\na,u1,Alice,maybe\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(tt.format, strings.NewReader(tt.input))
+			require.Error(t, err)
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+)
+
+var errInvalidFilter = errors.New("unsupported filter, only 'attr eq value' joined with 'and' is supported")
+
+// condition - одно сравнение вида attr eq "value"
+type condition struct {
+	attr  string
+	value string
+}
+
+// parseFilter разбирает подмножество фильтров RFC 7644, которое используют IdP
+// при поиске ресурсов: сравнения eq, объединенные через and.
+func parseFilter(filter string) ([]condition, error) {
+	tokens, err := tokenizeFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var conditions []condition
+	for i := 0; i < len(tokens); i += 4 {
+		if i+3 > len(tokens) || !strings.EqualFold(tokens[i+1], "eq") {
+			return nil, errInvalidFilter
+		}
+		if i+3 < len(tokens) && !strings.EqualFold(tokens[i+3], "and") {
+			return nil, errInvalidFilter
+		}
+		if i+3 == len(tokens)-1 {
+			return nil, errInvalidFilter
+		}
+
+		conditions = append(conditions, condition{attr: normalizeAttr(tokens[i]), value: tokens[i+2]})
+	}
+
+	if len(conditions) == 0 {
+		return nil, errInvalidFilter
+	}
+
+	return conditions, nil
+}
+
+func tokenizeFilter(filter string) ([]string, error) {
+	var tokens []string
+
+	for i := 0; i < len(filter); {
+		switch {
+		case filter[i] == ' ':
+			i++
+		case filter[i] == '"':
+			end := i + 1
+			for end < len(filter) && (filter[end] != '"' || filter[end-1] == '\\') {
+				end++
+			}
+			if end == len(filter) {
+				return nil, errInvalidFilter
+			}
+
+			var value string
+			if err := json.Unmarshal([]byte(filter[i:end+1]), &value); err != nil {
+				return nil, errInvalidFilter
+			}
+			tokens = append(tokens, value)
+			i = end + 1
+		default:
+			end := strings.IndexByte(filter[i:], ' ')
+			if end < 0 {
+				end = len(filter) - i
+			}
+			tokens = append(tokens, filter[i:i+end])
+			i += end
+		}
+	}
+
+	return tokens, nil
+}
+
+// normalizeAttr приводит имя атрибута к нижнему регистру и убирает префикс схемы
+func normalizeAttr(attr string) string {
+	attr = strings.ToLower(attr)
+	for _, schema := range []string{SchemaEnterpriseUser, SchemaUser, SchemaGroup} {
+		attr = strings.TrimPrefix(attr, strings.ToLower(schema)+":")
+	}
+	return attr
+}
+
+// userFilter переводит условия в фильтр выборки пользователей. Если условия
+// противоречат друг другу, ни один пользователь не подходит и ok = false.
+func userFilter(conditions []condition) (filter models.UserFilter, ok bool, err error) {
+	for _, cond := range conditions {
+		switch cond.attr {
+		case "id", "externalid":
+			ok = narrow(&filter.ID, cond.value, false)
+		case "username", "displayname":
+			ok = narrow(&filter.Username, cond.value, true)
+		case "active":
+			var active bool
+			active, err = strconv.ParseBool(strings.ToLower(cond.value))
+			if err != nil {
+				return filter, false, nil
+			}
+			ok = filter.IsActive == nil || *filter.IsActive == active
+			filter.IsActive = &active
+		case "department":
+			ok = narrow(&filter.TeamName, cond.value, false)
+		default:
+			return filter, false, errInvalidFilter
+		}
+
+		if !ok {
+			return filter, false, nil
+		}
+	}
+
+	return filter, true, nil
+}
+
+// groupFilter возвращает название команды из условий; все атрибуты группы совпадают с ним
+func groupFilter(conditions []condition) (teamName string, ok bool, err error) {
+	for _, cond := range conditions {
+		switch cond.attr {
+		case "id", "displayname", "externalid":
+			if !narrow(&teamName, cond.value, false) {
+				return "", false, nil
+			}
+		default:
+			return "", false, errInvalidFilter
+		}
+	}
+
+	return teamName, true, nil
+}
+
+// narrow сужает условие на поле до значения value и сообщает, совместимо ли оно с прежним
+func narrow(field *string, value string, foldCase bool) bool {
+	if value == "" {
+		return false
+	}
+	if *field == "" {
+		*field = value
+		return true
+	}
+	if foldCase {
+		return strings.EqualFold(*field, value)
+	}
+	return *field == value
+}
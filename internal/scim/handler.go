@@ -0,0 +1,524 @@
+package scim
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	logpkg "github.com/vnchk1/pr-manager/internal/logger"
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultCount = 100
+	maxCount     = 200
+)
+
+// UserService - операции над пользователями, нужные SCIM. Деактивация идет через
+// Update, поэтому открытые ревью переназначаются так же, как в /users/setIsActive.
+// Пользователи, удаленные через Deprovision, Find не возвращает.
+type UserService interface {
+	Provision(ctx context.Context, user *models.User) (*models.User, error)
+	Update(ctx context.Context, userID string, update *models.UserUpdate) (*models.User, error)
+	Find(ctx context.Context, filter models.UserFilter, page models.OffsetPage) ([]*models.User, int, error)
+	Deprovision(ctx context.Context, userID string) error
+}
+
+type TeamService interface {
+	Get(ctx context.Context, teamName string) (*models.Team, error)
+	Find(ctx context.Context, teamName string, page models.OffsetPage) ([]*models.Team, int, error)
+	Delete(ctx context.Context, teamName string) error
+}
+
+// RosterService создает группу вместе с участниками одной транзакцией
+type RosterService interface {
+	CreateTeam(ctx context.Context, teamName string, memberIDs []string) error
+}
+
+// Handler реализует эндпоинты SCIM 2.0 /Users и /Groups поверх сервисов пользователей и команд
+type Handler struct {
+	users  UserService
+	teams  TeamService
+	roster RosterService
+	token  string
+}
+
+// NewHandler создает обработчик SCIM. Запросы должны содержать заголовок
+// Authorization: Bearer <token>; с пустым token все запросы отклоняются.
+func NewHandler(users UserService, teams TeamService, roster RosterService, token string) *Handler {
+	return &Handler{users: users, teams: teams, roster: roster, token: token}
+}
+
+// Register подключает эндпоинты к группе маршрутов, обычно /scim/v2
+func (h *Handler) Register(g *echo.Group) {
+	g.Use(h.authenticate)
+
+	g.GET("/ServiceProviderConfig", h.serviceProviderConfig)
+
+	g.POST("/Users", h.createUser)
+	g.GET("/Users", h.listUsers)
+	g.GET("/Users/:id", h.getUser)
+	g.PUT("/Users/:id", h.replaceUser)
+	g.PATCH("/Users/:id", h.patchUser)
+	g.DELETE("/Users/:id", h.deleteUser)
+
+	g.POST("/Groups", h.createGroup)
+	g.GET("/Groups", h.listGroups)
+	g.GET("/Groups/:id", h.getGroup)
+	g.PATCH("/Groups/:id", h.patchGroup)
+	g.DELETE("/Groups/:id", h.deleteGroup)
+}
+
+func (h *Handler) authenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+		if !ok || h.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+			return writeError(c, http.StatusUnauthorized, "", "invalid bearer token")
+		}
+
+		return next(c)
+	}
+}
+
+func (h *Handler) serviceProviderConfig(c echo.Context) error {
+	return writeJSON(c, http.StatusOK, map[string]any{
+		"schemas":        []string{SchemaServiceConfig},
+		"patch":          map[string]bool{"supported": true},
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": maxCount},
+		"changePassword": map[string]bool{"supported": false},
+		"sort":           map[string]bool{"supported": false},
+		"etag":           map[string]bool{"supported": false},
+		"authenticationSchemes": []map[string]string{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication with a static bearer token",
+		}},
+	})
+}
+
+func (h *Handler) createUser(c echo.Context) error {
+	var resource User
+	if err := decode(c, &resource); err != nil {
+		return h.serviceError(c, err)
+	}
+
+	user := resource.toModel()
+	if user.TeamName == "" {
+		return writeError(c, http.StatusBadRequest, "invalidValue", "enterprise department is required and is used as the team")
+	}
+
+	created, err := h.users.Provision(c.Request().Context(), user)
+	if err != nil {
+		return h.serviceError(c, err)
+	}
+
+	return writeJSON(c, http.StatusCreated, userResource(created, location(c, "Users", created.ID)))
+}
+
+func (h *Handler) listUsers(c echo.Context) error {
+	conditions, err := filterConditions(c)
+	if err != nil {
+		return writeError(c, http.StatusBadRequest, "invalidFilter", err.Error())
+	}
+	filter, ok, err := userFilter(conditions)
+	if err != nil {
+		return writeError(c, http.StatusBadRequest, "invalidFilter", err.Error())
+	}
+	page, startIndex, err := listPage(c)
+	if err != nil {
+		return h.serviceError(c, err)
+	}
+	if !ok {
+		return writeList(c, nil, 0, startIndex)
+	}
+
+	users, total, err := h.users.Find(c.Request().Context(), filter, page)
+	if err != nil {
+		return h.serviceError(c, err)
+	}
+
+	resources := make([]any, 0, len(users))
+	for _, user := range users {
+		resources = append(resources, userResource(user, location(c, "Users", user.ID)))
+	}
+
+	return writeList(c, resources, total, startIndex)
+}
+
+func (h *Handler) getUser(c echo.Context) error {
+	user, err := h.lookup(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return h.serviceError(c, err)
+	}
+
+	return writeJSON(c, http.StatusOK, userResource(user, location(c, "Users", user.ID)))
+}
+
+func (h *Handler) replaceUser(c echo.Context) error {
+	var resource User
+	if err := decode(c, &resource); err != nil {
+		return h.serviceError(c, err)
+	}
+
+	ctx := c.Request().Context()
+	if _, err := h.lookup(ctx, c.Param("id")); err != nil {
+		return h.serviceError(c, err)
+	}
+
+	desired := resource.toModel()
+	update := &models.UserUpdate{
+		Username: &desired.Username,
+		IsActive: &desired.IsActive,
+	}
+	if desired.TeamName != "" {
+		update.TeamName = &desired.TeamName
+	}
+
+	user, err := h.users.Update(ctx, c.Param("id"), update)
+	if err != nil {
+		return h.serviceError(c, err)
+	}
+
+	return writeJSON(c, http.StatusOK, userResource(user, location(c, "Users", user.ID)))
+}
+
+func (h *Handler) patchUser(c echo.Context) error {
+	var req PatchRequest
+	if err := decode(c, &req); err != nil {
+		return h.serviceError(c, err)
+	}
+
+	update, err := userPatch(req.Operations)
+	if err != nil {
+		return h.serviceError(c, err)
+	}
+
+	ctx := c.Request().Context()
+	if _, err := h.lookup(ctx, c.Param("id")); err != nil {
+		return h.serviceError(c, err)
+	}
+
+	user, err := h.users.Update(ctx, c.Param("id"), update)
+	if err != nil {
+		return h.serviceError(c, err)
+	}
+
+	return writeJSON(c, http.StatusOK, userResource(user, location(c, "Users", user.ID)))
+}
+
+// deleteUser деактивирует пользователя и скрывает его из SCIM: запись удалить нельзя,
+// пока на нее ссылаются PR. Повторное создание с тем же ID восстанавливает пользователя.
+func (h *Handler) deleteUser(c echo.Context) error {
+	ctx := c.Request().Context()
+	if _, err := h.lookup(ctx, c.Param("id")); err != nil {
+		return h.serviceError(c, err)
+	}
+
+	if err := h.users.Deprovision(ctx, c.Param("id")); err != nil {
+		return h.serviceError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *Handler) createGroup(c echo.Context) error {
+	var resource Group
+	if err := decode(c, &resource); err != nil {
+		return h.serviceError(c, err)
+	}
+
+	ctx := c.Request().Context()
+	memberIDs := make([]string, 0, len(resource.Members))
+	for _, member := range resource.Members {
+		if _, err := h.lookup(ctx, member.Value); errors.Is(err, models.ErrNotFound) {
+			return h.serviceError(c, invalidValue("member %q does not exist", member.Value))
+		} else if err != nil {
+			return h.serviceError(c, err)
+		}
+		memberIDs = append(memberIDs, member.Value)
+	}
+
+	err := h.roster.CreateTeam(ctx, resource.DisplayName, memberIDs)
+	if errors.Is(err, models.ErrNotFound) {
+		return h.serviceError(c, invalidValue("group members must exist"))
+	}
+	if err != nil {
+		return h.serviceError(c, err)
+	}
+
+	return h.writeGroup(c, http.StatusCreated, resource.DisplayName)
+}
+
+func (h *Handler) listGroups(c echo.Context) error {
+	conditions, err := filterConditions(c)
+	if err != nil {
+		return writeError(c, http.StatusBadRequest, "invalidFilter", err.Error())
+	}
+	teamName, ok, err := groupFilter(conditions)
+	if err != nil {
+		return writeError(c, http.StatusBadRequest, "invalidFilter", err.Error())
+	}
+	page, startIndex, err := listPage(c)
+	if err != nil {
+		return h.serviceError(c, err)
+	}
+	if !ok {
+		return writeList(c, nil, 0, startIndex)
+	}
+
+	teams, total, err := h.teams.Find(c.Request().Context(), teamName, page)
+	if err != nil {
+		return h.serviceError(c, err)
+	}
+
+	withMembers := !excludesMembers(c)
+
+	resources := make([]any, 0, len(teams))
+	for _, team := range teams {
+		resources = append(resources, groupResource(team, location(c, "Groups", team.Name), withMembers))
+	}
+
+	return writeList(c, resources, total, startIndex)
+}
+
+func (h *Handler) getGroup(c echo.Context) error {
+	return h.writeGroup(c, http.StatusOK, c.Param("id"))
+}
+
+func (h *Handler) patchGroup(c echo.Context) error {
+	var req PatchRequest
+	if err := decode(c, &req); err != nil {
+		return h.serviceError(c, err)
+	}
+
+	patch, err := parseGroupPatch(req.Operations)
+	if err != nil {
+		return h.serviceError(c, err)
+	}
+
+	ctx := c.Request().Context()
+	team, err := h.teams.Get(ctx, c.Param("id"))
+	if err != nil {
+		return h.serviceError(c, err)
+	}
+
+	if patch.displayName != nil && *patch.displayName != team.Name {
+		return writeError(c, http.StatusBadRequest, "mutability", "groups cannot be renamed")
+	}
+
+	keep := make(map[string]bool)
+	if patch.replaceSet {
+		for _, id := range patch.replace {
+			keep[id] = true
+		}
+	}
+
+	remove := make(map[string]bool)
+	for _, id := range patch.remove {
+		remove[id] = true
+	}
+
+	for _, member := range team.Members {
+		if !member.IsActive {
+			continue
+		}
+		if patch.removeAll || remove[member.ID] || (patch.replaceSet && !keep[member.ID]) {
+			if err := h.deactivate(ctx, member.ID); err != nil {
+				return h.serviceError(c, err)
+			}
+		}
+	}
+
+	for _, id := range append(patch.replace, patch.add...) {
+		if err := h.addMember(ctx, team.Name, id); err != nil {
+			return h.serviceError(c, err)
+		}
+	}
+
+	return h.writeGroup(c, http.StatusOK, team.Name)
+}
+
+// deleteGroup удаляет пустую команду. Если в команде есть участники, они
+// деактивируются, а сама команда остается, потому что пользователь не может быть без команды.
+func (h *Handler) deleteGroup(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	team, err := h.teams.Get(ctx, c.Param("id"))
+	if err != nil {
+		return h.serviceError(c, err)
+	}
+
+	if len(team.Members) == 0 {
+		if err := h.teams.Delete(ctx, team.Name); err != nil {
+			return h.serviceError(c, err)
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	for _, member := range team.Members {
+		if member.IsActive {
+			if err := h.deactivate(ctx, member.ID); err != nil {
+				return h.serviceError(c, err)
+			}
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// addMember переводит пользователя в команду и активирует его
+func (h *Handler) addMember(ctx context.Context, teamName, userID string) error {
+	if _, err := h.lookup(ctx, userID); errors.Is(err, models.ErrNotFound) {
+		return invalidValue("member %q does not exist", userID)
+	} else if err != nil {
+		return err
+	}
+
+	active := true
+	_, err := h.users.Update(ctx, userID, &models.UserUpdate{TeamName: &teamName, IsActive: &active})
+	return err
+}
+
+// lookup возвращает пользователя, видимого через SCIM; удаленные через DELETE не находятся
+func (h *Handler) lookup(ctx context.Context, userID string) (*models.User, error) {
+	users, _, err := h.users.Find(ctx, models.UserFilter{ID: userID}, models.OffsetPage{Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, models.ErrNotFound
+	}
+	return users[0], nil
+}
+
+func (h *Handler) deactivate(ctx context.Context, userID string) error {
+	inactive := false
+	_, err := h.users.Update(ctx, userID, &models.UserUpdate{IsActive: &inactive})
+	return err
+}
+
+func (h *Handler) writeGroup(c echo.Context, status int, teamName string) error {
+	team, err := h.teams.Get(c.Request().Context(), teamName)
+	if err != nil {
+		return h.serviceError(c, err)
+	}
+
+	return writeJSON(c, status, groupResource(team, location(c, "Groups", team.Name), !excludesMembers(c)))
+}
+
+// filterConditions разбирает параметр filter; без него условий нет
+func filterConditions(c echo.Context) ([]condition, error) {
+	filter := c.QueryParam("filter")
+	if filter == "" {
+		return nil, nil
+	}
+	return parseFilter(filter)
+}
+
+// listPage переводит startIndex (с единицы) и count в страницу выборки
+func listPage(c echo.Context) (models.OffsetPage, int, error) {
+	startIndex, err := queryInt(c, "startIndex", 1)
+	if err != nil {
+		return models.OffsetPage{}, 0, invalidValue("startIndex must be an integer")
+	}
+	count, err := queryInt(c, "count", defaultCount)
+	if err != nil {
+		return models.OffsetPage{}, 0, invalidValue("count must be an integer")
+	}
+
+	startIndex = max(startIndex, 1)
+	count = min(max(count, 0), maxCount)
+
+	return models.OffsetPage{Offset: startIndex - 1, Limit: count}, startIndex, nil
+}
+
+// writeList отдает страницу результатов; total - число всех ресурсов, подходящих под фильтр
+func writeList(c echo.Context, resources []any, total, startIndex int) error {
+	if resources == nil {
+		resources = []any{}
+	}
+
+	return writeJSON(c, http.StatusOK, ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func (h *Handler) serviceError(c echo.Context, err error) error {
+	var reqErr *requestError
+	switch {
+	case errors.As(err, &reqErr):
+		return writeError(c, http.StatusBadRequest, reqErr.scimType, reqErr.detail)
+	case errors.Is(err, errInvalidFilter):
+		return writeError(c, http.StatusBadRequest, "invalidFilter", err.Error())
+	case errors.Is(err, models.ErrNotFound):
+		return writeError(c, http.StatusNotFound, "", "resource not found")
+	case errors.Is(err, models.ErrUserExists), errors.Is(err, models.ErrTeamExists):
+		return writeError(c, http.StatusConflict, "uniqueness", err.Error())
+	case errors.Is(err, models.ErrTeamNotEmpty):
+		return writeError(c, http.StatusConflict, "", err.Error())
+	case errors.Is(err, models.ErrInvalidUserID),
+		errors.Is(err, models.ErrInvalidUsername),
+		errors.Is(err, models.ErrInvalidTeamName):
+		return writeError(c, http.StatusBadRequest, "invalidValue", err.Error())
+	default:
+		logpkg.FromContext(c.Request().Context()).Error("scim request failed", "error", err)
+		return writeError(c, http.StatusInternalServerError, "", "internal server error")
+	}
+}
+
+func decode(c echo.Context, v any) error {
+	if err := json.NewDecoder(c.Request().Body).Decode(v); err != nil {
+		return &requestError{scimType: "invalidSyntax", detail: "request body is not valid JSON"}
+	}
+	return nil
+}
+
+func writeJSON(c echo.Context, status int, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.Blob(status, ContentType, data)
+}
+
+func writeError(c echo.Context, status int, scimType, detail string) error {
+	return writeJSON(c, status, Error{
+		Schemas:  []string{SchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+func location(c echo.Context, resourceType, id string) string {
+	return c.Scheme() + "://" + c.Request().Host + "/scim/v2/" + resourceType + "/" + id
+}
+
+func queryInt(c echo.Context, name string, fallback int) (int, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return fallback, nil
+	}
+	return strconv.Atoi(raw)
+}
+
+// excludesMembers проверяет excludedAttributes=members, которым Azure AD запрашивает группы без состава
+func excludesMembers(c echo.Context) bool {
+	for _, attr := range strings.Split(c.QueryParam("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(attr), "members") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,354 @@
+package scim
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// store - хранилище в памяти с той же семантикой, что у сервисов пользователей и команд
+type store struct {
+	users   map[string]*models.User
+	teams   map[string]bool
+	deleted map[string]bool
+}
+
+type fakeUsers struct{ *store }
+
+type fakeTeams struct{ *store }
+
+type fakeRoster struct{ *store }
+
+func newStore() *store {
+	return &store{
+		users:   make(map[string]*models.User),
+		teams:   map[string]bool{"backend": true},
+		deleted: make(map[string]bool),
+	}
+}
+
+func (s fakeUsers) Provision(_ context.Context, user *models.User) (*models.User, error) {
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+	if _, ok := s.users[user.ID]; ok && !s.deleted[user.ID] {
+		return nil, models.ErrUserExists
+	}
+	delete(s.deleted, user.ID)
+	s.teams[user.TeamName] = true
+	created := *user
+	s.users[user.ID] = &created
+	return &created, nil
+}
+
+func (s fakeUsers) Update(_ context.Context, userID string, update *models.UserUpdate) (*models.User, error) {
+	user, ok := s.users[userID]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	if update.Username != nil {
+		user.Username = *update.Username
+	}
+	if update.TeamName != nil {
+		user.TeamName = *update.TeamName
+		s.teams[user.TeamName] = true
+	}
+	if update.IsActive != nil {
+		user.IsActive = *update.IsActive
+	}
+	updated := *user
+	return &updated, nil
+}
+
+func (s fakeUsers) Find(_ context.Context, filter models.UserFilter, page models.OffsetPage) ([]*models.User, int, error) {
+	var users []*models.User
+	for _, user := range s.users {
+		switch {
+		case s.deleted[user.ID],
+			filter.ID != "" && user.ID != filter.ID,
+			filter.Username != "" && !strings.EqualFold(user.Username, filter.Username),
+			filter.TeamName != "" && user.TeamName != filter.TeamName,
+			filter.IsActive != nil && user.IsActive != *filter.IsActive:
+			continue
+		}
+		found := *user
+		users = append(users, &found)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	start := min(page.Offset, len(users))
+	end := min(start+page.Limit, len(users))
+	return users[start:end], len(users), nil
+}
+
+func (s fakeUsers) Deprovision(ctx context.Context, userID string) error {
+	inactive := false
+	if _, err := s.Update(ctx, userID, &models.UserUpdate{IsActive: &inactive}); err != nil {
+		return err
+	}
+	s.deleted[userID] = true
+	return nil
+}
+
+func (s fakeTeams) Get(_ context.Context, teamName string) (*models.Team, error) {
+	if !s.teams[teamName] {
+		return nil, models.ErrNotFound
+	}
+	team := &models.Team{Name: teamName}
+	for _, user := range s.users {
+		if user.TeamName == teamName {
+			member := *user
+			team.Members = append(team.Members, &member)
+		}
+	}
+	sort.Slice(team.Members, func(i, j int) bool { return team.Members[i].ID < team.Members[j].ID })
+	return team, nil
+}
+
+func (s fakeTeams) Find(ctx context.Context, teamName string, page models.OffsetPage) ([]*models.Team, int, error) {
+	names := make([]string, 0, len(s.teams))
+	for name := range s.teams {
+		if teamName == "" || name == teamName {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	start := min(page.Offset, len(names))
+	end := min(start+page.Limit, len(names))
+
+	teams := make([]*models.Team, 0, end-start)
+	for _, name := range names[start:end] {
+		team, _ := s.Get(ctx, name)
+		teams = append(teams, team)
+	}
+	return teams, len(names), nil
+}
+
+func (s fakeTeams) Delete(ctx context.Context, teamName string) error {
+	team, err := s.Get(ctx, teamName)
+	if err != nil {
+		return err
+	}
+	if len(team.Members) > 0 {
+		return models.ErrTeamNotEmpty
+	}
+	delete(s.teams, teamName)
+	return nil
+}
+
+// CreateTeam ничего не меняет, если хотя бы один участник не найден
+func (s fakeRoster) CreateTeam(_ context.Context, teamName string, memberIDs []string) error {
+	if err := (&models.Team{Name: teamName}).Validate(); err != nil {
+		return err
+	}
+	if s.teams[teamName] {
+		return models.ErrTeamExists
+	}
+	for _, id := range memberIDs {
+		if _, ok := s.users[id]; !ok {
+			return models.ErrNotFound
+		}
+	}
+
+	s.teams[teamName] = true
+	for _, id := range memberIDs {
+		s.users[id].TeamName = teamName
+		s.users[id].IsActive = true
+	}
+	return nil
+}
+
+type exchange struct {
+	Name    string `json:"name"`
+	Request struct {
+		Method string          `json:"method"`
+		Path   string          `json:"path"`
+		Body   json.RawMessage `json:"body"`
+	} `json:"request"`
+	Response struct {
+		Status int             `json:"status"`
+		Body   json.RawMessage `json:"body"`
+	} `json:"response"`
+}
+
+func newTestServer(token string) *echo.Echo {
+	s := newStore()
+	e := echo.New()
+	NewHandler(fakeUsers{s}, fakeTeams{s}, fakeRoster{s}, token).Register(e.Group("/scim/v2"))
+	return e
+}
+
+// TestRecordedProvisioning проигрывает запросы, записанные с Okta и Azure AD, и сверяет
+// ответы с ожидаемыми. В ожидаемом теле указываются только проверяемые поля.
+func TestRecordedProvisioning(t *testing.T) {
+	for _, provider := range []string{"okta", "azure"} {
+		t.Run(provider, func(t *testing.T) {
+			data, err := os.ReadFile("testdata/" + provider + ".json")
+			require.NoError(t, err)
+
+			var exchanges []exchange
+			require.NoError(t, json.Unmarshal(data, &exchanges))
+
+			e := newTestServer("secret")
+			for _, ex := range exchanges {
+				req := httptest.NewRequest(ex.Request.Method, ex.Request.Path, bytes.NewReader(ex.Request.Body))
+				req.Host = "example.com"
+				req.Header.Set(echo.HeaderContentType, ContentType)
+				req.Header.Set(echo.HeaderAuthorization, "Bearer secret")
+				rec := httptest.NewRecorder()
+
+				e.ServeHTTP(rec, req)
+
+				require.Equal(t, ex.Response.Status, rec.Code, "%s: %s", ex.Name, rec.Body.String())
+				if len(ex.Response.Body) == 0 {
+					continue
+				}
+
+				assert.Equal(t, ContentType, rec.Header().Get(echo.HeaderContentType), ex.Name)
+
+				var expected, actual any
+				require.NoError(t, json.Unmarshal(ex.Response.Body, &expected), ex.Name)
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual), ex.Name)
+				assertSubset(t, ex.Name, expected, actual)
+			}
+		})
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	e := newTestServer("secret")
+
+	req := httptest.NewRequest(http.MethodGet, "/scim/v2/Users", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/scim/v2/Users", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer secret")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Без настроенного токена SCIM не открыт, а закрыт
+	req = httptest.NewRequest(http.MethodGet, "/scim/v2/Users", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer ")
+	rec = httptest.NewRecorder()
+	newTestServer("").ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func serve(t *testing.T, e *echo.Echo, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, ContentType)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer secret")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCreateGroupIsAtomic(t *testing.T) {
+	e := newTestServer("secret")
+
+	user := `{"userName": "alice", "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {"department": "backend"}}`
+	require.Equal(t, http.StatusCreated, serve(t, e, http.MethodPost, "/scim/v2/Users", user).Code)
+
+	group := `{"displayName": "frontend", "members": [{"value": "alice"}, {"value": "ghost"}]}`
+	rec := serve(t, e, http.MethodPost, "/scim/v2/Groups", group)
+	require.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	// Группа не создана, участник остался в прежней команде, повтор без ошибки проходит
+	assert.Equal(t, http.StatusNotFound, serve(t, e, http.MethodGet, "/scim/v2/Groups/frontend", "").Code)
+	assert.Contains(t, serve(t, e, http.MethodGet, "/scim/v2/Users/alice", "").Body.String(), `"department":"backend"`)
+
+	rec = serve(t, e, http.MethodPost, "/scim/v2/Groups", `{"displayName": "frontend", "members": [{"value": "alice"}]}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Contains(t, serve(t, e, http.MethodGet, "/scim/v2/Users/alice", "").Body.String(), `"department":"frontend"`)
+}
+
+func TestListPaging(t *testing.T) {
+	e := newTestServer("secret")
+
+	for _, name := range []string{"a", "b", "c"} {
+		user := `{"userName": "` + name + `", "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {"department": "backend"}}`
+		require.Equal(t, http.StatusCreated, serve(t, e, http.MethodPost, "/scim/v2/Users", user).Code)
+	}
+
+	rec := serve(t, e, http.MethodGet, "/scim/v2/Users?startIndex=2&count=1", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var list struct {
+		TotalResults int `json:"totalResults"`
+		StartIndex   int `json:"startIndex"`
+		ItemsPerPage int `json:"itemsPerPage"`
+		Resources    []struct {
+			ID string `json:"id"`
+		} `json:"Resources"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Equal(t, 3, list.TotalResults)
+	assert.Equal(t, 2, list.StartIndex)
+	assert.Equal(t, 1, list.ItemsPerPage)
+	require.Len(t, list.Resources, 1)
+	assert.Equal(t, "b", list.Resources[0].ID)
+
+	// Противоречащие условия ничего не находят
+	rec = serve(t, e, http.MethodGet, `/scim/v2/Users?filter=userName+eq+%22a%22+and+userName+eq+%22b%22`, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"totalResults":0`)
+
+	assert.Equal(t, http.StatusBadRequest, serve(t, e, http.MethodGet, "/scim/v2/Users?count=x", "").Code)
+}
+
+func TestParseFilter(t *testing.T) {
+	conditions, err := parseFilter(`userName eq "a b" and urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department eq "backend"`)
+	require.NoError(t, err)
+	assert.Equal(t, []condition{{attr: "username", value: "a b"}, {attr: "department", value: "backend"}}, conditions)
+
+	for _, filter := range []string{`userName co "a"`, `userName eq`, `userName eq "a" and`, `userName eq "a" or id eq "b"`, `userName eq "a`} {
+		_, err := parseFilter(filter)
+		assert.ErrorIs(t, err, errInvalidFilter, filter)
+	}
+}
+
+// assertSubset проверяет, что все поля expected присутствуют в actual с теми же значениями
+func assertSubset(t *testing.T, name string, expected, actual any) {
+	t.Helper()
+
+	switch exp := expected.(type) {
+	case map[string]any:
+		act, ok := actual.(map[string]any)
+		if !assert.True(t, ok, "%s: expected object, got %v", name, actual) {
+			return
+		}
+		for key, value := range exp {
+			if assert.Contains(t, act, key, name) {
+				assertSubset(t, name+"."+key, value, act[key])
+			}
+		}
+	case []any:
+		act, ok := actual.([]any)
+		if !assert.True(t, ok, "%s: expected array, got %v", name, actual) || !assert.Len(t, act, len(exp), name) {
+			return
+		}
+		for i := range exp {
+			assertSubset(t, name, exp[i], act[i])
+		}
+	default:
+		assert.Equal(t, expected, actual, name)
+	}
+}
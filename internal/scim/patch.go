@@ -0,0 +1,237 @@
+package scim
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+)
+
+// requestError - ошибка в теле запроса, отдается клиенту как 400 с указанным scimType
+type requestError struct {
+	scimType string
+	detail   string
+}
+
+func (e *requestError) Error() string {
+	return e.detail
+}
+
+func invalidValue(format string, args ...any) error {
+	return &requestError{scimType: "invalidValue", detail: fmt.Sprintf(format, args...)}
+}
+
+// userPatch собирает изменения пользователя из операций PATCH. Атрибуты, которые
+// сервис не хранит (name, emails и т.п.), пропускаются.
+func userPatch(ops []PatchOperation) (*models.UserUpdate, error) {
+	update := &models.UserUpdate{}
+
+	for _, op := range ops {
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+			if op.Path != "" {
+				if err := applyUserAttr(update, normalizeAttr(op.Path), op.Value); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			values, ok := op.Value.(map[string]any)
+			if !ok {
+				return nil, invalidValue("operation without path must have an object value")
+			}
+			if err := applyUserAttrs(update, values); err != nil {
+				return nil, err
+			}
+		case "remove":
+			switch normalizeAttr(op.Path) {
+			case "username", "active", "department":
+				return nil, &requestError{scimType: "mutability", detail: fmt.Sprintf("attribute %q cannot be removed", op.Path)}
+			}
+		default:
+			return nil, invalidValue("unsupported patch operation %q", op.Op)
+		}
+	}
+
+	return update, nil
+}
+
+func applyUserAttrs(update *models.UserUpdate, values map[string]any) error {
+	for key, value := range values {
+		attr := normalizeAttr(key)
+		if attr == strings.ToLower(SchemaEnterpriseUser) {
+			extension, ok := value.(map[string]any)
+			if !ok {
+				return invalidValue("enterprise extension must be an object")
+			}
+			if err := applyUserAttrs(update, extension); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := applyUserAttr(update, attr, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func applyUserAttr(update *models.UserUpdate, attr string, value any) error {
+	switch attr {
+	case "active":
+		active, err := boolValue(value)
+		if err != nil {
+			return err
+		}
+		update.IsActive = &active
+	case "username":
+		username, ok := value.(string)
+		if !ok || username == "" {
+			return invalidValue("userName must be a non-empty string")
+		}
+		update.Username = &username
+	case "department":
+		department, ok := value.(string)
+		if !ok || department == "" {
+			return invalidValue("department must be a non-empty string")
+		}
+		update.TeamName = &department
+	}
+
+	return nil
+}
+
+// boolValue принимает как JSON boolean, так и строки "True"/"False", которые присылает Azure AD
+func boolValue(value any) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		switch strings.ToLower(v) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+	return false, invalidValue("active must be a boolean")
+}
+
+// groupPatch - изменения состава группы из операций PATCH
+type groupPatch struct {
+	displayName *string
+	add         []string
+	remove      []string
+	removeAll   bool
+	replace     []string
+	replaceSet  bool
+}
+
+func parseGroupPatch(ops []PatchOperation) (*groupPatch, error) {
+	patch := &groupPatch{}
+
+	for _, op := range ops {
+		opName := strings.ToLower(op.Op)
+		path := strings.ToLower(op.Path)
+
+		switch {
+		case opName == "remove" && path == "members":
+			if op.Value == nil {
+				patch.removeAll = true
+				continue
+			}
+			ids, err := memberValues(op.Value)
+			if err != nil {
+				return nil, err
+			}
+			patch.remove = append(patch.remove, ids...)
+		case opName == "remove" && strings.HasPrefix(path, "members[") && strings.HasSuffix(path, "]"):
+			conditions, err := parseFilter(op.Path[len("members[") : len(op.Path)-1])
+			if err != nil {
+				return nil, err
+			}
+			for _, cond := range conditions {
+				if cond.attr != "value" {
+					return nil, errInvalidFilter
+				}
+				patch.remove = append(patch.remove, cond.value)
+			}
+		case (opName == "add" || opName == "replace") && path == "members":
+			ids, err := memberValues(op.Value)
+			if err != nil {
+				return nil, err
+			}
+			patch.addMembers(opName, ids)
+		case (opName == "add" || opName == "replace") && path == "displayname":
+			name, ok := op.Value.(string)
+			if !ok {
+				return nil, invalidValue("displayName must be a string")
+			}
+			patch.displayName = &name
+		case (opName == "add" || opName == "replace") && path == "":
+			values, ok := op.Value.(map[string]any)
+			if !ok {
+				return nil, invalidValue("operation without path must have an object value")
+			}
+			for key, value := range values {
+				switch strings.ToLower(key) {
+				case "members":
+					ids, err := memberValues(value)
+					if err != nil {
+						return nil, err
+					}
+					patch.addMembers(opName, ids)
+				case "displayname":
+					name, ok := value.(string)
+					if !ok {
+						return nil, invalidValue("displayName must be a string")
+					}
+					patch.displayName = &name
+				}
+			}
+		default:
+			return nil, invalidValue("unsupported group patch operation %q on %q", op.Op, op.Path)
+		}
+	}
+
+	return patch, nil
+}
+
+func (p *groupPatch) addMembers(op string, ids []string) {
+	if op == "replace" {
+		p.replace = ids
+		p.replaceSet = true
+		return
+	}
+	p.add = append(p.add, ids...)
+}
+
+// memberValues достает идентификаторы из списка вида [{"value": "u1"}]
+func memberValues(value any) ([]string, error) {
+	var items []any
+	switch v := value.(type) {
+	case []any:
+		items = v
+	case map[string]any:
+		items = []any{v}
+	default:
+		return nil, invalidValue("members must be a list of objects with value")
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		member, ok := item.(map[string]any)
+		if !ok {
+			return nil, invalidValue("members must be a list of objects with value")
+		}
+		id, ok := member["value"].(string)
+		if !ok || id == "" {
+			return nil, invalidValue("member value must be a non-empty string")
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,153 @@
+package scim
+
+import (
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+)
+
+const (
+	SchemaUser           = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaEnterpriseUser = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	SchemaGroup          = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse   = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp        = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError          = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SchemaServiceConfig  = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+
+	// ContentType - тип содержимого ответов SCIM (RFC 7644, раздел 3.1)
+	ContentType = "application/scim+json"
+)
+
+type Meta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+type EnterpriseUser struct {
+	Department string `json:"department,omitempty"`
+}
+
+// User - ресурс пользователя. Команда пользователя передается как department
+// из расширения enterprise.
+type User struct {
+	Schemas     []string        `json:"schemas"`
+	ID          string          `json:"id,omitempty"`
+	ExternalID  string          `json:"externalId,omitempty"`
+	UserName    string          `json:"userName"`
+	DisplayName string          `json:"displayName,omitempty"`
+	Active      *bool           `json:"active,omitempty"`
+	Enterprise  *EnterpriseUser `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	Meta        *Meta           `json:"meta,omitempty"`
+}
+
+type GroupMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// Group - ресурс группы, соответствующий команде. Идентификатор группы совпадает с названием команды.
+type Group struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id,omitempty"`
+	DisplayName string        `json:"displayName"`
+	Members     []GroupMember `json:"members,omitempty"`
+	Meta        *Meta         `json:"meta,omitempty"`
+}
+
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// userResource переводит пользователя в ресурс SCIM
+func userResource(user *models.User, location string) *User {
+	active := user.IsActive
+	resource := &User{
+		Schemas:     []string{SchemaUser, SchemaEnterpriseUser},
+		ID:          user.ID,
+		ExternalID:  user.ID,
+		UserName:    user.Username,
+		DisplayName: user.Username,
+		Active:      &active,
+		Enterprise:  &EnterpriseUser{Department: user.TeamName},
+		Meta:        &Meta{ResourceType: "User", Location: location},
+	}
+
+	if !user.CreatedAt.IsZero() {
+		resource.Meta.Created = &user.CreatedAt
+	}
+	if !user.UpdatedAt.IsZero() {
+		resource.Meta.LastModified = &user.UpdatedAt
+	}
+
+	return resource
+}
+
+// groupResource переводит команду в ресурс SCIM. Участниками группы считаются
+// только активные участники команды: исключение из группы деактивирует пользователя.
+func groupResource(team *models.Team, location string, withMembers bool) *Group {
+	resource := &Group{
+		Schemas:     []string{SchemaGroup},
+		ID:          team.Name,
+		DisplayName: team.Name,
+		Meta:        &Meta{ResourceType: "Group", Location: location},
+	}
+
+	if withMembers {
+		for _, member := range team.Members {
+			if member.IsActive {
+				resource.Members = append(resource.Members, GroupMember{Value: member.ID, Display: member.Username})
+			}
+		}
+	}
+
+	if !team.CreatedAt.IsZero() {
+		resource.Meta.Created = &team.CreatedAt
+	}
+	if !team.UpdatedAt.IsZero() {
+		resource.Meta.LastModified = &team.UpdatedAt
+	}
+
+	return resource
+}
+
+// toModel переводит ресурс SCIM в пользователя. Идентификатором становится externalId,
+// а если его нет, то userName.
+func (u *User) toModel() *models.User {
+	user := &models.User{
+		ID:       u.ExternalID,
+		Username: u.UserName,
+		IsActive: u.Active == nil || *u.Active,
+	}
+	if user.ID == "" {
+		user.ID = u.UserName
+	}
+	if u.Enterprise != nil {
+		user.TeamName = u.Enterprise.Department
+	}
+	return user
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/vnchk1/pr-manager/internal/i18n"
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/roster"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ImportRosterResponse struct {
+	BaseResponse
+	DryRun bool               `json:"dry_run"`
+	Diff   *models.RosterDiff `json:"diff"`
+}
+
+type ReconcileRosterResponse struct {
+	BaseResponse
+	DryRun bool                  `json:"dry_run"`
+	Plan   *models.ReconcilePlan `json:"plan"`
+}
+
+// rosterFormat берет формат из параметра format, иначе из заголовка Content-Type
+func rosterFormat(c echo.Context) (string, error) {
+	format := c.QueryParam("format")
+	if format == "" {
+		return roster.FormatFromContentType(c.Request().Header.Get(echo.HeaderContentType)), nil
+	}
+
+	switch format {
+	case roster.FormatJSON, roster.FormatYAML, roster.FormatCSV:
+		return format, nil
+	default:
+		return "", models.ErrInvalidRosterFormat
+	}
+}
+
+// readRosterRequest разбирает тело запроса с составом команд и параметр dry_run.
+// При ошибке ответ уже отправлен клиенту и возвращается ok == false.
+func readRosterRequest(c echo.Context) (desired *models.Roster, dryRun bool, ok bool, err error) {
+	format, err := rosterFormat(c)
+	if err != nil {
+		return nil, false, false, errorJSON(c, http.StatusBadRequest, i18n.UnsupportedRosterFormat, err)
+	}
+
+	if raw := c.QueryParam("dry_run"); raw != "" {
+		dryRun, err = strconv.ParseBool(raw)
+		if err != nil {
+			return nil, false, false, errorJSON(c, http.StatusBadRequest, i18n.InvalidDryRun, err)
+		}
+	}
+
+	desired, err = roster.Decode(format, c.Request().Body)
+	if err != nil {
+		return nil, false, false, errorJSON(c, http.StatusBadRequest, i18n.InvalidRoster, err)
+	}
+
+	return desired, dryRun, true, nil
+}
+
+func (s *Server) importRoster(c echo.Context) error {
+	desired, dryRun, ok, err := readRosterRequest(c)
+	if !ok {
+		return err
+	}
+
+	diff, err := s.service.Roster.Import(c.Request().Context(), desired, dryRun)
+	if err != nil {
+		if errors.Is(err, models.ErrDuplicateRosterUser) {
+			return errorJSON(c, http.StatusBadRequest, i18n.DuplicateRosterUser, err)
+		}
+		return errorJSON(c, http.StatusInternalServerError, i18n.ImportRosterFailed, err)
+	}
+
+	message := i18n.RosterImported
+	if dryRun {
+		message = i18n.RosterImportPlanned
+	}
+
+	return c.JSON(http.StatusOK, ImportRosterResponse{
+		BaseResponse: BaseResponse{
+			Success: true,
+			Message: msg(c, message),
+		},
+		DryRun: dryRun,
+		Diff:   diff,
+	})
+}
+
+func (s *Server) reconcileRoster(c echo.Context) error {
+	desired, dryRun, ok, err := readRosterRequest(c)
+	if !ok {
+		return err
+	}
+
+	plan, err := s.service.Roster.Reconcile(c.Request().Context(), desired, dryRun)
+	if err != nil {
+		if errors.Is(err, models.ErrDuplicateRosterUser) {
+			return errorJSON(c, http.StatusBadRequest, i18n.DuplicateRosterUser, err)
+		}
+		if errors.Is(err, models.ErrVersionConflict) {
+			return errorJSON(c, http.StatusConflict, i18n.PRVersionConflict, err)
+		}
+		return errorJSON(c, http.StatusInternalServerError, i18n.ReconcileRosterFailed, err)
+	}
+
+	message := i18n.RosterReconciled
+	if dryRun {
+		message = i18n.RosterReconcilePlanned
+	}
+
+	return c.JSON(http.StatusOK, ReconcileRosterResponse{
+		BaseResponse: BaseResponse{
+			Success: true,
+			Message: msg(c, message),
+		},
+		DryRun: dryRun,
+		Plan:   plan,
+	})
+}
+
+func (s *Server) exportRoster(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format == "" {
+		format = roster.FormatJSON
+	}
+
+	current, err := s.service.Roster.Export(c.Request().Context())
+	if err != nil {
+		return errorJSON(c, http.StatusInternalServerError, i18n.ExportRosterFailed, err)
+	}
+
+	var buf bytes.Buffer
+	if err = roster.Encode(format, &buf, current); err != nil {
+		if errors.Is(err, models.ErrInvalidRosterFormat) {
+			return errorJSON(c, http.StatusBadRequest, i18n.UnsupportedRosterFormat, err)
+		}
+		return errorJSON(c, http.StatusInternalServerError, i18n.ExportRosterFailed, err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, "attachment; filename=roster."+format)
+	return c.Blob(http.StatusOK, roster.ContentType(format), buf.Bytes())
+}
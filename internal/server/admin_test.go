@@ -0,0 +1,51 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminRoutesRequireToken(t *testing.T) {
+	request := func(s *Server, authorization string) int {
+		req := httptest.NewRequest(http.MethodPost, "/admin/import?format=xml", nil)
+		if authorization != "" {
+			req.Header.Set(echo.HeaderAuthorization, authorization)
+		}
+		rec := httptest.NewRecorder()
+		s.echo.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Без ADMIN_TOKEN маршруты /admin и /scim/v2 не подключены
+	open := newTestServer(t)
+	assert.Equal(t, http.StatusNotFound, request(open, ""))
+	assert.Equal(t, http.StatusNotFound, serve(open, http.MethodGet, "/scim/v2/Users", "").Code)
+
+	s := newTestServer(t, WithAdminToken("secret"))
+	assert.Equal(t, http.StatusUnauthorized, request(s, ""))
+	assert.Equal(t, http.StatusUnauthorized, request(s, "Bearer wrong"))
+	// Запрос прошел проверку токена и отклонен уже обработчиком
+	assert.Equal(t, http.StatusBadRequest, request(s, "Bearer secret"))
+}
+
+func TestPreferencesRequireAdminToken(t *testing.T) {
+	open := newTestServer(t)
+	s := newTestServer(t, WithAdminToken(testAdminToken))
+	body := `{"user_id":"u1","email":"attacker@evil.example"}`
+
+	// Без ADMIN_TOKEN настройки недоступны никому
+	assert.Equal(t, http.StatusUnauthorized, serveAdmin(open, http.MethodGet, "/users/preferences?user_id=u1", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serveAdmin(open, http.MethodPut, "/users/preferences", body).Code)
+
+	assert.Equal(t, http.StatusUnauthorized, serve(s, http.MethodGet, "/users/preferences?user_id=u1", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(s, http.MethodPut, "/users/preferences", body).Code)
+	assert.Equal(t, http.StatusUnauthorized,
+		serveWithHeader(s, http.MethodPut, "/users/preferences", body, echo.HeaderAuthorization, "Bearer wrong").Code)
+
+	assert.Equal(t, http.StatusOK, serveAdmin(s, http.MethodGet, "/users/preferences?user_id=u1", "").Code)
+	assert.Equal(t, http.StatusOK, serveAdmin(s, http.MethodPut, "/users/preferences", body).Code)
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	headerETag    = "ETag"
+	headerIfMatch = "If-Match"
+)
+
+// errETagMismatch - If-Match не может совпасть ни с одной версией PR
+var errETagMismatch = errors.New("precondition does not match any pull request version")
+
+// setETag отдает версию PR в ETag, чтобы клиент мог передать ее в If-Match
+func setETag(c echo.Context, version int) {
+	c.Response().Header().Set(headerETag, strconv.Quote(strconv.Itoa(version)))
+}
+
+// ifMatchVersion возвращает версию PR из If-Match; 0, если заголовка нет или
+// в нем "*". Слабый или чужой ETag не совпадает ни с одной версией.
+func ifMatchVersion(c echo.Context) (int, error) {
+	ifMatch := strings.TrimSpace(c.Request().Header.Get(headerIfMatch))
+	if ifMatch == "" || ifMatch == "*" {
+		return 0, nil
+	}
+
+	unquoted, err := strconv.Unquote(ifMatch)
+	if err != nil {
+		return 0, errETagMismatch
+	}
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version < 1 {
+		return 0, errETagMismatch
+	}
+
+	return version, nil
+}
+
+// conflictStatus - статус ответа на ErrVersionConflict: 412, если клиент сам передал
+// версию в If-Match, и 409, если PR изменили параллельно во время запроса
+func conflictStatus(c echo.Context) int {
+	if c.Request().Header.Get(headerIfMatch) != "" {
+		return http.StatusPreconditionFailed
+	}
+	return http.StatusConflict
+}
+
+// v1PullRequestError дополняет v1ServiceError статусом конфликта версий
+func v1PullRequestError(c echo.Context, err error) error {
+	if errors.Is(err, models.ErrVersionConflict) || errors.Is(err, errETagMismatch) {
+		return v1Fail(c, conflictStatus(c), CodeVersionConflict, models.ErrVersionConflict.Error(), err)
+	}
+	return v1ServiceError(c, err)
+}
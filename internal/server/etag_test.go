@@ -0,0 +1,106 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveIfMatch(s *Server, method, path, body, ifMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	if ifMatch != "" {
+		req.Header.Set(headerIfMatch, ifMatch)
+	}
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPullRequestETag(t *testing.T) {
+	s := newTestServer(t)
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		status int
+		etag   string
+	}{
+		{http.MethodGet, "/api/v1/pull-requests/pr-1", "", http.StatusOK, `"3"`},
+		{http.MethodPost, "/api/v1/pull-requests", `{"pull_request_id":"pr-9","pull_request_name":"Fix","author_id":"u1"}`, http.StatusCreated, `"3"`},
+		{http.MethodPost, "/api/v1/pull-requests/pr-1/merge", "", http.StatusOK, `"4"`},
+		{http.MethodPost, "/api/v1/pull-requests/pr-1/reviewers/u2/reassign", "", http.StatusOK, `"4"`},
+		{http.MethodGet, "/pullRequest/get?pull_request_id=pr-1", "", http.StatusOK, `"3"`},
+		{http.MethodPost, "/pullRequest/merge", `{"pull_request_id":"pr-1"}`, http.StatusOK, `"4"`},
+		{http.MethodPost, "/pullRequest/reassign", `{"pull_request_id":"pr-1","old_user_id":"u2"}`, http.StatusOK, `"4"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			rec := serve(s, tt.method, tt.path, tt.body)
+			require.Equal(t, tt.status, rec.Code, rec.Body.String())
+			assert.Equal(t, tt.etag, rec.Header().Get(headerETag))
+		})
+	}
+}
+
+func TestV1IfMatch(t *testing.T) {
+	s := newTestServer(t)
+
+	tests := []struct {
+		name    string
+		path    string
+		ifMatch string
+		status  int
+	}{
+		{"Current version", "/api/v1/pull-requests/pr-1/merge", `"3"`, http.StatusOK},
+		{"Any version", "/api/v1/pull-requests/pr-1/merge", "*", http.StatusOK},
+		{"Stale version", "/api/v1/pull-requests/pr-1/merge", `"2"`, http.StatusPreconditionFailed},
+		{"Weak ETag", "/api/v1/pull-requests/pr-1/merge", `W/"3"`, http.StatusPreconditionFailed},
+		{"Unquoted ETag", "/api/v1/pull-requests/pr-1/reviewers/u2/reassign", "3", http.StatusPreconditionFailed},
+		{"Stale reassign", "/api/v1/pull-requests/pr-1/reviewers/u2/reassign", `"2"`, http.StatusPreconditionFailed},
+		{"Concurrent change", "/api/v1/pull-requests/pr-concurrent/reviewers/u2/reassign", "", http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveIfMatch(s, http.MethodPost, tt.path, "", tt.ifMatch)
+			require.Equal(t, tt.status, rec.Code, rec.Body.String())
+			if tt.status == http.StatusOK {
+				return
+			}
+
+			var resp V1ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, CodeVersionConflict, resp.Error.Code)
+			assert.Empty(t, rec.Header().Get(headerETag))
+		})
+	}
+}
+
+func TestLegacyIfMatch(t *testing.T) {
+	s := newTestServer(t)
+
+	rec := serveIfMatch(s, http.MethodPost, "/pullRequest/reassign", `{"pull_request_id":"pr-1","old_user_id":"u2"}`, `"2"`)
+	require.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.False(t, resp.Success)
+	assert.Contains(t, resp.Message, "изменен другим запросом")
+
+	rec = serveIfMatch(s, http.MethodPost, "/pullRequest/reassign", `{"pull_request_id":"pr-concurrent","old_user_id":"u2"}`, "")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = serveIfMatch(s, http.MethodPost, "/pullRequest/merge", `{"pull_request_id":"pr-1"}`, `"3"`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"4"`, rec.Header().Get(headerETag))
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/events"
+	"github.com/vnchk1/pr-manager/internal/i18n"
+	logpkg "github.com/vnchk1/pr-manager/internal/logger"
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	headerLastEventID = "Last-Event-ID"
+
+	defaultEventHeartbeat = 15 * time.Second
+)
+
+// WithEventStream включает SSE-поток /events/stream. Раз в heartbeat в поток пишется
+// комментарий, чтобы прокси не закрывали простаивающее соединение.
+func WithEventStream(bus *events.Bus, heartbeat time.Duration) Option {
+	return func(s *Server) {
+		s.events = bus
+		s.eventHeartbeat = heartbeat
+		if s.eventHeartbeat <= 0 {
+			s.eventHeartbeat = defaultEventHeartbeat
+		}
+	}
+}
+
+// streamEvents отдает события в формате text/event-stream. С заголовком Last-Event-ID
+// сначала догоняет пропущенные события по журналу, затем передает новые.
+func (s *Server) streamEvents(c echo.Context) error {
+	filter := models.EventFilter{
+		TeamName: c.QueryParam("team_name"),
+		UserID:   c.QueryParam("user_id"),
+	}
+
+	var lastID int64
+	if raw := c.Request().Header.Get(headerLastEventID); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id < 0 {
+			return errorJSON(c, http.StatusBadRequest, i18n.InvalidLastEventID, err)
+		}
+		lastID = id
+	}
+
+	// Подписка оформляется до чтения журнала, чтобы не потерять события,
+	// записанные во время догонки
+	sub := s.events.Subscribe(filter)
+	defer s.events.Unsubscribe(sub)
+
+	w := c.Response()
+	w.Header().Set(echo.HeaderContentType, "text/event-stream")
+	w.Header().Set(echo.HeaderCacheControl, "no-cache")
+	w.Header().Set(echo.HeaderConnection, "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	w.Flush()
+
+	ctx := c.Request().Context()
+	logger := logpkg.FromContext(ctx)
+
+	// Живые события могут прийти не по возрастанию ID (см. events.Bus.Publish),
+	// поэтому отбрасываются только те, что уже отданы из журнала
+	replayedTo := lastID
+	if lastID > 0 {
+		var err error
+		replayedTo, err = s.events.Replay(ctx, lastID, filter, func(event *models.Event) error {
+			return writeEvent(w, event)
+		})
+		if err != nil {
+			logger.Error("failed to replay events", "error", err)
+			return nil
+		}
+	}
+
+	heartbeat := time.NewTicker(s.eventHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.closing:
+			return nil
+		case event, ok := <-sub.Events():
+			if !ok {
+				// Клиент отстал и отписан; EventSource переподключится с Last-Event-ID
+				return nil
+			}
+			if event.ID <= replayedTo {
+				continue
+			}
+			if err := writeEvent(w, event); err != nil {
+				return nil
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			w.Flush()
+		}
+	}
+}
+
+func writeEvent(w *echo.Response, event *models.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+		return err
+	}
+	w.Flush()
+	return nil
+}
@@ -0,0 +1,224 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/events"
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memEventStore struct {
+	mu     sync.Mutex
+	events []*models.Event
+}
+
+func (s *memEventStore) Append(_ context.Context, event *models.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event.ID = int64(len(s.events) + 1)
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *memEventStore) ListAfter(_ context.Context, afterID int64, filter models.EventFilter, limit int) ([]*models.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []*models.Event
+	for _, event := range s.events {
+		if event.ID > afterID && filter.Match(event) && len(result) < limit {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func (s *memEventStore) DeleteBefore(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+type sseMessage struct {
+	id, event string
+	data      models.Event
+}
+
+// readStream разбирает text/event-stream; комментарии передаются с пустым id
+func readStream(t *testing.T, resp *http.Response) <-chan sseMessage {
+	t.Helper()
+
+	messages := make(chan sseMessage)
+	go func() {
+		defer close(messages)
+
+		var msg sseMessage
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, ": "):
+				messages <- sseMessage{event: strings.TrimPrefix(line, ": ")}
+			case strings.HasPrefix(line, "id: "):
+				msg.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				msg.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				_ = json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &msg.data)
+			case line == "" && msg.id != "":
+				messages <- msg
+				msg = sseMessage{}
+			}
+		}
+	}()
+	return messages
+}
+
+// nextEvent пропускает пульсы и возвращает следующее событие
+func nextEvent(t *testing.T, messages <-chan sseMessage) sseMessage {
+	t.Helper()
+
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case msg, ok := <-messages:
+			require.True(t, ok, "stream closed")
+			if msg.id != "" {
+				return msg
+			}
+		case <-timeout:
+			t.Fatal("no event received")
+		}
+	}
+}
+
+func openStream(t *testing.T, url, lastEventID string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set(headerLastEventID, lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func prEvent(eventType models.EventType, prID, teamName string) *models.Event {
+	return models.NewPREvent(eventType, teamName, models.PREventData{
+		PullRequest: &models.PullRequest{ID: prID, AuthorID: "u1", Status: models.StatusOpen, AssignedReviewers: []string{"u2"}},
+	})
+}
+
+func TestEventStreamResumesFromLastEventID(t *testing.T) {
+	bus := events.NewBus(&memEventStore{})
+	s := newTestServer(t, WithEventStream(bus, time.Hour))
+	ts := httptest.NewServer(s.echo)
+	t.Cleanup(ts.Close)
+
+	ctx := context.Background()
+	bus.Publish(ctx, prEvent(models.EventPRCreated, "pr-1", "backend"))
+	bus.Publish(ctx, prEvent(models.EventPRCreated, "pr-2", "backend"))
+	bus.Publish(ctx, prEvent(models.EventPRCreated, "pr-3", "frontend"))
+
+	resp := openStream(t, ts.URL+"/events/stream?team_name=backend", "1")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+	messages := readStream(t, resp)
+
+	// Из журнала: после события 1 в команде backend есть только событие 2
+	replayed := nextEvent(t, messages)
+	assert.Equal(t, "2", replayed.id)
+	assert.Equal(t, "pr.created", replayed.event)
+	assert.Equal(t, "backend", replayed.data.TeamName)
+	assert.JSONEq(t, `{"pull_request": {"pull_request_id": "pr-2", "pull_request_name": "", "author_id": "u1", "status": "OPEN",
+		"assigned_reviewers": ["u2"], "created_at": "0001-01-01T00:00:00Z", "updated_at": "0001-01-01T00:00:00Z",
+		"version": 0}}`,
+		string(replayed.data.Data))
+
+	bus.Publish(ctx, prEvent(models.EventPRMerged, "pr-2", "frontend"))
+	bus.Publish(ctx, prEvent(models.EventPRMerged, "pr-1", "backend"))
+
+	live := nextEvent(t, messages)
+	assert.Equal(t, "5", live.id)
+	assert.Equal(t, "pr.merged", live.event)
+}
+
+func TestEventStreamFiltersByUser(t *testing.T) {
+	bus := events.NewBus(&memEventStore{})
+	s := newTestServer(t, WithEventStream(bus, time.Hour))
+	ts := httptest.NewServer(s.echo)
+	t.Cleanup(ts.Close)
+
+	messages := readStream(t, openStream(t, ts.URL+"/events/stream?user_id=u3", ""))
+
+	ctx := context.Background()
+	bus.Publish(ctx, prEvent(models.EventPRCreated, "pr-1", "backend"))
+	bus.Publish(ctx, models.NewPREvent(models.EventPRReassigned, "backend", models.PREventData{
+		PullRequest: &models.PullRequest{ID: "pr-1", AuthorID: "u1", AssignedReviewers: []string{"u4"}},
+		OldReviewer: "u3",
+		NewReviewer: "u4",
+	}))
+
+	msg := nextEvent(t, messages)
+	assert.Equal(t, "2", msg.id, "only events involving u3, including as the removed reviewer")
+	assert.Equal(t, []string{"u1", "u4", "u3"}, msg.data.UserIDs)
+}
+
+func TestEventStreamHeartbeatAndShutdown(t *testing.T) {
+	bus := events.NewBus(&memEventStore{})
+	s := newTestServer(t, WithEventStream(bus, 10*time.Millisecond))
+	ts := httptest.NewServer(s.echo)
+	t.Cleanup(ts.Close)
+
+	messages := readStream(t, openStream(t, ts.URL+"/events/stream", ""))
+
+	select {
+	case msg := <-messages:
+		assert.Equal(t, "heartbeat", msg.event)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no heartbeat received")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_ = s.Shutdown(ctx)
+
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case _, ok := <-messages:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("stream is not closed on shutdown")
+		}
+	}
+}
+
+func TestEventStreamRejectsInvalidLastEventID(t *testing.T) {
+	s := newTestServer(t, WithEventStream(events.NewBus(&memEventStore{}), time.Hour))
+
+	req := httptest.NewRequest(http.MethodGet, "/events/stream", nil)
+	req.Header.Set(headerLastEventID, "abc")
+	req.Header.Set("Accept-Language", "en")
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Last-Event-ID header must be a non-negative integer")
+}
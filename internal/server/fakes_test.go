@@ -0,0 +1,273 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/service"
+)
+
+// Заглушки сервисов возвращают фиксированные данные: обработчики проверяются
+// без базы, а ответы - на соответствие контракту
+type fakeTeamService struct {
+	teams map[string]*models.Team
+}
+
+func (s fakeTeamService) Create(_ context.Context, team *models.Team) (*models.Team, error) {
+	if err := team.Validate(); err != nil {
+		return nil, err
+	}
+	if _, ok := s.teams[team.Name]; ok {
+		return nil, models.ErrTeamExists
+	}
+	s.teams[team.Name] = team
+	return team, nil
+}
+
+func (s fakeTeamService) Get(_ context.Context, teamName string) (*models.Team, error) {
+	team, ok := s.teams[teamName]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return team, nil
+}
+
+func (s fakeTeamService) GetByNames(_ context.Context, teamNames []string) ([]*models.Team, error) {
+	var teams []*models.Team
+	for _, name := range teamNames {
+		if team, ok := s.teams[name]; ok {
+			teams = append(teams, team)
+		}
+	}
+	return teams, nil
+}
+
+func (s fakeTeamService) List(_ context.Context) ([]*models.Team, error) {
+	return []*models.Team{s.teams["backend"]}, nil
+}
+
+func (s fakeTeamService) Find(ctx context.Context, _ string, _ models.OffsetPage) ([]*models.Team, int, error) {
+	teams, err := s.List(ctx)
+	return teams, len(teams), err
+}
+
+func (s fakeTeamService) Delete(ctx context.Context, teamName string) error {
+	team, err := s.Get(ctx, teamName)
+	if err != nil {
+		return err
+	}
+	if len(team.Members) > 0 {
+		return models.ErrTeamNotEmpty
+	}
+	delete(s.teams, teamName)
+	return nil
+}
+
+var (
+	fakeTime = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	fakeUser = &models.User{ID: "u1", Username: "Alice", TeamName: "backend", IsActive: true, CreatedAt: fakeTime, UpdatedAt: fakeTime}
+	fakePR   = &models.PullRequest{
+		ID:                "pr-1",
+		Name:              "Add search",
+		AuthorID:          "u1",
+		Status:            models.StatusOpen,
+		AssignedReviewers: []string{"u2"},
+		CreatedAt:         fakeTime,
+		UpdatedAt:         fakeTime,
+		Version:           3,
+	}
+)
+
+type fakeUserService struct {
+	service.UserService
+}
+
+func (fakeUserService) Create(_ context.Context, user *models.User) (*models.User, error) {
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (fakeUserService) GetByID(_ context.Context, userID string) (*models.User, error) {
+	if userID != fakeUser.ID {
+		return nil, models.ErrNotFound
+	}
+	return fakeUser, nil
+}
+
+func (s fakeUserService) Update(ctx context.Context, userID string, update *models.UserUpdate) (*models.User, error) {
+	user, err := s.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	updated := *user
+	if update.IsActive != nil {
+		updated.IsActive = *update.IsActive
+	}
+	return &updated, nil
+}
+
+func (s fakeUserService) SetActive(ctx context.Context, userID string, isActive bool) (*models.User, error) {
+	return s.Update(ctx, userID, &models.UserUpdate{IsActive: &isActive})
+}
+
+func (fakeUserService) List(_ context.Context) ([]*models.User, error) {
+	return []*models.User{fakeUser}, nil
+}
+
+func (fakeUserService) GetReviewPRs(
+	_ context.Context,
+	userID string,
+	_ models.PullRequestStatus,
+	page models.PageRequest,
+) (*models.ReviewQueue, error) {
+	if userID != fakeUser.ID {
+		return nil, models.ErrNotFound
+	}
+	prs := []*models.ReviewPR{
+		{PullRequestShort: models.PullRequestShort{ID: "pr-1", Name: "Add search", AuthorID: "u2", Status: models.StatusOpen}, ReviewState: models.ReviewPending},
+		{PullRequestShort: models.PullRequestShort{ID: "pr-2", Name: "Fix login", AuthorID: "u2", Status: models.StatusMerged}, ReviewState: models.ReviewApproved},
+	}
+
+	var next string
+	if page.Limit > 0 && len(prs) > page.Limit {
+		prs = prs[:page.Limit]
+		next = models.TimeCursor(fakePR.CreatedAt, prs[page.Limit-1].ID).Encode()
+	}
+
+	queue := models.NewReviewQueue(prs)
+	queue.NextCursor = next
+	return queue, nil
+}
+
+type fakePRService struct {
+	service.PRService
+}
+
+func (fakePRService) Create(_ context.Context, req *models.PRCreateRequest) (*models.PullRequest, error) {
+	pr := *fakePR
+	pr.ID, pr.Name, pr.AuthorID = req.ID, req.Name, req.AuthorID
+	return &pr, nil
+}
+
+func (fakePRService) Merge(_ context.Context, req *models.PRMergeRequest) (*models.PullRequest, error) {
+	if req.Version != 0 && req.Version != fakePR.Version {
+		return nil, models.ErrVersionConflict
+	}
+	pr := *fakePR
+	mergedAt := fakeTime.Add(time.Hour)
+	pr.ID, pr.Status, pr.MergedAt = req.ID, models.StatusMerged, &mergedAt
+	pr.Version++
+	return &pr, nil
+}
+
+// ReassignReviewer для pr-concurrent ведет себя так, будто PR изменили во время запроса
+func (fakePRService) ReassignReviewer(_ context.Context, req *models.PRReassignRequest) (*models.PullRequest, string, error) {
+	switch {
+	case req.ID == "pr-merged":
+		return nil, "", models.ErrPRMerged
+	case req.ID == "pr-concurrent", req.Version != 0 && req.Version != fakePR.Version:
+		return nil, "", models.ErrVersionConflict
+	}
+	pr := *fakePR
+	pr.ID, pr.AssignedReviewers = req.ID, []string{"u3"}
+	pr.Version++
+	return &pr, "u3", nil
+}
+
+func (fakePRService) GetDetails(_ context.Context, prID string) (*models.PullRequestDetails, error) {
+	if prID != fakePR.ID {
+		return nil, models.ErrNotFound
+	}
+	return &models.PullRequestDetails{
+		PullRequest:    *fakePR,
+		AuthorUsername: "Alice",
+		Reviewers:      []*models.PRReviewer{{UserID: "u2", Username: "Bob", IsActive: true}},
+	}, nil
+}
+
+func (fakePRService) List(_ context.Context, _ models.PRFilter, _ models.PageRequest) (*models.PRPage, error) {
+	return &models.PRPage{PullRequests: []*models.PullRequest{fakePR}, NextCursor: models.TimeCursor(fakePR.CreatedAt, fakePR.ID).Encode()}, nil
+}
+
+func (fakePRService) Search(_ context.Context, _ models.PRSearch) ([]*models.PullRequest, error) {
+	return []*models.PullRequest{}, nil
+}
+
+func (fakePRService) SubmitReview(_ context.Context, req *models.PRReviewRequest) (*models.PRReview, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return &models.PRReview{PullRequestID: req.ID, ReviewerID: req.ReviewerID, State: req.State, UpdatedAt: fakeTime}, nil
+}
+
+type fakeStatsService struct{}
+
+func (fakeStatsService) GetAssignmentStats(_ context.Context, _ models.AssignmentStatsFilter, _ models.PageRequest) (*models.AssignmentStatsResponse, error) {
+	return &models.AssignmentStatsResponse{
+		UserStats: []*models.UserAssignmentStats{{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true, AssignmentCount: 2}},
+		PRStats:   &models.PRAssignmentStats{TotalPRs: 1, OpenPRs: 1, AvgReviewersPerPR: 1, PRsWithOneReviewer: 1},
+		Summary:   &models.StatsSummary{TotalUsers: 1, ActiveUsers: 1, TotalAssignments: 2, MostAssignedUser: "Alice", MostAssignments: 2},
+	}, nil
+}
+
+func (fakeStatsService) GetUserStats(_ context.Context, userID string) (*models.UserAssignmentStats, error) {
+	if userID != fakeUser.ID {
+		return nil, models.ErrNotFound
+	}
+	return &models.UserAssignmentStats{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true, AssignmentCount: 2}, nil
+}
+
+func (fakeStatsService) GetFairnessReport(_ context.Context, giniThreshold float64) (*models.FairnessReport, error) {
+	member := &models.MemberFairness{UserID: "u1", Username: "Alice", AssignmentCount: 2, DaysActive: 10, AssignmentsPerDay: 0.2}
+	return &models.FairnessReport{
+		GiniThreshold: giniThreshold,
+		Teams: []*models.TeamFairness{{
+			TeamName:         "backend",
+			ActiveMembers:    1,
+			TotalAssignments: 2,
+			MeanPerDay:       0.2,
+			Members:          []*models.MemberFairness{member},
+		}},
+	}, nil
+}
+
+type fakePreferencesService struct{}
+
+func (fakePreferencesService) Get(_ context.Context, userID string) (*models.NotificationPreferences, error) {
+	if userID != fakeUser.ID {
+		return nil, models.ErrNotFound
+	}
+	return models.DefaultNotificationPreferences(userID), nil
+}
+
+func (fakePreferencesService) Update(_ context.Context, p *models.NotificationPreferences) (*models.NotificationPreferences, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	if p.UserID != fakeUser.ID {
+		return nil, models.ErrNotFound
+	}
+	return p, nil
+}
+
+func newTestServer(t *testing.T, opts ...Option) *Server {
+	t.Helper()
+
+	svc := &service.Service{
+		User: fakeUserService{},
+		Team: fakeTeamService{teams: map[string]*models.Team{
+			"backend": {Name: "backend", Members: []*models.User{fakeUser}, CreatedAt: fakeTime, UpdatedAt: fakeTime},
+			"empty":   {Name: "empty"},
+		}},
+		PR:          fakePRService{},
+		Stats:       fakeStatsService{},
+		Preferences: fakePreferencesService{},
+	}
+	return NewServer(0, svc, slog.New(slog.NewTextHandler(io.Discard, nil)), opts...)
+}
@@ -3,6 +3,8 @@ package server
 import (
 	"net/http"
 
+	"github.com/vnchk1/pr-manager/internal/health"
+
 	"github.com/labstack/echo/v4"
 )
 
@@ -17,3 +19,18 @@ func (s *Server) healthCheck(c echo.Context) error {
 		Message: "Service is running",
 	})
 }
+
+func (s *Server) liveness(c echo.Context) error {
+	return c.JSON(http.StatusOK, health.Report{Status: health.StatusUp})
+}
+
+func (s *Server) readiness(c echo.Context) error {
+	report := health.Run(c.Request().Context(), s.healthTimeout, s.healthCheckers...)
+
+	status := http.StatusOK
+	if report.Status != health.StatusUp {
+		status = http.StatusServiceUnavailable
+	}
+
+	return c.JSON(status, report)
+}
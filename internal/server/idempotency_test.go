@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/idempotency"
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]*models.IdempotencyRecord
+}
+
+func (s *memIdempotencyStore) Reserve(_ context.Context, key, requestHash string, expiresAt time.Time) (*models.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[key]; ok {
+		found := *record
+		return &found, false, nil
+	}
+	s.records[key] = &models.IdempotencyRecord{Key: key, RequestHash: requestHash, ExpiresAt: expiresAt}
+	return &models.IdempotencyRecord{Key: key, RequestHash: requestHash, ExpiresAt: expiresAt}, true, nil
+}
+
+func (s *memIdempotencyStore) Complete(_ context.Context, record *models.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved := *record
+	s.records[record.Key] = &saved
+	return nil
+}
+
+func (s *memIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+func (s *memIdempotencyStore) DeleteExpired(context.Context) (int64, error) {
+	return 0, nil
+}
+
+func newIdempotentTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	store := &memIdempotencyStore{records: make(map[string]*models.IdempotencyRecord)}
+	keys := idempotency.New(store, time.Hour, time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return newTestServer(t, WithIdempotency(keys))
+}
+
+func serveWithKey(s *Server, path, key, body, lang string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(idempotency.HeaderKey, key)
+	if lang != "" {
+		req.Header.Set("Accept-Language", lang)
+	}
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotentRetryIsReplayed(t *testing.T) {
+	s := newIdempotentTestServer(t)
+	body := `{"pull_request_id":"pr-1","old_user_id":"u2"}`
+
+	first := serveWithKey(s, "/pullRequest/reassign", "ci-run-7", body, "")
+	require.Equal(t, http.StatusOK, first.Code, first.Body.String())
+
+	retry := serveWithKey(s, "/pullRequest/reassign", "ci-run-7", body, "")
+	require.Equal(t, http.StatusOK, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get(idempotency.HeaderReplayed))
+	assert.NotEqual(t, first.Header().Get("X-Request-ID"), retry.Header().Get("X-Request-ID"))
+
+	// Тот же ключ с другим телом
+	rec := serveWithKey(s, "/pullRequest/reassign", "ci-run-7", `{"pull_request_id":"pr-2","old_user_id":"u2"}`, "")
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "Ключ Idempotency-Key уже использован для другого запроса", resp.Message)
+}
+
+func TestIdempotencyErrorsInV1Format(t *testing.T) {
+	s := newIdempotentTestServer(t)
+	path := "/api/v1/pull-requests/pr-1/reviews"
+
+	require.Equal(t, http.StatusOK, serveWithKey(s, path, "ci-run-8", `{"user_id":"u2","state":"APPROVED"}`, "").Code)
+	rec := serveWithKey(s, path, "ci-run-8", `{"user_id":"u2","state":"CHANGES_REQUESTED"}`, "ru")
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	var resp V1ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, CodeIdempotencyKeyReused, resp.Error.Code)
+	assert.Equal(t, "Idempotency-Key has already been used for a different request", resp.Error.Message)
+
+	rec = serveWithKey(s, path, "bad key", `{}`, "")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, CodeInvalidArgument, resp.Error.Code)
+}
+
+func TestIdempotencyKeyIgnoredOutsideAPI(t *testing.T) {
+	s := newIdempotentTestServer(t)
+	body := `{"query":"{ __typename }"}`
+
+	// /graphql, /admin и /scim ключи не обрабатывают: повтор выполняется заново
+	require.Equal(t, http.StatusOK, serveWithKey(s, "/graphql", "ci-run-9", body, "").Code)
+	rec := serveWithKey(s, "/graphql", "ci-run-9", body, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get(idempotency.HeaderReplayed))
+}
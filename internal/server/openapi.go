@@ -0,0 +1,27 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/vnchk1/pr-manager/api"
+	"github.com/vnchk1/pr-manager/internal/i18n"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (s *Server) openAPISpec(c echo.Context) error {
+	spec, err := api.OpenAPIJSON()
+	if err != nil {
+		return errorJSON(c, http.StatusInternalServerError, i18n.OpenAPIFailed, err)
+	}
+
+	return c.JSONBlob(http.StatusOK, spec)
+}
+
+// swaggerUI отдает страницу Swagger UI; ее скрипты и стили отдает swaggerAssets
+func (s *Server) swaggerUI(c echo.Context) error {
+	return c.HTMLBlob(http.StatusOK, api.SwaggerHTML)
+}
+
+// swaggerAssets отдает встроенные файлы Swagger UI по пути после /docs/assets/
+var swaggerAssets = echo.StaticDirectoryHandler(api.SwaggerAssets, false)
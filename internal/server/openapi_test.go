@@ -0,0 +1,252 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/vnchk1/pr-manager/api"
+
+	"github.com/labstack/echo/v4"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const specURL = "openapi.json"
+
+// loadOpenAPISpec разбирает встроенную спецификацию и готовит компилятор схем
+// ответов. Схемы компилируются по JSON pointer внутри документа.
+func loadOpenAPISpec(t *testing.T) (map[string]any, *jsonschema.Compiler) {
+	t.Helper()
+
+	data, err := api.OpenAPIJSON()
+	require.NoError(t, err)
+
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	compiler := jsonschema.NewCompiler()
+	compiler.DefaultDraft(jsonschema.Draft2020)
+	require.NoError(t, compiler.AddResource(specURL, doc))
+
+	spec, ok := doc.(map[string]any)
+	require.True(t, ok)
+	return spec, compiler
+}
+
+// pointerToken экранирует сегмент JSON pointer (RFC 6901) и кодирует его для
+// фрагмента URI: фигурные скобки шаблонов путей недопустимы во фрагменте
+func pointerToken(s string) string {
+	s = strings.ReplaceAll(s, "~", "~0")
+	s = strings.ReplaceAll(s, "/", "~1")
+	return url.PathEscape(s)
+}
+
+// responsePointer находит схему тела ответа операции. Ответы, вынесенные в
+// components/responses, разворачиваются по $ref; при отсутствии статуса
+// используется default.
+func responsePointer(t *testing.T, spec map[string]any, template, method string, status int) string {
+	t.Helper()
+
+	paths, _ := spec["paths"].(map[string]any)
+	item, ok := paths[template].(map[string]any)
+	require.True(t, ok, "path %s is not documented", template)
+	operation, ok := item[strings.ToLower(method)].(map[string]any)
+	require.True(t, ok, "%s %s is not documented", method, template)
+	responses, _ := operation["responses"].(map[string]any)
+
+	pointer := "/paths/" + pointerToken(template) + "/" + strings.ToLower(method) + "/responses/"
+	key := strconv.Itoa(status)
+	response, ok := responses[key].(map[string]any)
+	if !ok {
+		key = "default"
+		response, ok = responses[key].(map[string]any)
+		require.True(t, ok, "%s %s: status %d is not documented", method, template, status)
+	}
+	pointer += key
+
+	if ref, ok := response["$ref"].(string); ok {
+		pointer = strings.TrimPrefix(ref, "#")
+		name := strings.TrimPrefix(ref, "#/components/responses/")
+		components, _ := spec["components"].(map[string]any)
+		shared, _ := components["responses"].(map[string]any)
+		response, ok = shared[name].(map[string]any)
+		require.True(t, ok, "unresolved response %s", ref)
+	}
+
+	if _, ok := response["content"]; !ok {
+		return ""
+	}
+	return pointer + "/content/" + pointerToken("application/json") + "/schema"
+}
+
+func TestPointerToken(t *testing.T) {
+	assert.Equal(t, "~1api~1v1~1teams~1%7Bname%7D", pointerToken("/api/v1/teams/{name}"))
+	assert.Equal(t, "a~0b", pointerToken("a~b"))
+	assert.Equal(t, "application~1json", pointerToken("application/json"))
+}
+
+// TestOpenAPIResponses проверяет, что реальные ответы обработчиков соответствуют
+// спецификации: расхождение кода и описания API ломает тест
+func TestOpenAPIResponses(t *testing.T) {
+	spec, compiler := loadOpenAPISpec(t)
+	s := newTestServer(t, WithAdminToken(testAdminToken))
+
+	tests := []struct {
+		method   string
+		path     string
+		template string
+		body     string
+		status   int
+	}{
+		{http.MethodGet, "/health", "/health", "", http.StatusOK},
+		{http.MethodGet, "/health/live", "/health/live", "", http.StatusOK},
+		{http.MethodGet, "/health/ready", "/health/ready", "", http.StatusOK},
+
+		{http.MethodGet, "/api/v1/teams", "/api/v1/teams", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/teams", "/api/v1/teams", `{"team_name":"mobile","members":[{"user_id":"u7","username":"Eve","is_active":true}]}`, http.StatusCreated},
+		{http.MethodPost, "/api/v1/teams", "/api/v1/teams", `{`, http.StatusBadRequest},
+		{http.MethodGet, "/api/v1/teams/backend", "/api/v1/teams/{name}", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/teams/frontend", "/api/v1/teams/{name}", "", http.StatusNotFound},
+		{http.MethodDelete, "/api/v1/teams/empty", "/api/v1/teams/{name}", "", http.StatusNoContent},
+		{http.MethodDelete, "/api/v1/teams/backend", "/api/v1/teams/{name}", "", http.StatusConflict},
+
+		{http.MethodGet, "/api/v1/users", "/api/v1/users", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/users", "/api/v1/users", `{"user_id":"u9","username":"Dan","team_name":"backend"}`, http.StatusCreated},
+		{http.MethodGet, "/api/v1/users/u1", "/api/v1/users/{id}", "", http.StatusOK},
+		{http.MethodPatch, "/api/v1/users/u1", "/api/v1/users/{id}", `{"is_active":false}`, http.StatusOK},
+		{http.MethodGet, "/api/v1/users/u1/reviews", "/api/v1/users/{id}/reviews", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/users/u1/reviews?limit=1", "/api/v1/users/{id}/reviews", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/users/u1/stats", "/api/v1/users/{id}/stats", "", http.StatusOK},
+
+		{http.MethodGet, "/api/v1/pull-requests?limit=1", "/api/v1/pull-requests", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/pull-requests", "/api/v1/pull-requests", `{"pull_request_id":"pr-2","pull_request_name":"Fix","author_id":"u1"}`, http.StatusCreated},
+		{http.MethodGet, "/api/v1/pull-requests/search?q=search", "/api/v1/pull-requests/search", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/pull-requests/pr-1", "/api/v1/pull-requests/{id}", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/pull-requests/pr-1/merge", "/api/v1/pull-requests/{id}/merge", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/pull-requests/pr-1/reviewers", "/api/v1/pull-requests/{id}/reviewers", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/pull-requests/pr-1/reviewers/u2/reassign", "/api/v1/pull-requests/{id}/reviewers/{user_id}/reassign", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/pull-requests/pr-1/reviews", "/api/v1/pull-requests/{id}/reviews", `{"user_id":"u2","state":"APPROVED"}`, http.StatusOK},
+		{http.MethodGet, "/api/v1/stats/assignments", "/api/v1/stats/assignments", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/stats/fairness", "/api/v1/stats/fairness", "", http.StatusOK},
+
+		{http.MethodPost, "/team/add", "/team/add", `{"team_name":"ios","members":[{"user_id":"u7","username":"Eve","is_active":true}]}`, http.StatusCreated},
+		{http.MethodGet, "/team/get?team_name=backend", "/team/get", "", http.StatusOK},
+		{http.MethodGet, "/team/get", "/team/get", "", http.StatusBadRequest},
+		{http.MethodPost, "/users/setIsActive", "/users/setIsActive", `{"user_id":"u1","is_active":false}`, http.StatusOK},
+		{http.MethodGet, "/users/getReview?user_id=u1", "/users/getReview", "", http.StatusOK},
+		{http.MethodGet, "/users/getReview?user_id=u1&limit=1", "/users/getReview", "", http.StatusOK},
+		{http.MethodGet, "/users/getReview?user_id=u1&cursor=bad", "/users/getReview", "", http.StatusBadRequest},
+		{http.MethodGet, "/users/preferences?user_id=u1", "/users/preferences", "", http.StatusOK},
+		{http.MethodGet, "/users/preferences?user_id=u9", "/users/preferences", "", http.StatusNotFound},
+		{http.MethodPut, "/users/preferences", "/users/preferences", `{"user_id":"u1","delivery":"digest","quiet_hours":{"start":"22:00","end":"08:00"},"timezone":"Europe/Moscow"}`, http.StatusOK},
+		{http.MethodPut, "/users/preferences", "/users/preferences", `{"user_id":"u1","channels":["sms"]}`, http.StatusBadRequest},
+		{http.MethodPost, "/pullRequest/create", "/pullRequest/create", `{"pull_request_id":"pr-2","pull_request_name":"Fix","author_id":"u1"}`, http.StatusCreated},
+		{http.MethodPost, "/pullRequest/merge", "/pullRequest/merge", `{"pull_request_id":"pr-1"}`, http.StatusOK},
+		{http.MethodPost, "/pullRequest/reassign", "/pullRequest/reassign", `{"pull_request_id":"pr-1","old_user_id":"u2"}`, http.StatusOK},
+		{http.MethodPost, "/pullRequest/review", "/pullRequest/review", `{"pull_request_id":"pr-1","user_id":"u2","state":"CHANGES_REQUESTED"}`, http.StatusOK},
+		{http.MethodGet, "/pullRequest/list?limit=1", "/pullRequest/list", "", http.StatusOK},
+		{http.MethodGet, "/pullRequest/get?pull_request_id=pr-1", "/pullRequest/get", "", http.StatusOK},
+		{http.MethodGet, "/pullRequest/search?q=search", "/pullRequest/search", "", http.StatusOK},
+		{http.MethodGet, "/stats/assignments", "/stats/assignments", "", http.StatusOK},
+		{http.MethodGet, "/stats/user?user_id=u1", "/stats/user", "", http.StatusOK},
+		{http.MethodGet, "/stats/fairness", "/stats/fairness", "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			rec := serveAdmin(s, tt.method, tt.path, tt.body)
+			require.Equal(t, tt.status, rec.Code, rec.Body.String())
+
+			pointer := responsePointer(t, spec, tt.template, tt.method, tt.status)
+			if pointer == "" {
+				assert.Empty(t, rec.Body.String())
+				return
+			}
+
+			schema, err := compiler.Compile(specURL + "#" + pointer)
+			require.NoError(t, err)
+
+			body, err := jsonschema.UnmarshalJSON(bytes.NewReader(rec.Body.Bytes()))
+			require.NoError(t, err)
+			assert.NoError(t, schema.Validate(body), rec.Body.String())
+		})
+	}
+}
+
+// TestOpenAPICoversRoutes проверяет, что каждый зарегистрированный маршрут описан
+func TestOpenAPICoversRoutes(t *testing.T) {
+	spec, _ := loadOpenAPISpec(t)
+	s := newTestServer(t)
+
+	undocumented := []string{"/admin/", "/scim/", "/metrics", "/openapi.json", "/docs", "/graphql"}
+	param := regexp.MustCompile(`:(\w+)`)
+	paths, _ := spec["paths"].(map[string]any)
+
+	for _, route := range s.echo.Routes() {
+		if route.Method == echo.RouteNotFound || skipRoute(route.Path, undocumented) {
+			continue
+		}
+
+		template := param.ReplaceAllString(route.Path, "{$1}")
+		item, ok := paths[template].(map[string]any)
+		if !assert.True(t, ok, "route %s %s is not documented", route.Method, route.Path) {
+			continue
+		}
+		assert.Contains(t, item, strings.ToLower(route.Method), "route %s %s is not documented", route.Method, route.Path)
+	}
+}
+
+// TestOpenAPIOperationsExist проверяет обратное: каждая описанная операция
+// зарегистрирована, и спецификация не описывает несуществующих маршрутов
+func TestOpenAPIOperationsExist(t *testing.T) {
+	spec, _ := loadOpenAPISpec(t)
+	s := newTestServer(t)
+
+	param := regexp.MustCompile(`:(\w+)`)
+	registered := make(map[string]bool)
+	for _, route := range s.echo.Routes() {
+		registered[route.Method+" "+param.ReplaceAllString(route.Path, "{$1}")] = true
+	}
+
+	paths, _ := spec["paths"].(map[string]any)
+	for template, item := range paths {
+		operations, _ := item.(map[string]any)
+		for method := range operations {
+			if method == "parameters" {
+				continue
+			}
+			assert.True(t, registered[strings.ToUpper(method)+" "+template], "%s %s is documented but not registered", method, template)
+		}
+	}
+}
+
+func TestSwaggerUIIsSelfContained(t *testing.T) {
+	s := newTestServer(t)
+
+	rec := serve(s, http.MethodGet, "/docs", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "https://")
+
+	assets := regexp.MustCompile(`(?:href|src)="(/docs/assets/[^"]+)"`).FindAllStringSubmatch(rec.Body.String(), -1)
+	require.Len(t, assets, 2, "stylesheet and bundle")
+	for _, asset := range assets {
+		assetRec := serve(s, http.MethodGet, asset[1], "")
+		assert.Equal(t, http.StatusOK, assetRec.Code, asset[1])
+		assert.NotEmpty(t, assetRec.Body.Len(), asset[1])
+	}
+}
+
+func skipRoute(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
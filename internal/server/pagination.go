@@ -0,0 +1,81 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/i18n"
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// parsePage читает параметры limit, cursor и order
+func parsePage(c echo.Context) (models.PageRequest, error) {
+	var page models.PageRequest
+
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > models.MaxPageLimit {
+			return page, errors.New("limit must be between 1 and " + strconv.Itoa(models.MaxPageLimit))
+		}
+		page.Limit = limit
+	}
+
+	if raw := c.QueryParam("cursor"); raw != "" {
+		cursor, err := models.DecodeCursor(raw)
+		if err != nil {
+			return page, err
+		}
+		page.Cursor = cursor
+	}
+
+	switch order := models.SortOrder(c.QueryParam("order")); order {
+	case "", models.SortAsc, models.SortDesc:
+		page.Order = order
+	default:
+		return page, models.ErrInvalidSort
+	}
+
+	return page, nil
+}
+
+// parseStatus читает фильтр status, пустое значение означает любой статус
+func parseStatus(c echo.Context) (models.PullRequestStatus, error) {
+	switch status := models.PullRequestStatus(c.QueryParam("status")); status {
+	case "", models.StatusOpen, models.StatusMerged:
+		return status, nil
+	default:
+		return "", errors.New("status must be OPEN or MERGED")
+	}
+}
+
+// parseTimeParam принимает время в RFC 3339 или дату вида 2006-01-02
+func parseTimeParam(c echo.Context, name string) (*time.Time, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return &t, nil
+		}
+	}
+
+	return nil, errors.New(name + " must be RFC 3339 time or YYYY-MM-DD date")
+}
+
+// pageError отвечает 400 на ошибки параметров выборки, 404 с сообщением notFound о сущности
+// из фильтра, которой нет, и 500 на остальные ошибки
+func pageError(c echo.Context, message, notFound i18n.MessageID, err error) error {
+	if errors.Is(err, models.ErrInvalidCursor) || errors.Is(err, models.ErrInvalidSort) {
+		return errorJSON(c, http.StatusBadRequest, i18n.InvalidQuery, err, err.Error())
+	}
+	if errors.Is(err, models.ErrNotFound) {
+		return errorJSON(c, http.StatusNotFound, notFound, err)
+	}
+	return errorJSON(c, http.StatusInternalServerError, message, err)
+}
@@ -1,180 +1,271 @@
 package server
 
 import (
+	"errors"
+	"github.com/vnchk1/pr-manager/internal/i18n"
 	"github.com/vnchk1/pr-manager/internal/models"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 )
 
-type CreatePRRequest struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
-}
-
 type CreatePRResponse struct {
 	BaseResponse
 	PR *models.PullRequest `json:"pr,omitempty"`
 }
 
-type MergePRRequest struct {
-	PullRequestID string `json:"pull_request_id"`
-}
-
 type MergePRResponse struct {
 	BaseResponse
 	PR *models.PullRequest `json:"pr,omitempty"`
 }
 
-type ReassignReviewerRequest struct {
-	PullRequestID string `json:"pull_request_id"`
-	OldUserID     string `json:"old_user_id"`
-}
-
 type ReassignReviewerResponse struct {
 	BaseResponse
 	PR         *models.PullRequest `json:"pr,omitempty"`
 	ReplacedBy string              `json:"replaced_by,omitempty"`
 }
 
+type ListPRsResponse struct {
+	BaseResponse
+	PullRequests []*models.PullRequest `json:"pull_requests"`
+	NextCursor   string                `json:"next_cursor,omitempty"`
+}
+
+type GetPRResponse struct {
+	BaseResponse
+	PR *models.PullRequestDetails `json:"pr,omitempty"`
+}
+
+type SubmitReviewResponse struct {
+	BaseResponse
+	Review *models.PRReview `json:"review,omitempty"`
+}
+
+type SearchPRsResponse struct {
+	BaseResponse
+	PullRequests []*models.PullRequest `json:"pull_requests"`
+}
+
 func (s *Server) createPR(c echo.Context) error {
-	var req CreatePRRequest
+	var req models.PRCreateRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "Неверный формат запроса",
-			},
-			Error: err.Error(),
-		})
+		return errorJSON(c, http.StatusBadRequest, i18n.InvalidRequestBody, err)
 	}
 
 	// Валидация обязательных полей
-	if req.PullRequestID == "" || req.PullRequestName == "" || req.AuthorID == "" {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "Все поля обязательны для заполнения",
-			},
-		})
-	}
-
-	createReq := &models.PRCreateRequest{
-		ID:       req.PullRequestID,
-		Name:     req.PullRequestName,
-		AuthorID: req.AuthorID,
+	if req.ID == "" || req.Name == "" || req.AuthorID == "" {
+		return errorJSON(c, http.StatusBadRequest, i18n.AllFieldsRequired, nil)
 	}
 
-	pr, err := s.service.PR.Create(c.Request().Context(), createReq)
+	pr, err := s.service.PR.Create(c.Request().Context(), &req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "Не удалось создать pull request",
-			},
-			Error: err.Error(),
-		})
+		return errorJSON(c, http.StatusInternalServerError, i18n.CreatePRFailed, err)
 	}
 
+	setETag(c, pr.Version)
 	return c.JSON(http.StatusCreated, CreatePRResponse{
 		BaseResponse: BaseResponse{
 			Success: true,
-			Message: "Pull request успешно создан",
+			Message: msg(c, i18n.PRCreated),
 		},
 		PR: pr,
 	})
 }
 
 func (s *Server) mergePR(c echo.Context) error {
-	var req MergePRRequest
+	var req models.PRMergeRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "Неверный формат запроса",
-			},
-			Error: err.Error(),
-		})
+		return errorJSON(c, http.StatusBadRequest, i18n.InvalidRequestBody, err)
 	}
 
-	if req.PullRequestID == "" {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "ID pull request обязателен",
-			},
-		})
+	if req.ID == "" {
+		return errorJSON(c, http.StatusBadRequest, i18n.PRIDRequired, nil)
 	}
 
-	mergeReq := &models.PRMergeRequest{
-		ID: req.PullRequestID,
+	var err error
+	if req.Version, err = ifMatchVersion(c); err != nil {
+		return errorJSON(c, http.StatusPreconditionFailed, i18n.PRVersionConflict, err)
 	}
 
-	pr, err := s.service.PR.Merge(c.Request().Context(), mergeReq)
+	pr, err := s.service.PR.Merge(c.Request().Context(), &req)
 	if err != nil {
+		if errors.Is(err, models.ErrVersionConflict) {
+			return errorJSON(c, conflictStatus(c), i18n.PRVersionConflict, err)
+		}
 		// Можно добавить более детальную обработку разных типов ошибок
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "Не удалось выполнить merge pull request",
-			},
-			Error: err.Error(),
-		})
+		return errorJSON(c, http.StatusInternalServerError, i18n.MergePRFailed, err)
 	}
 
+	setETag(c, pr.Version)
 	return c.JSON(http.StatusOK, MergePRResponse{
 		BaseResponse: BaseResponse{
 			Success: true,
-			Message: "Pull request успешно объединен",
+			Message: msg(c, i18n.PRMergeSucceeded),
 		},
 		PR: pr,
 	})
 }
 
 func (s *Server) reassignReviewer(c echo.Context) error {
-	var req ReassignReviewerRequest
+	var req models.PRReassignRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "Неверный формат запроса",
-			},
-			Error: err.Error(),
-		})
+		return errorJSON(c, http.StatusBadRequest, i18n.InvalidRequestBody, err)
 	}
 
-	if req.PullRequestID == "" || req.OldUserID == "" {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "ID pull request и старого ревьювера обязательны",
-			},
-		})
+	if req.ID == "" || req.OldReviewer == "" {
+		return errorJSON(c, http.StatusBadRequest, i18n.ReassignFieldsRequired, nil)
 	}
 
-	reassignReq := &models.PRReassignRequest{
-		ID:          req.PullRequestID,
-		OldReviewer: req.OldUserID,
+	var err error
+	if req.Version, err = ifMatchVersion(c); err != nil {
+		return errorJSON(c, http.StatusPreconditionFailed, i18n.PRVersionConflict, err)
 	}
 
-	pr, newReviewerID, err := s.service.PR.ReassignReviewer(c.Request().Context(), reassignReq)
+	pr, newReviewerID, err := s.service.PR.ReassignReviewer(c.Request().Context(), &req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "Не удалось перераспределить ревьювера",
-			},
-			Error: err.Error(),
-		})
+		if errors.Is(err, models.ErrVersionConflict) {
+			return errorJSON(c, conflictStatus(c), i18n.PRVersionConflict, err)
+		}
+		return errorJSON(c, http.StatusInternalServerError, i18n.ReassignFailed, err)
 	}
 
+	setETag(c, pr.Version)
 	return c.JSON(http.StatusOK, ReassignReviewerResponse{
 		BaseResponse: BaseResponse{
 			Success: true,
-			Message: "Ревьювер успешно перераспределен",
+			Message: msg(c, i18n.ReviewerReassigned),
 		},
 		PR:         pr,
 		ReplacedBy: newReviewerID,
 	})
 }
+
+func (s *Server) submitReview(c echo.Context) error {
+	var req models.PRReviewRequest
+	if err := c.Bind(&req); err != nil {
+		return errorJSON(c, http.StatusBadRequest, i18n.InvalidRequestBody, err)
+	}
+
+	review, err := s.service.PR.SubmitReview(c.Request().Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidPRID), errors.Is(err, models.ErrInvalidUserID):
+			return errorJSON(c, http.StatusBadRequest, i18n.ReviewFieldsRequired, err)
+		case errors.Is(err, models.ErrInvalidReviewState):
+			return errorJSON(c, http.StatusBadRequest, i18n.InvalidReviewState, err)
+		case errors.Is(err, models.ErrNotFound):
+			return errorJSON(c, http.StatusNotFound, i18n.PRNotFound, err)
+		case errors.Is(err, models.ErrPRMerged):
+			return errorJSON(c, http.StatusConflict, i18n.PRMerged, err)
+		case errors.Is(err, models.ErrNotAssigned):
+			return errorJSON(c, http.StatusConflict, i18n.NotAssigned, err)
+		default:
+			return errorJSON(c, http.StatusInternalServerError, i18n.SaveReviewFailed, err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, SubmitReviewResponse{
+		BaseResponse: BaseResponse{
+			Success: true,
+			Message: msg(c, i18n.ReviewSaved),
+		},
+		Review: review,
+	})
+}
+
+func (s *Server) listPRs(c echo.Context) error {
+	page, err := parsePage(c)
+	if err != nil {
+		return errorJSON(c, http.StatusBadRequest, i18n.InvalidQuery, err, err.Error())
+	}
+
+	filter := models.PRFilter{
+		AuthorID:   c.QueryParam("author_id"),
+		ReviewerID: c.QueryParam("reviewer_id"),
+		TeamName:   c.QueryParam("team_name"),
+	}
+
+	if filter.Status, err = parseStatus(c); err != nil {
+		return errorJSON(c, http.StatusBadRequest, i18n.InvalidQuery, err, err.Error())
+	}
+	if filter.CreatedFrom, err = parseTimeParam(c, "created_from"); err != nil {
+		return errorJSON(c, http.StatusBadRequest, i18n.InvalidQuery, err, err.Error())
+	}
+	if filter.CreatedTo, err = parseTimeParam(c, "created_to"); err != nil {
+		return errorJSON(c, http.StatusBadRequest, i18n.InvalidQuery, err, err.Error())
+	}
+
+	result, err := s.service.PR.List(c.Request().Context(), filter, page)
+	if err != nil {
+		return pageError(c, i18n.ListPRsFailed, i18n.UserNotFound, err)
+	}
+
+	return c.JSON(http.StatusOK, ListPRsResponse{
+		BaseResponse: BaseResponse{
+			Success: true,
+			Message: msg(c, i18n.PRsFound, len(result.PullRequests)),
+		},
+		PullRequests: result.PullRequests,
+		NextCursor:   result.NextCursor,
+	})
+}
+
+func (s *Server) getPR(c echo.Context) error {
+	prID := c.QueryParam("pull_request_id")
+	if prID == "" {
+		return errorJSON(c, http.StatusBadRequest, i18n.PRIDRequired, nil)
+	}
+
+	pr, err := s.service.PR.GetDetails(c.Request().Context(), prID)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return errorJSON(c, http.StatusNotFound, i18n.PRNotFound, err)
+		}
+		return errorJSON(c, http.StatusInternalServerError, i18n.GetPRFailed, err)
+	}
+
+	setETag(c, pr.Version)
+	return c.JSON(http.StatusOK, GetPRResponse{
+		BaseResponse: BaseResponse{
+			Success: true,
+			Message: msg(c, i18n.PRFound),
+		},
+		PR: pr,
+	})
+}
+
+func (s *Server) searchPRs(c echo.Context) error {
+	search := models.PRSearch{
+		Query: c.QueryParam("q"),
+		Filter: models.PRFilter{
+			ReviewerID: c.QueryParam("reviewer_id"),
+			TeamName:   c.QueryParam("team_name"),
+		},
+	}
+	if search.Query == "" {
+		return errorJSON(c, http.StatusBadRequest, i18n.ParamRequired, nil, "q")
+	}
+
+	page, err := parsePage(c)
+	if err != nil {
+		return errorJSON(c, http.StatusBadRequest, i18n.InvalidQuery, err, err.Error())
+	}
+	search.Limit = page.Limit
+
+	if search.Filter.Status, err = parseStatus(c); err != nil {
+		return errorJSON(c, http.StatusBadRequest, i18n.InvalidQuery, err, err.Error())
+	}
+
+	prs, err := s.service.PR.Search(c.Request().Context(), search)
+	if err != nil {
+		return pageError(c, i18n.SearchPRsFailed, i18n.UserNotFound, err)
+	}
+
+	return c.JSON(http.StatusOK, SearchPRsResponse{
+		BaseResponse: BaseResponse{
+			Success: true,
+			Message: msg(c, i18n.PRsFound, len(prs)),
+		},
+		PullRequests: prs,
+	})
+}
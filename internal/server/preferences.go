@@ -0,0 +1,84 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vnchk1/pr-manager/internal/i18n"
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PreferencesResponse struct {
+	BaseResponse
+	Preferences *models.NotificationPreferences `json:"preferences,omitempty"`
+}
+
+// preferencesErrors - ошибки проверки настроек, о которых сообщается клиенту
+var preferencesErrors = []error{
+	models.ErrInvalidChannel,
+	models.ErrInvalidNotificationType,
+	models.ErrInvalidQuietHours,
+	models.ErrInvalidTimezone,
+	models.ErrInvalidDelivery,
+	models.ErrInvalidEmail,
+	models.ErrInvalidWebhookURL,
+}
+
+func (s *Server) getPreferences(c echo.Context) error {
+	userID := c.QueryParam("user_id")
+	if userID == "" {
+		return errorJSON(c, http.StatusBadRequest, i18n.ParamRequired, nil, "user_id")
+	}
+
+	preferences, err := s.service.Preferences.Get(c.Request().Context(), userID)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return errorJSON(c, http.StatusNotFound, i18n.UserNotFound, err)
+		}
+		return errorJSON(c, http.StatusInternalServerError, i18n.GetPreferencesFailed, err)
+	}
+
+	return c.JSON(http.StatusOK, PreferencesResponse{
+		BaseResponse: BaseResponse{
+			Success: true,
+			Message: msg(c, i18n.PreferencesFound),
+		},
+		Preferences: preferences,
+	})
+}
+
+// updatePreferences заменяет настройки пользователя целиком; поля, которых нет
+// в запросе, получают значения по умолчанию
+func (s *Server) updatePreferences(c echo.Context) error {
+	preferences := models.DefaultNotificationPreferences("")
+	if err := c.Bind(preferences); err != nil {
+		return errorJSON(c, http.StatusBadRequest, i18n.InvalidRequestBody, err)
+	}
+
+	if preferences.UserID == "" {
+		return errorJSON(c, http.StatusBadRequest, i18n.UserIDRequired, nil)
+	}
+
+	saved, err := s.service.Preferences.Update(c.Request().Context(), preferences)
+	if err != nil {
+		for _, invalid := range preferencesErrors {
+			if errors.Is(err, invalid) {
+				return errorJSON(c, http.StatusBadRequest, i18n.InvalidPreferences, err, err.Error())
+			}
+		}
+		if errors.Is(err, models.ErrNotFound) {
+			return errorJSON(c, http.StatusNotFound, i18n.UserNotFound, err)
+		}
+		return errorJSON(c, http.StatusInternalServerError, i18n.SavePreferencesFailed, err)
+	}
+
+	return c.JSON(http.StatusOK, PreferencesResponse{
+		BaseResponse: BaseResponse{
+			Success: true,
+			Message: msg(c, i18n.PreferencesSaved),
+		},
+		Preferences: saved,
+	})
+}
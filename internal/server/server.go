@@ -3,33 +3,143 @@ package server
 import (
 	"github.com/vnchk1/pr-manager/internal/middleware"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"time"
 
+	"github.com/vnchk1/pr-manager/internal/events"
+	"github.com/vnchk1/pr-manager/internal/graphql"
+	"github.com/vnchk1/pr-manager/internal/grpcserver"
+	"github.com/vnchk1/pr-manager/internal/health"
+	"github.com/vnchk1/pr-manager/internal/i18n"
+	"github.com/vnchk1/pr-manager/internal/idempotency"
+	logpkg "github.com/vnchk1/pr-manager/internal/logger"
+	"github.com/vnchk1/pr-manager/internal/metrics"
+	"github.com/vnchk1/pr-manager/internal/scim"
 	"github.com/vnchk1/pr-manager/internal/service"
 
 	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"google.golang.org/grpc"
 )
 
 type Server struct {
 	echo    *echo.Echo
 	port    int
 	service *service.Service
+	metrics *metrics.Metrics
+
+	tracingServiceName string
+
+	healthTimeout  time.Duration
+	healthCheckers []health.Checker
+
+	scimToken  string
+	adminToken string
+
+	defaultLang i18n.Lang
+
+	grpc *grpcserver.Server
+
+	idempotency *idempotency.Keys
+
+	events         *events.Bus
+	eventHeartbeat time.Duration
+
+	// closing закрывается в Shutdown и завершает открытые SSE-потоки: иначе
+	// echo.Shutdown ждал бы их до истечения таймаута
+	closing   chan struct{}
+	closeOnce sync.Once
 }
 
-func NewServer(port int, service *service.Service, logger *slog.Logger) *Server {
-	e := echo.New()
+type Option func(*Server)
 
-	e.Use(middleware.LoggingMiddleware(logger))
+// WithGRPCServer запускает gRPC-сервер вместе с HTTP и останавливает его в GracefulStart
+func WithGRPCServer(srv *grpcserver.Server) Option {
+	return func(s *Server) {
+		s.grpc = srv
+	}
+}
+
+// WithDefaultLanguage задает язык сообщений для запросов без подходящего Accept-Language
+func WithDefaultLanguage(lang i18n.Lang) Option {
+	return func(s *Server) {
+		s.defaultLang = lang
+	}
+}
+
+// WithMetrics включает сбор HTTP-метрик и эндпоинт /metrics
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(s *Server) {
+		s.metrics = m
+	}
+}
+
+// WithTracing создает спан на каждый HTTP-запрос и извлекает W3C trace context из заголовков
+func WithTracing(serviceName string) Option {
+	return func(s *Server) {
+		s.tracingServiceName = serviceName
+	}
+}
+
+// WithHealthCheckers задает зависимости, проверяемые в /health/ready
+func WithHealthCheckers(timeout time.Duration, checkers ...health.Checker) Option {
+	return func(s *Server) {
+		s.healthTimeout = timeout
+		s.healthCheckers = append(s.healthCheckers, checkers...)
+	}
+}
+
+// WithSCIMToken подключает /scim/v2 с bearer-токеном; без токена SCIM не подключается
+func WithSCIMToken(token string) Option {
+	return func(s *Server) {
+		s.scimToken = token
+	}
+}
+
+// WithAdminToken подключает /admin с bearer-токеном; без токена маршруты /admin не подключаются
+func WithAdminToken(token string) Option {
+	return func(s *Server) {
+		s.adminToken = token
+	}
+}
+
+// WithIdempotency включает поддержку заголовка Idempotency-Key в POST-запросах API
+func WithIdempotency(keys *idempotency.Keys) Option {
+	return func(s *Server) {
+		s.idempotency = keys
+	}
+}
+
+func NewServer(port int, service *service.Service, logger *slog.Logger, opts ...Option) *Server {
+	e := echo.New()
+	e.HTTPErrorHandler = httpErrorHandler
 
 	server := &Server{
-		echo:    e,
-		port:    port,
-		service: service,
+		echo:        e,
+		port:        port,
+		service:     service,
+		defaultLang: i18n.Russian,
+		closing:     make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(server)
+	}
+
+	if server.tracingServiceName != "" {
+		e.Use(otelecho.Middleware(server.tracingServiceName))
+	}
+	e.Use(middleware.RequestIDMiddleware(logger))
+	e.Use(middleware.LoggingMiddleware(logger))
+	e.Use(middleware.LanguageMiddleware(server.defaultLang))
+	if server.metrics != nil {
+		e.Use(middleware.MetricsMiddleware(server.metrics))
 	}
 
 	server.setupRoutes()
@@ -39,19 +149,61 @@ func NewServer(port int, service *service.Service, logger *slog.Logger) *Server
 
 func (s *Server) setupRoutes() {
 	s.echo.GET("/health", s.healthCheck)
+	s.echo.GET("/health/live", s.liveness)
+	s.echo.GET("/health/ready", s.readiness)
 
-	s.echo.POST("/team/add", s.createTeam)
+	s.echo.GET("/openapi.json", s.openAPISpec)
+	s.echo.GET("/docs", s.swaggerUI)
+	s.echo.GET("/docs/assets/*", swaggerAssets)
+
+	idempotent := s.idempotentMiddleware()
+
+	s.echo.POST("/team/add", s.createTeam, idempotent)
 	s.echo.GET("/team/get", s.getTeam)
 
-	s.echo.POST("/users/setIsActive", s.setUserActive)
+	s.echo.POST("/users/setIsActive", s.setUserActive, idempotent)
 	s.echo.GET("/users/getReview", s.getUserReviewPRs)
+	// Настройки задают адреса доставки уведомлений, поэтому доступны только с токеном /admin
+	preferencesAuth := middleware.BearerAuthMiddleware(s.adminToken)
+	s.echo.GET("/users/preferences", s.getPreferences, preferencesAuth)
+	s.echo.PUT("/users/preferences", s.updatePreferences, preferencesAuth)
 
-	s.echo.POST("/pullRequest/create", s.createPR)
-	s.echo.POST("/pullRequest/merge", s.mergePR)
-	s.echo.POST("/pullRequest/reassign", s.reassignReviewer)
+	s.echo.POST("/pullRequest/create", s.createPR, idempotent)
+	s.echo.POST("/pullRequest/merge", s.mergePR, idempotent)
+	s.echo.POST("/pullRequest/reassign", s.reassignReviewer, idempotent)
+	s.echo.POST("/pullRequest/review", s.submitReview, idempotent)
+	s.echo.GET("/pullRequest/list", s.listPRs)
+	s.echo.GET("/pullRequest/get", s.getPR)
+	s.echo.GET("/pullRequest/search", s.searchPRs)
 
 	s.echo.GET("/stats/assignments", s.getStats)
 	s.echo.GET("/stats/user", s.getUserStats)
+	s.echo.GET("/stats/fairness", s.getFairnessReport)
+
+	// Импорт и синхронизация состава могут массово деактивировать пользователей,
+	// поэтому без токена маршруты не подключаются
+	if s.adminToken != "" {
+		admin := s.echo.Group("/admin", middleware.BearerAuthMiddleware(s.adminToken))
+		admin.POST("/import", s.importRoster)
+		admin.GET("/export", s.exportRoster)
+		admin.POST("/reconcile", s.reconcileRoster)
+	}
+
+	s.setupV1Routes()
+
+	s.echo.POST("/graphql", echo.WrapHandler(graphql.NewHandler(s.service)))
+
+	if s.events != nil {
+		s.echo.GET("/events/stream", s.streamEvents)
+	}
+
+	if s.scimToken != "" {
+		scim.NewHandler(s.service.User, s.service.Team, s.service.Roster, s.scimToken).Register(s.echo.Group("/scim/v2"))
+	}
+
+	if s.metrics != nil {
+		s.echo.GET("/metrics", echo.WrapHandler(s.metrics.Handler()))
+	}
 }
 
 func (s *Server) Start(logger *slog.Logger) error {
@@ -60,30 +212,52 @@ func (s *Server) Start(logger *slog.Logger) error {
 	return s.echo.Start(address)
 }
 
+// Shutdown останавливает HTTP- и gRPC-серверы, дожидаясь текущих запросов до истечения ctx
 func (s *Server) Shutdown(ctx context.Context) error {
-	return s.echo.Shutdown(ctx)
+	s.closeOnce.Do(func() { close(s.closing) })
+
+	var grpcErr error
+	if s.grpc != nil {
+		grpcErr = s.grpc.Shutdown(ctx)
+	}
+
+	return errors.Join(s.echo.Shutdown(ctx), grpcErr)
 }
 
+// GracefulStart запускает HTTP- и gRPC-серверы и ждет сигнала остановки. Если один
+// из серверов не смог запуститься или упал, останавливает другой и возвращает ошибку.
 func (s *Server) GracefulStart(logger *slog.Logger) error {
+	failed := make(chan error, 2)
 
 	go func() {
 		if err := s.Start(logger); err != nil && err != http.ErrServerClosed {
-			logger.Debug("shutting down the server")
+			failed <- fmt.Errorf("http server: %w", err)
 		}
 	}()
 
+	if s.grpc != nil {
+		go func() {
+			if err := s.grpc.Start(logger); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+				failed <- fmt.Errorf("grpc server: %w", err)
+			}
+		}()
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt)
-	<-quit
+	defer signal.Stop(quit)
+
+	var serveErr error
+	select {
+	case <-quit:
+	case serveErr = <-failed:
+		logger.Error("server stopped, shutting down", "error", serveErr)
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	if err := s.Shutdown(ctx); err != nil {
-		return err
-	}
-
-	return nil
+	return errors.Join(serveErr, s.Shutdown(ctx))
 }
 
 type BaseResponse struct {
@@ -93,5 +267,96 @@ type BaseResponse struct {
 
 type ErrorResponse struct {
 	BaseResponse
-	Error string `json:"error,omitempty"`
+	Error     string `json:"error,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// msg переводит сообщение на язык, выбранный для запроса
+func msg(c echo.Context, id i18n.MessageID, args ...any) string {
+	return i18n.Translate(i18n.FromContext(c.Request().Context()), id, args...)
+}
+
+// errorJSON отвечает ошибкой в старом формате. Сообщение переводится на язык запроса,
+// в лог попадает английский текст, чтобы логи не зависели от клиента.
+func errorJSON(c echo.Context, status int, message i18n.MessageID, err error, args ...any) error {
+	ctx := c.Request().Context()
+
+	resp := ErrorResponse{
+		BaseResponse: BaseResponse{
+			Success: false,
+			Message: msg(c, message, args...),
+		},
+		RequestID: logpkg.RequestIDFromContext(ctx),
+	}
+
+	if err != nil {
+		resp.Error = err.Error()
+		if status >= http.StatusInternalServerError {
+			logpkg.FromContext(ctx).Error(i18n.Translate(i18n.English, message, args...), "error", err)
+		}
+	}
+
+	return c.JSON(status, resp)
+}
+
+// httpErrorHandler отдает ошибки echo (404, 405, echo.NewHTTPError) в формате ErrorResponse,
+// а под /api/v1 - в формате V1ErrorResponse
+func httpErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		err = httpErr.Internal
+	} else {
+		httpErr = echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
+	}
+	status := httpErr.Code
+
+	if c.Request().Method == http.MethodHead {
+		_ = c.NoContent(status)
+		return
+	}
+
+	if isV1Path(c.Request().URL.Path) {
+		if jsonErr := v1HTTPError(c, httpErr); jsonErr != nil {
+			logpkg.FromContext(c.Request().Context()).Error("failed to write error response", "error", jsonErr)
+		}
+		return
+	}
+
+	if jsonErr := errorJSON(c, status, httpErrorMessage(httpErr), err); jsonErr != nil {
+		logpkg.FromContext(c.Request().Context()).Error("failed to write error response", "error", jsonErr)
+	}
+}
+
+// idempotentMiddleware - обработка Idempotency-Key для POST-маршрутов основного API и API v1.
+// /graphql, /scim и /admin ключи не принимают. Без WithIdempotency заголовок игнорируется.
+func (s *Server) idempotentMiddleware() echo.MiddlewareFunc {
+	if s.idempotency == nil {
+		return func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+	}
+	return s.idempotency.Middleware()
+}
+
+// httpStatusMessages - переводы стандартных текстов ошибок echo
+var httpStatusMessages = map[int]i18n.MessageID{
+	http.StatusBadRequest:            i18n.HTTPBadRequest,
+	http.StatusUnauthorized:          i18n.HTTPUnauthorized,
+	http.StatusNotFound:              i18n.HTTPNotFound,
+	http.StatusMethodNotAllowed:      i18n.HTTPMethodNotAllowed,
+	http.StatusRequestEntityTooLarge: i18n.HTTPPayloadTooLarge,
+	http.StatusUnsupportedMediaType:  i18n.HTTPUnsupportedMedia,
+	http.StatusInternalServerError:   i18n.HTTPInternal,
+}
+
+// httpErrorMessage переводит стандартный текст статуса ошибки echo; текст, заданный
+// явно, отдается как есть (Translate не находит его в каталоге)
+func httpErrorMessage(httpErr *echo.HTTPError) i18n.MessageID {
+	message := fmt.Sprint(httpErr.Message)
+	if id, ok := httpStatusMessages[httpErr.Code]; ok && message == http.StatusText(httpErr.Code) {
+		return id
+	}
+	return i18n.MessageID(message)
 }
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/grpcserver"
+	"github.com/vnchk1/pr-manager/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGracefulStartFailsWhenGRPCCannotListen(t *testing.T) {
+	busy, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer busy.Close()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	grpcSrv := grpcserver.New(busy.Addr().(*net.TCPAddr).Port, &service.Service{}, logger)
+	s := newTestServer(t, WithGRPCServer(grpcSrv))
+	s.echo.HideBanner, s.echo.HidePort = true, true
+
+	started := make(chan error, 1)
+	go func() { started <- s.GracefulStart(logger) }()
+
+	select {
+	case err := <-started:
+		assert.ErrorContains(t, err, "grpc server")
+	case <-time.After(5 * time.Second):
+		t.Fatal("GracefulStart did not return after gRPC failed to listen")
+	}
+}
@@ -2,6 +2,10 @@ package server
 
 import (
 	"net/http"
+	"strconv"
+
+	"github.com/vnchk1/pr-manager/internal/i18n"
+	"github.com/vnchk1/pr-manager/internal/models"
 
 	"github.com/labstack/echo/v4"
 )
@@ -10,6 +14,8 @@ type StatsResponse struct {
 	UserStats []*UserStatsItem `json:"user_stats"`
 	PRStats   *PRStats         `json:"pr_stats"`
 	Summary   *Summary         `json:"summary"`
+	// NextCursor - курсор следующей страницы user_stats
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type UserStatsItem struct {
@@ -48,9 +54,19 @@ type UserStatsResponse struct {
 }
 
 func (s *Server) getStats(c echo.Context) error {
-	stats, err := s.service.Stats.GetAssignmentStats(c.Request().Context())
+	page, err := parsePage(c)
 	if err != nil {
-		return err
+		return errorJSON(c, http.StatusBadRequest, i18n.InvalidQuery, err, err.Error())
+	}
+
+	filter := models.AssignmentStatsFilter{
+		TeamName: c.QueryParam("team_name"),
+		Sort:     c.QueryParam("sort"),
+	}
+
+	stats, err := s.service.Stats.GetAssignmentStats(c.Request().Context(), filter, page)
+	if err != nil {
+		return pageError(c, i18n.StatsFailed, i18n.TeamNotFound, err)
 	}
 
 	response := StatsResponse{
@@ -71,6 +87,7 @@ func (s *Server) getStats(c echo.Context) error {
 			MostAssignedUser: stats.Summary.MostAssignedUser,
 			MostAssignments:  stats.Summary.MostAssignments,
 		},
+		NextCursor: stats.NextCursor,
 	}
 
 	for i, userStat := range stats.UserStats {
@@ -89,7 +106,7 @@ func (s *Server) getStats(c echo.Context) error {
 func (s *Server) getUserStats(c echo.Context) error {
 	userID := c.QueryParam("user_id")
 	if userID == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "user_id is required")
+		return errorJSON(c, http.StatusBadRequest, i18n.ParamRequired, nil, "user_id")
 	}
 
 	userStats, err := s.service.Stats.GetUserStats(c.Request().Context(), userID)
@@ -107,3 +124,21 @@ func (s *Server) getUserStats(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, response)
 }
+
+func (s *Server) getFairnessReport(c echo.Context) error {
+	giniThreshold := models.DefaultGiniThreshold
+	if raw := c.QueryParam("gini_threshold"); raw != "" {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || value < 0 || value > 1 {
+			return errorJSON(c, http.StatusBadRequest, i18n.InvalidGiniThreshold, err)
+		}
+		giniThreshold = value
+	}
+
+	report, err := s.service.Stats.GetFairnessReport(c.Request().Context(), giniThreshold)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
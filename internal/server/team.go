@@ -1,6 +1,7 @@
 package server
 
 import (
+	"github.com/vnchk1/pr-manager/internal/i18n"
 	"github.com/vnchk1/pr-manager/internal/models"
 	"net/http"
 
@@ -25,43 +26,22 @@ type GetTeamResponse struct {
 func (s *Server) createTeam(c echo.Context) error {
 	var req CreateTeamRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "Неверный формат запроса",
-			},
-			Error: err.Error(),
-		})
+		return errorJSON(c, http.StatusBadRequest, i18n.InvalidRequestBody, err)
 	}
 
 	// Валидация обязательных полей
 	if req.TeamName == "" {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "Название команды обязательно",
-			},
-		})
+		return errorJSON(c, http.StatusBadRequest, i18n.TeamNameRequired, nil)
 	}
 
 	// Проверка уникальности ID пользователей
 	memberIDs := make(map[string]bool)
 	for _, member := range req.Members {
 		if member.ID == "" {
-			return c.JSON(http.StatusBadRequest, ErrorResponse{
-				BaseResponse: BaseResponse{
-					Success: false,
-					Message: "ID участника команды не может быть пустым",
-				},
-			})
+			return errorJSON(c, http.StatusBadRequest, i18n.MemberIDRequired, nil)
 		}
 		if memberIDs[member.ID] {
-			return c.JSON(http.StatusBadRequest, ErrorResponse{
-				BaseResponse: BaseResponse{
-					Success: false,
-					Message: "Обнаружены дублирующиеся ID участников",
-				},
-			})
+			return errorJSON(c, http.StatusBadRequest, i18n.DuplicateMemberIDs, nil)
 		}
 		memberIDs[member.ID] = true
 	}
@@ -82,19 +62,13 @@ func (s *Server) createTeam(c echo.Context) error {
 
 	createdTeam, err := s.service.Team.Create(c.Request().Context(), team)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "Не удалось создать команду",
-			},
-			Error: err.Error(),
-		})
+		return errorJSON(c, http.StatusInternalServerError, i18n.CreateTeamFailed, err)
 	}
 
 	return c.JSON(http.StatusCreated, CreateTeamResponse{
 		BaseResponse: BaseResponse{
 			Success: true,
-			Message: "Команда успешно создана",
+			Message: msg(c, i18n.TeamCreated),
 		},
 		Team: createdTeam,
 	})
@@ -103,39 +77,23 @@ func (s *Server) createTeam(c echo.Context) error {
 func (s *Server) getTeam(c echo.Context) error {
 	teamName := c.QueryParam("team_name")
 	if teamName == "" {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "Параметр team_name обязателен",
-			},
-		})
+		return errorJSON(c, http.StatusBadRequest, i18n.ParamRequired, nil, "team_name")
 	}
 
 	team, err := s.service.Team.Get(c.Request().Context(), teamName)
 	if err != nil {
 
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "Не удалось получить информацию о команде",
-			},
-			Error: err.Error(),
-		})
+		return errorJSON(c, http.StatusInternalServerError, i18n.GetTeamFailed, err)
 	}
 
 	if team == nil {
-		return c.JSON(http.StatusNotFound, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "Команда не найдена",
-			},
-		})
+		return errorJSON(c, http.StatusNotFound, i18n.TeamNotFound, nil)
 	}
 
 	return c.JSON(http.StatusOK, GetTeamResponse{
 		BaseResponse: BaseResponse{
 			Success: true,
-			Message: "Информация о команде успешно получена",
+			Message: msg(c, i18n.TeamFound),
 		},
 		Team: team,
 	})
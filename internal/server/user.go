@@ -1,7 +1,9 @@
 package server
 
 import (
+	"github.com/vnchk1/pr-manager/internal/i18n"
 	"github.com/vnchk1/pr-manager/internal/models"
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -19,60 +21,43 @@ type SetUserActiveResponse struct {
 
 type GetUserReviewResponse struct {
 	BaseResponse
-	UserID       string                     `json:"user_id"`
-	PullRequests []*models.PullRequestShort `json:"pull_requests,omitempty"`
+	UserID       string              `json:"user_id"`
+	PullRequests []*models.ReviewPR  `json:"pull_requests"`
+	Review       *models.ReviewQueue `json:"review"`
+	NextCursor   string              `json:"next_cursor,omitempty"`
 }
 
 func (s *Server) setUserActive(c echo.Context) error {
 	var req SetUserActiveRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "Неверный формат запроса",
-			},
-			Error: err.Error(),
-		})
+		return errorJSON(c, http.StatusBadRequest, i18n.InvalidRequestBody, err)
 	}
 
 	if req.UserID == "" {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "ID пользователя обязательно",
-			},
-		})
+		return errorJSON(c, http.StatusBadRequest, i18n.UserIDRequired, nil)
 	}
 
 	user, err := s.service.User.SetActive(c.Request().Context(), req.UserID, req.IsActive)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "Не удалось обновить статус пользователя",
-			},
-			Error: err.Error(),
-		})
+		if errors.Is(err, models.ErrNotFound) {
+			return errorJSON(c, http.StatusNotFound, i18n.UserNotFound, err)
+		}
+		return errorJSON(c, http.StatusInternalServerError, i18n.UpdateUserFailed, err)
 	}
 
 	if user == nil {
-		return c.JSON(http.StatusNotFound, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "Пользователь не найден",
-			},
-		})
+		return errorJSON(c, http.StatusNotFound, i18n.UserNotFound, nil)
 	}
 
-	statusMessage := "деактивирован"
+	message := i18n.UserDeactivated
 	if req.IsActive {
-		statusMessage = "активирован"
+		message = i18n.UserActivated
 	}
 
 	return c.JSON(http.StatusOK, SetUserActiveResponse{
 		BaseResponse: BaseResponse{
 			Success: true,
-			Message: "Пользователь успешно " + statusMessage,
+			Message: msg(c, message),
 		},
 		User: user,
 	})
@@ -81,32 +66,27 @@ func (s *Server) setUserActive(c echo.Context) error {
 func (s *Server) getUserReviewPRs(c echo.Context) error {
 	userID := c.QueryParam("user_id")
 	if userID == "" {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "Параметр user_id обязателен",
-			},
-		})
+		return errorJSON(c, http.StatusBadRequest, i18n.ParamRequired, nil, "user_id")
 	}
 
-	prs, err := s.service.PR.GetByReviewer(c.Request().Context(), userID)
+	page, err := parsePage(c)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			BaseResponse: BaseResponse{
-				Success: false,
-				Message: "Не удалось получить список pull requests для ревью",
-			},
-			Error: err.Error(),
-		})
+		return errorJSON(c, http.StatusBadRequest, i18n.InvalidQuery, err, err.Error())
 	}
 
-	if prs == nil {
-		prs = []*models.PullRequestShort{}
+	status, err := parseStatus(c)
+	if err != nil {
+		return errorJSON(c, http.StatusBadRequest, i18n.InvalidQuery, err, err.Error())
+	}
+
+	queue, err := s.service.User.GetReviewPRs(c.Request().Context(), userID, status, page)
+	if err != nil {
+		return pageError(c, i18n.ReviewPRsFailed, i18n.UserNotFound, err)
 	}
 
-	message := "Найдено " + string(rune(len(prs))) + " pull requests для ревью"
-	if len(prs) == 0 {
-		message = "Нет pull requests для ревью"
+	message := msg(c, i18n.ReviewPRsFound, queue.Counts.Total, queue.Counts.NeedsReview)
+	if queue.Counts.Total == 0 {
+		message = msg(c, i18n.NoReviewPRs)
 	}
 
 	return c.JSON(http.StatusOK, GetUserReviewResponse{
@@ -115,6 +95,8 @@ func (s *Server) getUserReviewPRs(c echo.Context) error {
 			Message: message,
 		},
 		UserID:       userID,
-		PullRequests: prs,
+		PullRequests: queue.All(),
+		Review:       queue,
+		NextCursor:   queue.NextCursor,
 	})
 }
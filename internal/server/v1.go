@@ -0,0 +1,204 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/vnchk1/pr-manager/internal/i18n"
+	logpkg "github.com/vnchk1/pr-manager/internal/logger"
+	"github.com/vnchk1/pr-manager/internal/middleware"
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// V1Prefix - префикс версионированного API. Ответы под ним не содержат success/message:
+// успешный ответ - сам ресурс, ошибка - V1ErrorResponse.
+const V1Prefix = "/api/v1"
+
+// Коды ошибок API v1. Код - часть контракта, текст сообщения может меняться.
+const (
+	CodeInvalidArgument      = "INVALID_ARGUMENT"
+	CodeNotFound             = "NOT_FOUND"
+	CodeMethodNotAllowed     = "METHOD_NOT_ALLOWED"
+	CodeUnauthorized         = "UNAUTHORIZED"
+	CodeUserExists           = "USER_EXISTS"
+	CodeUserNotActive        = "USER_NOT_ACTIVE"
+	CodeTeamExists           = "TEAM_EXISTS"
+	CodeTeamNotEmpty         = "TEAM_NOT_EMPTY"
+	CodePRExists             = "PR_EXISTS"
+	CodePRMerged             = "PR_MERGED"
+	CodeNotAssigned          = "NOT_ASSIGNED"
+	CodeNoCandidate          = "NO_CANDIDATE"
+	CodeVersionConflict      = "VERSION_CONFLICT"
+	CodeRequestInProgress    = "REQUEST_IN_PROGRESS"
+	CodeIdempotencyKeyReused = "IDEMPOTENCY_KEY_REUSED"
+	CodeInternal             = "INTERNAL"
+)
+
+type V1Error struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+type V1ErrorResponse struct {
+	Error V1Error `json:"error"`
+}
+
+// V1List - страница коллекции; next_cursor пуст на последней странице
+type V1List[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// newV1List не допускает null вместо пустой коллекции
+func newV1List[T any](items []T, nextCursor string) V1List[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return V1List[T]{Items: items, NextCursor: nextCursor}
+}
+
+// V1Items - коллекция без постраничной выдачи: все элементы одним ответом
+type V1Items[T any] struct {
+	Items []T `json:"items"`
+}
+
+func newV1Items[T any](items []T) V1Items[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return V1Items[T]{Items: items}
+}
+
+// v1ErrorCodes сопоставляет доменные ошибки статусам и кодам API v1
+var v1ErrorCodes = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{models.ErrNotFound, http.StatusNotFound, CodeNotFound},
+	{models.ErrInvalidUserID, http.StatusBadRequest, CodeInvalidArgument},
+	{models.ErrInvalidUsername, http.StatusBadRequest, CodeInvalidArgument},
+	{models.ErrInvalidTeamName, http.StatusBadRequest, CodeInvalidArgument},
+	{models.ErrInvalidPRID, http.StatusBadRequest, CodeInvalidArgument},
+	{models.ErrInvalidPRName, http.StatusBadRequest, CodeInvalidArgument},
+	{models.ErrInvalidAuthorID, http.StatusBadRequest, CodeInvalidArgument},
+	{models.ErrInvalidReviewState, http.StatusBadRequest, CodeInvalidArgument},
+	{models.ErrInvalidCursor, http.StatusBadRequest, CodeInvalidArgument},
+	{models.ErrInvalidSort, http.StatusBadRequest, CodeInvalidArgument},
+	{models.ErrUserExists, http.StatusConflict, CodeUserExists},
+	{models.ErrUserNotActive, http.StatusConflict, CodeUserNotActive},
+	{models.ErrTeamExists, http.StatusConflict, CodeTeamExists},
+	{models.ErrTeamNotEmpty, http.StatusConflict, CodeTeamNotEmpty},
+	{models.ErrPRExists, http.StatusConflict, CodePRExists},
+	{models.ErrPRMerged, http.StatusConflict, CodePRMerged},
+	{models.ErrNotAssigned, http.StatusConflict, CodeNotAssigned},
+	{models.ErrNoCandidate, http.StatusConflict, CodeNoCandidate},
+	{models.ErrVersionConflict, http.StatusConflict, CodeVersionConflict},
+}
+
+// v1Fail отвечает ошибкой в формате API v1
+func v1Fail(c echo.Context, status int, code, message string, err error) error {
+	ctx := c.Request().Context()
+	if status >= http.StatusInternalServerError {
+		logpkg.FromContext(ctx).Error(message, "error", err)
+	}
+
+	return c.JSON(status, V1ErrorResponse{Error: V1Error{
+		Code:      code,
+		Message:   message,
+		RequestID: logpkg.RequestIDFromContext(ctx),
+	}})
+}
+
+// v1Invalid - ответ 400 на ошибку в параметрах запроса
+func v1Invalid(c echo.Context, message string) error {
+	return v1Fail(c, http.StatusBadRequest, CodeInvalidArgument, message, nil)
+}
+
+// v1ServiceError переводит ошибку сервиса в ответ API v1. Текст неизвестных ошибок
+// клиенту не отдается.
+func v1ServiceError(c echo.Context, err error) error {
+	for _, mapping := range v1ErrorCodes {
+		if errors.Is(err, mapping.err) {
+			return v1Fail(c, mapping.status, mapping.code, mapping.err.Error(), err)
+		}
+	}
+	return v1Fail(c, http.StatusInternalServerError, CodeInternal, "internal error", err)
+}
+
+// v1HTTPError отдает ошибки echo (неизвестный маршрут, метод, тело запроса) в формате API v1
+func v1HTTPError(c echo.Context, httpErr *echo.HTTPError) error {
+	code := CodeInternal
+	switch httpErr.Code {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType:
+		code = CodeInvalidArgument
+	case http.StatusNotFound:
+		code = CodeNotFound
+	case http.StatusMethodNotAllowed:
+		code = CodeMethodNotAllowed
+	case http.StatusUnauthorized:
+		code = CodeUnauthorized
+	case http.StatusConflict:
+		code = CodeRequestInProgress
+	case http.StatusUnprocessableEntity:
+		code = CodeIdempotencyKeyReused
+	}
+
+	// Сообщение может быть ключом каталога (ошибки Idempotency-Key), в API v1 оно на английском
+	message := strings.ToLower(http.StatusText(httpErr.Code))
+	if msg, ok := httpErr.Message.(string); ok && httpErr.Code < http.StatusInternalServerError {
+		message = i18n.Translate(i18n.English, i18n.MessageID(msg))
+	}
+
+	return v1Fail(c, httpErr.Code, code, message, httpErr.Internal)
+}
+
+func isV1Path(path string) bool {
+	return path == V1Prefix || strings.HasPrefix(path, V1Prefix+"/")
+}
+
+// bindV1 разбирает тело запроса. Ошибка - готовый ответ 400 в формате API v1,
+// обработчик возвращает ее как есть.
+func bindV1(c echo.Context, v any) error {
+	if err := c.Bind(v); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "malformed request body").SetInternal(err)
+	}
+	return nil
+}
+
+func (s *Server) setupV1Routes() {
+	g := s.echo.Group(V1Prefix)
+
+	// Удаление команд, создание и изменение пользователей не имеют аналогов в основном
+	// API и доступны только с токеном администратора; без токена отвечают 401
+	admin := middleware.BearerAuthMiddleware(s.adminToken)
+	idempotent := s.idempotentMiddleware()
+
+	g.GET("/teams", s.v1ListTeams)
+	g.POST("/teams", s.v1CreateTeam, idempotent)
+	g.GET("/teams/:name", s.v1GetTeam)
+	g.DELETE("/teams/:name", s.v1DeleteTeam, admin)
+
+	g.GET("/users", s.v1ListUsers)
+	g.POST("/users", s.v1CreateUser, admin, idempotent)
+	g.GET("/users/:id", s.v1GetUser)
+	g.PATCH("/users/:id", s.v1UpdateUser, admin)
+	g.GET("/users/:id/reviews", s.v1GetUserReviews)
+	g.GET("/users/:id/stats", s.v1GetUserStats)
+
+	g.GET("/pull-requests", s.v1ListPullRequests)
+	g.POST("/pull-requests", s.v1CreatePullRequest, idempotent)
+	g.GET("/pull-requests/search", s.v1SearchPullRequests)
+	g.GET("/pull-requests/:id", s.v1GetPullRequest)
+	g.POST("/pull-requests/:id/merge", s.v1MergePullRequest, idempotent)
+	g.GET("/pull-requests/:id/reviewers", s.v1ListReviewers)
+	g.POST("/pull-requests/:id/reviewers/:user_id/reassign", s.v1ReassignReviewer, idempotent)
+	g.POST("/pull-requests/:id/reviews", s.v1SubmitReview, idempotent)
+
+	g.GET("/stats/assignments", s.v1AssignmentStats)
+	g.GET("/stats/fairness", s.v1FairnessReport)
+}
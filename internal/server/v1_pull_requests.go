@@ -0,0 +1,174 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+type V1ReassignResponse struct {
+	PullRequest *models.PullRequest `json:"pull_request"`
+	ReplacedBy  string              `json:"replaced_by"`
+}
+
+type V1SubmitReviewRequest struct {
+	ReviewerID string             `json:"user_id"`
+	State      models.ReviewState `json:"state"`
+}
+
+func (s *Server) v1ListPullRequests(c echo.Context) error {
+	page, err := parsePage(c)
+	if err != nil {
+		return v1Invalid(c, err.Error())
+	}
+
+	filter := models.PRFilter{
+		AuthorID:   c.QueryParam("author_id"),
+		ReviewerID: c.QueryParam("reviewer_id"),
+		TeamName:   c.QueryParam("team_name"),
+	}
+	if filter.Status, err = parseStatus(c); err != nil {
+		return v1Invalid(c, err.Error())
+	}
+	if filter.CreatedFrom, err = parseTimeParam(c, "created_from"); err != nil {
+		return v1Invalid(c, err.Error())
+	}
+	if filter.CreatedTo, err = parseTimeParam(c, "created_to"); err != nil {
+		return v1Invalid(c, err.Error())
+	}
+
+	result, err := s.service.PR.List(c.Request().Context(), filter, page)
+	if err != nil {
+		return v1ServiceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, newV1List(result.PullRequests, result.NextCursor))
+}
+
+func (s *Server) v1CreatePullRequest(c echo.Context) error {
+	var req models.PRCreateRequest
+	if err := bindV1(c, &req); err != nil {
+		return err
+	}
+	if req.ID == "" {
+		return v1ServiceError(c, models.ErrInvalidPRID)
+	}
+	if req.Name == "" {
+		return v1ServiceError(c, models.ErrInvalidPRName)
+	}
+	if req.AuthorID == "" {
+		return v1ServiceError(c, models.ErrInvalidAuthorID)
+	}
+
+	pr, err := s.service.PR.Create(c.Request().Context(), &req)
+	if err != nil {
+		return v1ServiceError(c, err)
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, V1Prefix+"/pull-requests/"+pr.ID)
+	setETag(c, pr.Version)
+	return c.JSON(http.StatusCreated, pr)
+}
+
+func (s *Server) v1SearchPullRequests(c echo.Context) error {
+	search := models.PRSearch{
+		Query: c.QueryParam("q"),
+		Filter: models.PRFilter{
+			ReviewerID: c.QueryParam("reviewer_id"),
+			TeamName:   c.QueryParam("team_name"),
+		},
+	}
+	if search.Query == "" {
+		return v1Invalid(c, "q is required")
+	}
+
+	page, err := parsePage(c)
+	if err != nil {
+		return v1Invalid(c, err.Error())
+	}
+	search.Limit = page.Limit
+
+	if search.Filter.Status, err = parseStatus(c); err != nil {
+		return v1Invalid(c, err.Error())
+	}
+
+	prs, err := s.service.PR.Search(c.Request().Context(), search)
+	if err != nil {
+		return v1ServiceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, newV1List(prs, ""))
+}
+
+func (s *Server) v1GetPullRequest(c echo.Context) error {
+	pr, err := s.service.PR.GetDetails(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return v1ServiceError(c, err)
+	}
+
+	setETag(c, pr.Version)
+	return c.JSON(http.StatusOK, pr)
+}
+
+func (s *Server) v1MergePullRequest(c echo.Context) error {
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return v1PullRequestError(c, err)
+	}
+
+	pr, err := s.service.PR.Merge(c.Request().Context(), &models.PRMergeRequest{ID: c.Param("id"), Version: version})
+	if err != nil {
+		return v1PullRequestError(c, err)
+	}
+
+	setETag(c, pr.Version)
+	return c.JSON(http.StatusOK, pr)
+}
+
+func (s *Server) v1ListReviewers(c echo.Context) error {
+	pr, err := s.service.PR.GetDetails(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return v1ServiceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, newV1Items(pr.Reviewers))
+}
+
+func (s *Server) v1ReassignReviewer(c echo.Context) error {
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return v1PullRequestError(c, err)
+	}
+
+	pr, replacedBy, err := s.service.PR.ReassignReviewer(c.Request().Context(), &models.PRReassignRequest{
+		ID:          c.Param("id"),
+		OldReviewer: c.Param("user_id"),
+		Version:     version,
+	})
+	if err != nil {
+		return v1PullRequestError(c, err)
+	}
+
+	setETag(c, pr.Version)
+	return c.JSON(http.StatusOK, V1ReassignResponse{PullRequest: pr, ReplacedBy: replacedBy})
+}
+
+func (s *Server) v1SubmitReview(c echo.Context) error {
+	var req V1SubmitReviewRequest
+	if err := bindV1(c, &req); err != nil {
+		return err
+	}
+
+	review, err := s.service.PR.SubmitReview(c.Request().Context(), &models.PRReviewRequest{
+		ID:         c.Param("id"),
+		ReviewerID: req.ReviewerID,
+		State:      req.State,
+	})
+	if err != nil {
+		return v1ServiceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, review)
+}
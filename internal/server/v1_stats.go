@@ -0,0 +1,47 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (s *Server) v1AssignmentStats(c echo.Context) error {
+	page, err := parsePage(c)
+	if err != nil {
+		return v1Invalid(c, err.Error())
+	}
+
+	filter := models.AssignmentStatsFilter{
+		TeamName: c.QueryParam("team_name"),
+		Sort:     c.QueryParam("sort"),
+	}
+
+	stats, err := s.service.Stats.GetAssignmentStats(c.Request().Context(), filter, page)
+	if err != nil {
+		return v1ServiceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
+
+func (s *Server) v1FairnessReport(c echo.Context) error {
+	giniThreshold := models.DefaultGiniThreshold
+	if raw := c.QueryParam("gini_threshold"); raw != "" {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || value < 0 || value > 1 {
+			return v1Invalid(c, "gini_threshold must be a number between 0 and 1")
+		}
+		giniThreshold = value
+	}
+
+	report, err := s.service.Stats.GetFairnessReport(c.Request().Context(), giniThreshold)
+	if err != nil {
+		return v1ServiceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
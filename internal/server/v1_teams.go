@@ -0,0 +1,71 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+type V1CreateTeamRequest struct {
+	Name    string              `json:"team_name"`
+	Members []models.TeamMember `json:"members"`
+}
+
+func (s *Server) v1ListTeams(c echo.Context) error {
+	teams, err := s.service.Team.List(c.Request().Context())
+	if err != nil {
+		return v1ServiceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, newV1Items(teams))
+}
+
+func (s *Server) v1CreateTeam(c echo.Context) error {
+	var req V1CreateTeamRequest
+	if err := bindV1(c, &req); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(req.Members))
+	team := &models.Team{Name: req.Name, Members: make([]*models.User, 0, len(req.Members))}
+	for _, member := range req.Members {
+		if seen[member.UserID] {
+			return v1Invalid(c, "duplicate member user_id "+member.UserID)
+		}
+		seen[member.UserID] = true
+
+		team.Members = append(team.Members, &models.User{
+			ID:       member.UserID,
+			Username: member.Username,
+			TeamName: req.Name,
+			IsActive: member.IsActive,
+		})
+	}
+
+	created, err := s.service.Team.Create(c.Request().Context(), team)
+	if err != nil {
+		return v1ServiceError(c, err)
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, V1Prefix+"/teams/"+created.Name)
+	return c.JSON(http.StatusCreated, created)
+}
+
+func (s *Server) v1GetTeam(c echo.Context) error {
+	team, err := s.service.Team.Get(c.Request().Context(), c.Param("name"))
+	if err != nil {
+		return v1ServiceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, team)
+}
+
+func (s *Server) v1DeleteTeam(c echo.Context) error {
+	if err := s.service.Team.Delete(c.Request().Context(), c.Param("name")); err != nil {
+		return v1ServiceError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,178 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testAdminToken - токен администратора тестового сервера, см. serveAdmin
+const testAdminToken = "secret"
+
+func serve(s *Server, method, path, body string) *httptest.ResponseRecorder {
+	return serveWithHeader(s, method, path, body, "", "")
+}
+
+// serveAdmin выполняет запрос с токеном testAdminToken
+func serveAdmin(s *Server, method, path, body string) *httptest.ResponseRecorder {
+	return serveWithHeader(s, method, path, body, echo.HeaderAuthorization, "Bearer "+testAdminToken)
+}
+
+func serveWithHeader(s *Server, method, path, body, header, value string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	if header != "" {
+		req.Header.Set(header, value)
+	}
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestV1Errors(t *testing.T) {
+	s := newTestServer(t, WithAdminToken(testAdminToken))
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		code   string
+	}{
+		{"Unknown team", http.MethodGet, "/api/v1/teams/frontend", "", http.StatusNotFound, CodeNotFound},
+		{"Team not empty", http.MethodDelete, "/api/v1/teams/backend", "", http.StatusConflict, CodeTeamNotEmpty},
+		{"PR merged", http.MethodPost, "/api/v1/pull-requests/pr-merged/reviewers/u2/reassign", "", http.StatusConflict, CodePRMerged},
+		{"Malformed body", http.MethodPost, "/api/v1/teams", "{", http.StatusBadRequest, CodeInvalidArgument},
+		{"Invalid query", http.MethodGet, "/api/v1/pull-requests?limit=0", "", http.StatusBadRequest, CodeInvalidArgument},
+		{"Invalid cursor", http.MethodGet, "/api/v1/users/u1/reviews?cursor=bad", "", http.StatusBadRequest, CodeInvalidArgument},
+		{"Unknown route", http.MethodGet, "/api/v1/unknown", "", http.StatusNotFound, CodeNotFound},
+		{"Wrong method", http.MethodPut, "/api/v1/teams", "", http.StatusMethodNotAllowed, CodeMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveAdmin(s, tt.method, tt.path, tt.body)
+			require.Equal(t, tt.status, rec.Code, rec.Body.String())
+
+			var resp V1ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, tt.code, resp.Error.Code)
+			assert.NotEmpty(t, resp.Error.Message)
+			assert.NotEmpty(t, resp.Error.RequestID)
+
+			// Конверт API v1 не содержит полей старого формата
+			var raw map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &raw))
+			assert.Len(t, raw, 1)
+		})
+	}
+}
+
+func TestV1Resources(t *testing.T) {
+	s := newTestServer(t, WithAdminToken(testAdminToken))
+
+	rec := serve(s, http.MethodGet, "/api/v1/teams/backend", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var team models.Team
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &team))
+	assert.Equal(t, "backend", team.Name)
+	assert.Len(t, team.Members, 1)
+
+	rec = serve(s, http.MethodPost, "/api/v1/pull-requests/pr-1/reviewers/u2/reassign", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var reassigned V1ReassignResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &reassigned))
+	assert.Equal(t, "u3", reassigned.ReplacedBy)
+	assert.Equal(t, "pr-1", reassigned.PullRequest.ID)
+
+	rec = serveAdmin(s, http.MethodDelete, "/api/v1/teams/empty", "")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestV1AdminRoutesRequireToken(t *testing.T) {
+	routes := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodDelete, "/api/v1/teams/empty", ""},
+		{http.MethodPost, "/api/v1/users", `{"user_id":"u9","username":"Dan","team_name":"backend"}`},
+		{http.MethodPatch, "/api/v1/users/u1", `{"is_active":false}`},
+	}
+
+	// Без ADMIN_TOKEN маршруты подключены, но всегда отвечают 401
+	open := newTestServer(t)
+	s := newTestServer(t, WithAdminToken(testAdminToken))
+
+	for _, route := range routes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			for _, rec := range []*httptest.ResponseRecorder{
+				serve(open, route.method, route.path, route.body),
+				serveAdmin(open, route.method, route.path, route.body),
+				serve(s, route.method, route.path, route.body),
+				serveWithHeader(s, route.method, route.path, route.body, echo.HeaderAuthorization, "Bearer wrong"),
+			} {
+				require.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+
+				var resp V1ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, CodeUnauthorized, resp.Error.Code)
+			}
+		})
+	}
+}
+
+func TestLegacyRoutesKeepOldFormat(t *testing.T) {
+	s := newTestServer(t)
+
+	rec := serve(s, http.MethodGet, "/unknown", "")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.False(t, resp.Success)
+	assert.NotEmpty(t, resp.Message)
+}
+
+func TestLegacyMessagesFollowAcceptLanguage(t *testing.T) {
+	s := newTestServer(t)
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		path           string
+		want           string
+	}{
+		{"Default language", "", "/team/get", "Параметр team_name обязателен"},
+		{"English", "en-US,en;q=0.9", "/team/get", "Parameter team_name is required"},
+		{"Unsupported falls back", "de", "/team/get", "Параметр team_name обязателен"},
+		{"Echo errors are translated", "en", "/unknown", "Not found"},
+		{"Success message", "en", "/team/get?team_name=backend", "Team found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+			s.echo.ServeHTTP(rec, req)
+
+			var resp BaseResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, tt.want, resp.Message)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+type V1CreateUserRequest struct {
+	ID       string `json:"user_id"`
+	Username string `json:"username"`
+	TeamName string `json:"team_name"`
+	IsActive *bool  `json:"is_active"`
+}
+
+func (s *Server) v1ListUsers(c echo.Context) error {
+	users, err := s.service.User.List(c.Request().Context())
+	if err != nil {
+		return v1ServiceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, newV1Items(users))
+}
+
+func (s *Server) v1CreateUser(c echo.Context) error {
+	var req V1CreateUserRequest
+	if err := bindV1(c, &req); err != nil {
+		return err
+	}
+
+	user := &models.User{ID: req.ID, Username: req.Username, TeamName: req.TeamName, IsActive: true}
+	if req.IsActive != nil {
+		user.IsActive = *req.IsActive
+	}
+
+	created, err := s.service.User.Create(c.Request().Context(), user)
+	if err != nil {
+		return v1ServiceError(c, err)
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, V1Prefix+"/users/"+created.ID)
+	return c.JSON(http.StatusCreated, created)
+}
+
+func (s *Server) v1GetUser(c echo.Context) error {
+	user, err := s.service.User.GetByID(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return v1ServiceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, user)
+}
+
+// v1UpdateUser меняет переданные поля. Деактивация и переход в другую команду
+// переназначают открытые ревью пользователя.
+func (s *Server) v1UpdateUser(c echo.Context) error {
+	var update models.UserUpdate
+	if err := bindV1(c, &update); err != nil {
+		return err
+	}
+
+	user, err := s.service.User.Update(c.Request().Context(), c.Param("id"), &update)
+	if err != nil {
+		return v1ServiceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, user)
+}
+
+func (s *Server) v1GetUserReviews(c echo.Context) error {
+	page, err := parsePage(c)
+	if err != nil {
+		return v1Invalid(c, err.Error())
+	}
+
+	status, err := parseStatus(c)
+	if err != nil {
+		return v1Invalid(c, err.Error())
+	}
+
+	queue, err := s.service.User.GetReviewPRs(c.Request().Context(), c.Param("id"), status, page)
+	if err != nil {
+		return v1ServiceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, queue)
+}
+
+func (s *Server) v1GetUserStats(c echo.Context) error {
+	stats, err := s.service.Stats.GetUserStats(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return v1ServiceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
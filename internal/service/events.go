@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/repository"
+)
+
+// EventPublisher принимает события об изменениях назначений и активности пользователей.
+// Публикация не влияет на результат операции, поэтому ошибок не возвращает.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *models.Event)
+}
+
+// eventEmitter строит события для сервисов. Без publisher события не публикуются.
+type eventEmitter struct {
+	publisher EventPublisher
+	userRepo  repository.UserRepository
+}
+
+// pullRequest публикует событие PR; командой события считается команда автора
+func (e eventEmitter) pullRequest(ctx context.Context, eventType models.EventType, data models.PREventData) {
+	if e.publisher == nil {
+		return
+	}
+
+	var teamName string
+	if author, err := e.userRepo.GetByID(ctx, data.PullRequest.AuthorID); err == nil {
+		teamName = author.TeamName
+	}
+
+	e.publisher.Publish(ctx, models.NewPREvent(eventType, teamName, data))
+}
+
+func (e eventEmitter) user(ctx context.Context, eventType models.EventType, user *models.User) {
+	if e.publisher == nil {
+		return
+	}
+
+	e.publisher.Publish(ctx, models.NewUserEvent(eventType, user))
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+)
+
+const (
+	// imbalanceFactor - во сколько раз нагрузка участника должна отличаться от средней по команде
+	imbalanceFactor = 1.5
+
+	minDaysActive = 1.0
+)
+
+func buildFairnessReport(members []*models.MemberAssignment, now time.Time, giniThreshold float64) *models.FairnessReport {
+	report := &models.FairnessReport{
+		GiniThreshold: giniThreshold,
+		Teams:         []*models.TeamFairness{},
+	}
+
+	byTeam := make(map[string][]*models.MemberFairness)
+	var teamNames []string
+
+	for _, member := range members {
+		daysActive := now.Sub(member.ActiveSince).Hours() / 24
+		if daysActive < minDaysActive {
+			daysActive = minDaysActive
+		}
+
+		if _, ok := byTeam[member.TeamName]; !ok {
+			teamNames = append(teamNames, member.TeamName)
+		}
+
+		byTeam[member.TeamName] = append(byTeam[member.TeamName], &models.MemberFairness{
+			UserID:            member.UserID,
+			Username:          member.Username,
+			AssignmentCount:   member.AssignmentCount,
+			DaysActive:        daysActive,
+			AssignmentsPerDay: float64(member.AssignmentCount) / daysActive,
+		})
+	}
+
+	sort.Strings(teamNames)
+
+	for _, teamName := range teamNames {
+		team := buildTeamFairness(teamName, byTeam[teamName], giniThreshold)
+		if team.Imbalanced {
+			report.Imbalanced = true
+		}
+		report.Teams = append(report.Teams, team)
+	}
+
+	return report
+}
+
+func buildTeamFairness(teamName string, members []*models.MemberFairness, giniThreshold float64) *models.TeamFairness {
+	team := &models.TeamFairness{
+		TeamName:      teamName,
+		ActiveMembers: len(members),
+		Overloaded:    []*models.MemberFairness{},
+		Underloaded:   []*models.MemberFairness{},
+		Members:       members,
+	}
+
+	rates := make([]float64, len(members))
+	for i, member := range members {
+		rates[i] = member.AssignmentsPerDay
+		team.TotalAssignments += member.AssignmentCount
+	}
+
+	team.MeanPerDay = mean(rates)
+	team.StdDev = stdDev(rates)
+	team.Gini = gini(rates)
+	team.Imbalanced = team.Gini > giniThreshold
+
+	if team.MeanPerDay == 0 {
+		return team
+	}
+
+	for _, member := range members {
+		switch {
+		case member.AssignmentsPerDay > team.MeanPerDay*imbalanceFactor:
+			team.Overloaded = append(team.Overloaded, member)
+		case member.AssignmentsPerDay < team.MeanPerDay/imbalanceFactor:
+			team.Underloaded = append(team.Underloaded, member)
+		}
+	}
+
+	return team
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum / float64(len(values))
+}
+
+func stdDev(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	m := mean(values)
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - m) * (v - m)
+	}
+
+	return math.Sqrt(variance / float64(len(values)))
+}
+
+// gini считает коэффициент Джини: 0 - нагрузка распределена поровну, 1 - вся нагрузка на одном человеке
+func gini(values []float64) float64 {
+	n := len(values)
+	if n < 2 {
+		return 0
+	}
+
+	sorted := make([]float64, n)
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	var sum, weighted float64
+	for i, v := range sorted {
+		sum += v
+		weighted += float64(i+1) * v
+	}
+
+	if sum == 0 {
+		return 0
+	}
+
+	return 2*weighted/(float64(n)*sum) - float64(n+1)/float64(n)
+}
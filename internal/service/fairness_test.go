@@ -0,0 +1,74 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vnchk1/pr-manager/internal/models"
+)
+
+func TestGini(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{name: "Empty", values: nil, want: 0},
+		{name: "Single member", values: []float64{5}, want: 0},
+		{name: "Equal load", values: []float64{2, 2, 2, 2}, want: 0},
+		{name: "No assignments", values: []float64{0, 0, 0}, want: 0},
+		{name: "All on one member", values: []float64{0, 0, 0, 4}, want: 0.75},
+		{name: "Unsorted input", values: []float64{3, 1, 2}, want: 2.0 / 9.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, gini(tt.values), 1e-9)
+		})
+	}
+}
+
+func TestStdDev(t *testing.T) {
+	assert.InDelta(t, 0, stdDev(nil), 1e-9)
+	assert.InDelta(t, 2, stdDev([]float64{2, 4, 4, 4, 5, 5, 7, 9}), 1e-9)
+}
+
+func TestBuildFairnessReport(t *testing.T) {
+	now := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	tenDaysAgo := now.AddDate(0, 0, -10)
+
+	members := []*models.MemberAssignment{
+		{UserID: "u1", Username: "alice", TeamName: "backend", AssignmentCount: 10, ActiveSince: tenDaysAgo},
+		{UserID: "u2", Username: "bob", TeamName: "backend", AssignmentCount: 1, ActiveSince: tenDaysAgo},
+		{UserID: "u3", Username: "carol", TeamName: "backend", AssignmentCount: 4, ActiveSince: tenDaysAgo},
+		{UserID: "u4", Username: "dave", TeamName: "frontend", AssignmentCount: 3, ActiveSince: tenDaysAgo},
+		{UserID: "u5", Username: "erin", TeamName: "frontend", AssignmentCount: 3, ActiveSince: tenDaysAgo},
+		{UserID: "u6", Username: "frank", TeamName: "frontend", AssignmentCount: 0, ActiveSince: now},
+	}
+
+	report := buildFairnessReport(members, now, models.DefaultGiniThreshold)
+
+	require.Len(t, report.Teams, 2)
+	assert.True(t, report.Imbalanced)
+
+	backend := report.Teams[0]
+	assert.Equal(t, "backend", backend.TeamName)
+	assert.Equal(t, 3, backend.ActiveMembers)
+	assert.Equal(t, 15, backend.TotalAssignments)
+	assert.InDelta(t, 0.5, backend.MeanPerDay, 1e-9)
+	assert.True(t, backend.Imbalanced)
+	require.Len(t, backend.Overloaded, 1)
+	assert.Equal(t, "u1", backend.Overloaded[0].UserID)
+	require.Len(t, backend.Underloaded, 1)
+	assert.Equal(t, "u2", backend.Underloaded[0].UserID)
+
+	frontend := report.Teams[1]
+	assert.Equal(t, "frontend", frontend.TeamName)
+	// новый участник считается активным минимум один день
+	assert.InDelta(t, 1, frontend.Members[2].DaysActive, 1e-9)
+	require.Len(t, frontend.Underloaded, 1)
+	assert.Equal(t, "u6", frontend.Underloaded[0].UserID)
+	assert.Empty(t, frontend.Overloaded)
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	logpkg "github.com/vnchk1/pr-manager/internal/logger"
 	"github.com/vnchk1/pr-manager/internal/models"
 	"github.com/vnchk1/pr-manager/internal/repository"
 	"context"
@@ -12,7 +13,13 @@ type PRService interface {
 	Merge(ctx context.Context, req *models.PRMergeRequest) (*models.PullRequest, error)
 	ReassignReviewer(ctx context.Context, req *models.PRReassignRequest) (*models.PullRequest, string, error)
 	GetByID(ctx context.Context, prID string) (*models.PullRequest, error)
-	GetByReviewer(ctx context.Context, reviewerID string) ([]*models.PullRequestShort, error)
+	GetByIDs(ctx context.Context, prIDs []string) ([]*models.PullRequest, error)
+	GetByReviewer(ctx context.Context, reviewerID string, status models.PullRequestStatus, page models.PageRequest) (*models.ReviewQueue, error)
+	GetReviewsByReviewers(ctx context.Context, reviewerIDs []string, status models.PullRequestStatus) (map[string][]*models.ReviewPR, error)
+	SubmitReview(ctx context.Context, req *models.PRReviewRequest) (*models.PRReview, error)
+	List(ctx context.Context, filter models.PRFilter, page models.PageRequest) (*models.PRPage, error)
+	GetDetails(ctx context.Context, prID string) (*models.PullRequestDetails, error)
+	Search(ctx context.Context, search models.PRSearch) ([]*models.PullRequest, error)
 }
 
 type prService struct {
@@ -20,6 +27,7 @@ type prService struct {
 	userRepo         repository.UserRepository
 	teamRepo         repository.TeamRepository
 	reviewerSelector ReviewerSelector
+	events           eventEmitter
 }
 
 func NewPRService(
@@ -27,12 +35,14 @@ func NewPRService(
 	userRepo repository.UserRepository,
 	teamRepo repository.TeamRepository,
 	reviewerSelector ReviewerSelector,
+	publisher EventPublisher,
 ) PRService {
 	return &prService{
 		prRepo:           prRepo,
 		userRepo:         userRepo,
 		teamRepo:         teamRepo,
 		reviewerSelector: reviewerSelector,
+		events:           eventEmitter{publisher: publisher, userRepo: userRepo},
 	}
 }
 
@@ -84,6 +94,10 @@ func (s *prService) Create(ctx context.Context, req *models.PRCreateRequest) (*m
 		return nil, err
 	}
 
+	logpkg.FromContext(ctx).Debug("pull request created", "pr_id", pr.ID, "reviewers", pr.AssignedReviewers)
+
+	s.events.pullRequest(ctx, models.EventPRCreated, models.PREventData{PullRequest: pr})
+
 	return pr, nil
 }
 
@@ -93,12 +107,17 @@ func (s *prService) Merge(ctx context.Context, req *models.PRMergeRequest) (*mod
 		return nil, err
 	}
 
+	if req.Version != 0 && pr.Version != req.Version {
+		return nil, models.ErrVersionConflict
+	}
+
 	if pr.Status == models.StatusMerged {
 		return pr, nil
 	}
 
 	mergedAt := time.Now()
-	if err := s.prRepo.Merge(ctx, req.ID, mergedAt); err != nil {
+	merged, err := s.prRepo.Merge(ctx, req.ID, req.Version, mergedAt)
+	if err != nil {
 		return nil, err
 	}
 
@@ -107,6 +126,11 @@ func (s *prService) Merge(ctx context.Context, req *models.PRMergeRequest) (*mod
 		return nil, err
 	}
 
+	// PR мог смержить параллельный запрос: событие публикует только тот, кто его изменил
+	if merged {
+		s.events.pullRequest(ctx, models.EventPRMerged, models.PREventData{PullRequest: mergedPR})
+	}
+
 	return mergedPR, nil
 }
 
@@ -116,6 +140,10 @@ func (s *prService) ReassignReviewer(ctx context.Context, req *models.PRReassign
 		return nil, "", err
 	}
 
+	if req.Version != 0 && pr.Version != req.Version {
+		return nil, "", models.ErrVersionConflict
+	}
+
 	if pr.Status == models.StatusMerged {
 		return nil, "", models.ErrPRMerged
 	}
@@ -147,11 +175,21 @@ func (s *prService) ReassignReviewer(ctx context.Context, req *models.PRReassign
 		}
 	}
 
+	// Update применится, только если PR не изменили с момента чтения: иначе
+	// параллельные переназначение или merge были бы перезаписаны
 	pr.AssignedReviewers = newReviewers
 	if err := s.prRepo.Update(ctx, pr); err != nil {
 		return nil, "", err
 	}
 
+	logpkg.FromContext(ctx).Debug("reviewer reassigned", "pr_id", pr.ID, "old_reviewer", req.OldReviewer, "new_reviewer", newReviewerID)
+
+	s.events.pullRequest(ctx, models.EventPRReassigned, models.PREventData{
+		PullRequest: pr,
+		OldReviewer: req.OldReviewer,
+		NewReviewer: newReviewerID,
+	})
+
 	return pr, newReviewerID, nil
 }
 
@@ -159,12 +197,117 @@ func (s *prService) GetByID(ctx context.Context, prID string) (*models.PullReque
 	return s.prRepo.GetByID(ctx, prID)
 }
 
-func (s *prService) GetByReviewer(ctx context.Context, reviewerID string) ([]*models.PullRequestShort, error) {
-	if _, err := s.userRepo.GetByID(ctx, reviewerID); err != nil {
+// GetByIDs возвращает найденные PR из списка одним запросом
+func (s *prService) GetByIDs(ctx context.Context, prIDs []string) ([]*models.PullRequest, error) {
+	return s.prRepo.GetByIDs(ctx, prIDs)
+}
+
+func (s *prService) GetByReviewer(
+	ctx context.Context,
+	reviewerID string,
+	status models.PullRequestStatus,
+	page models.PageRequest,
+) (*models.ReviewQueue, error) {
+	return loadReviewQueue(ctx, s.userRepo, s.prRepo, reviewerID, status, page)
+}
+
+// GetReviewsByReviewers возвращает PR, назначенные каждому из ревьюверов, с состоянием
+// ревью одним запросом. В отличие от GetByReviewer существование ревьюверов не проверяется.
+func (s *prService) GetReviewsByReviewers(
+	ctx context.Context,
+	reviewerIDs []string,
+	status models.PullRequestStatus,
+) (map[string][]*models.ReviewPR, error) {
+	return s.prRepo.GetReviewsByReviewers(ctx, reviewerIDs, status)
+}
+
+// SubmitReview сохраняет ревью назначенного ревьювера. После merge ревью не принимаются.
+func (s *prService) SubmitReview(ctx context.Context, req *models.PRReviewRequest) (*models.PRReview, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.prRepo.GetByID(ctx, req.ID)
+	if err != nil {
 		return nil, err
 	}
 
-	return s.prRepo.GetByReviewer(ctx, reviewerID)
+	if pr.Status == models.StatusMerged {
+		return nil, models.ErrPRMerged
+	}
+
+	if !contains(pr.AssignedReviewers, req.ReviewerID) {
+		return nil, models.ErrNotAssigned
+	}
+
+	review := &models.PRReview{
+		PullRequestID: pr.ID,
+		ReviewerID:    req.ReviewerID,
+		State:         req.State,
+	}
+	if err := s.prRepo.SaveReview(ctx, review); err != nil {
+		return nil, err
+	}
+
+	logpkg.FromContext(ctx).Debug("review submitted", "pr_id", pr.ID, "reviewer", req.ReviewerID, "state", req.State)
+
+	return review, nil
+}
+
+func (s *prService) List(ctx context.Context, filter models.PRFilter, page models.PageRequest) (*models.PRPage, error) {
+	if filter.ReviewerID != "" {
+		if _, err := s.userRepo.GetByID(ctx, filter.ReviewerID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.prRepo.List(ctx, filter, page)
+}
+
+// GetDetails возвращает PR с именами автора и ревьюверов
+func (s *prService) GetDetails(ctx context.Context, prID string) (*models.PullRequestDetails, error) {
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.userRepo.GetByIDs(ctx, append([]string{pr.AuthorID}, pr.AssignedReviewers...))
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*models.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+
+	details := &models.PullRequestDetails{
+		PullRequest: *pr,
+		Reviewers:   make([]*models.PRReviewer, 0, len(pr.AssignedReviewers)),
+	}
+	if author, ok := byID[pr.AuthorID]; ok {
+		details.AuthorUsername = author.Username
+	}
+	for _, reviewerID := range pr.AssignedReviewers {
+		reviewer := &models.PRReviewer{UserID: reviewerID}
+		if user, ok := byID[reviewerID]; ok {
+			reviewer.Username = user.Username
+			reviewer.IsActive = user.IsActive
+		}
+		details.Reviewers = append(details.Reviewers, reviewer)
+	}
+
+	return details, nil
+}
+
+func (s *prService) Search(ctx context.Context, search models.PRSearch) ([]*models.PullRequest, error) {
+	if search.Filter.ReviewerID != "" {
+		if _, err := s.userRepo.GetByID(ctx, search.Filter.ReviewerID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.prRepo.Search(ctx, search)
 }
 
 func contains(slice []string, item string) bool {
@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *stubUserRepo) GetByIDs(_ context.Context, userIDs []string) ([]*models.User, error) {
+	var users []*models.User
+	for _, id := range userIDs {
+		if user, ok := r.users[id]; ok {
+			found := *user
+			users = append(users, &found)
+		}
+	}
+	return users, nil
+}
+
+func (r *stubPRRepo) GetByID(_ context.Context, prID string) (*models.PullRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pr, ok := r.prs[prID]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	found := *pr
+	return &found, nil
+}
+
+// Merge, как и репозиторий, сообщает, перевел ли PR в MERGED именно этот вызов
+func (r *stubPRRepo) Merge(_ context.Context, prID string, version int, mergedAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pr, ok := r.prs[prID]
+	if !ok {
+		return false, models.ErrNotFound
+	}
+	if version != 0 && pr.Version != version {
+		return false, models.ErrVersionConflict
+	}
+	if pr.Status == models.StatusMerged {
+		return false, nil
+	}
+
+	merged := *pr
+	merged.Status = models.StatusMerged
+	merged.MergedAt = &mergedAt
+	merged.Version++
+	r.prs[prID] = &merged
+	return true, nil
+}
+
+// racingMergeRepo мержит PR «параллельным запросом» между чтением PR сервисом и его merge
+type racingMergeRepo struct {
+	*stubPRRepo
+}
+
+func (r *racingMergeRepo) Merge(ctx context.Context, prID string, version int, mergedAt time.Time) (bool, error) {
+	if _, err := r.stubPRRepo.Merge(ctx, prID, 0, mergedAt); err != nil {
+		return false, err
+	}
+	return r.stubPRRepo.Merge(ctx, prID, version, mergedAt)
+}
+
+func TestPRServiceMergePublishesOnce(t *testing.T) {
+	userRepo := &stubUserRepo{users: map[string]*models.User{}}
+	prRepo := &stubPRRepo{prs: map[string]*models.PullRequest{
+		"pr-1": {ID: "pr-1", AuthorID: "u1", Status: models.StatusOpen, Version: 1},
+		"pr-2": {ID: "pr-2", AuthorID: "u1", Status: models.StatusOpen, Version: 1},
+	}}
+	publisher := &recordingPublisher{}
+	ctx := context.Background()
+
+	svc := NewPRService(prRepo, userRepo, stubTeamRepo{}, NewReviewerSelector(userRepo), publisher)
+	_, err := svc.Merge(ctx, &models.PRMergeRequest{ID: "pr-1"})
+	require.NoError(t, err)
+	_, err = svc.Merge(ctx, &models.PRMergeRequest{ID: "pr-1"})
+	require.NoError(t, err)
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, models.EventPRMerged, publisher.events[0].Type)
+
+	// PR смержили между чтением и merge: ответ тот же, но событие уже опубликовал другой запрос
+	racing := NewPRService(&racingMergeRepo{prRepo}, userRepo, stubTeamRepo{}, NewReviewerSelector(userRepo), publisher)
+	pr, err := racing.Merge(ctx, &models.PRMergeRequest{ID: "pr-2"})
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusMerged, pr.Status)
+	assert.Len(t, publisher.events, 1)
+}
+
+func TestPRServiceGetDetailsResolvesUsernames(t *testing.T) {
+	userRepo := &stubUserRepo{users: map[string]*models.User{
+		"u1": {ID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		"u2": {ID: "u2", Username: "Bob", TeamName: "backend", IsActive: false},
+	}}
+	prRepo := &stubPRRepo{prs: map[string]*models.PullRequest{
+		"pr-1": {ID: "pr-1", Name: "Add search", AuthorID: "u1", Status: models.StatusOpen, AssignedReviewers: []string{"u2", "u9"}},
+	}}
+	svc := NewPRService(prRepo, userRepo, stubTeamRepo{}, NewReviewerSelector(userRepo), nil)
+
+	details, err := svc.GetDetails(context.Background(), "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Add search", details.Name)
+	assert.Equal(t, "Alice", details.AuthorUsername)
+	// Ревьювер, которого нет в базе, остается в списке без имени
+	assert.Equal(t, []*models.PRReviewer{
+		{UserID: "u2", Username: "Bob", IsActive: false},
+		{UserID: "u9"},
+	}, details.Reviewers)
+
+	_, err = svc.GetDetails(context.Background(), "pr-404")
+	assert.ErrorIs(t, err, models.ErrNotFound)
+}
+
+// stubReviewRepo хранит ревью в памяти поверх stubPRRepo
+type stubReviewRepo struct {
+	*stubPRRepo
+	reviews map[[2]string]models.ReviewState
+}
+
+// GetReviews отдает PR в порядке id; курсор хранит id последнего PR страницы.
+// Как и в репозитории, смерженные PR без ревью отбрасываются до лимита, а счетчики
+// считаются по всем PR ревьювера
+func (r *stubReviewRepo) GetReviews(
+	_ context.Context,
+	reviewerID string,
+	status models.PullRequestStatus,
+	page models.PageRequest,
+) (*models.ReviewPage, error) {
+	page.Normalize()
+
+	var (
+		prs []*models.ReviewPR
+		all []*models.ReviewPR
+	)
+	for _, id := range []string{"pr-1", "pr-2", "pr-3", "pr-4"} {
+		pr, ok := r.prs[id]
+		if !ok || !contains(pr.AssignedReviewers, reviewerID) || (status != "" && pr.Status != status) {
+			continue
+		}
+		state, ok := r.reviews[[2]string{id, reviewerID}]
+		if !ok {
+			if pr.Status == models.StatusMerged {
+				continue
+			}
+			state = models.ReviewPending
+		}
+		review := &models.ReviewPR{
+			PullRequestShort: models.PullRequestShort{ID: pr.ID, Name: pr.Name, AuthorID: pr.AuthorID, Status: pr.Status},
+			ReviewState:      state,
+		}
+		all = append(all, review)
+		if page.Cursor == nil || id > page.Cursor.ID {
+			prs = append(prs, review)
+		}
+	}
+
+	result := &models.ReviewPage{PullRequests: prs, Counts: models.NewReviewQueue(all).Counts}
+	if len(prs) > page.Limit {
+		result.PullRequests = prs[:page.Limit]
+		result.NextCursor = (&models.Cursor{ID: prs[page.Limit-1].ID}).Encode()
+	}
+	return result, nil
+}
+
+func (r *stubReviewRepo) SaveReview(_ context.Context, review *models.PRReview) error {
+	r.reviews[[2]string{review.PullRequestID, review.ReviewerID}] = review.State
+	return nil
+}
+
+func TestReviewQueueSharedByServices(t *testing.T) {
+	userRepo := &stubUserRepo{users: map[string]*models.User{
+		"u1": {ID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		"u2": {ID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+	}}
+	prRepo := &stubReviewRepo{
+		stubPRRepo: &stubPRRepo{prs: map[string]*models.PullRequest{
+			"pr-1": {ID: "pr-1", AuthorID: "u1", Status: models.StatusOpen, AssignedReviewers: []string{"u2"}},
+			"pr-2": {ID: "pr-2", AuthorID: "u1", Status: models.StatusOpen, AssignedReviewers: []string{"u2"}},
+			"pr-3": {ID: "pr-3", AuthorID: "u1", Status: models.StatusOpen, AssignedReviewers: []string{"u2"}},
+			"pr-4": {ID: "pr-4", AuthorID: "u1", Status: models.StatusMerged, AssignedReviewers: []string{"u2"}},
+		}},
+		reviews: make(map[[2]string]models.ReviewState),
+	}
+	selector := NewReviewerSelector(userRepo)
+	prService := NewPRService(prRepo, userRepo, stubTeamRepo{}, selector, nil)
+	userService := NewUserService(userRepo, stubTeamRepo{}, prRepo, selector, nil)
+	ctx := context.Background()
+
+	_, err := prService.SubmitReview(ctx, &models.PRReviewRequest{ID: "pr-1", ReviewerID: "u2", State: models.ReviewApproved})
+	require.NoError(t, err)
+	_, err = prService.SubmitReview(ctx, &models.PRReviewRequest{ID: "pr-2", ReviewerID: "u2", State: models.ReviewChangesRequested})
+	require.NoError(t, err)
+
+	queue, err := userService.GetReviewPRs(ctx, "u2", "", models.PageRequest{})
+	require.NoError(t, err)
+	// Смерженный pr-4 без ревью не попадает в очередь
+	assert.Equal(t, models.ReviewCounts{NeedsReview: 1, Approved: 1, ChangesRequested: 1, Total: 3}, queue.Counts)
+	assert.Equal(t, "pr-3", queue.NeedsReview[0].ID)
+	assert.Equal(t, "pr-1", queue.Approved[0].ID)
+	assert.Equal(t, "pr-2", queue.ChangesRequested[0].ID)
+
+	assert.Empty(t, queue.NextCursor)
+
+	fromPRService, err := prService.GetByReviewer(ctx, "u2", "", models.PageRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, queue, fromPRService)
+
+	// Счетчики на каждой странице считаются по всей очереди
+	first, err := userService.GetReviewPRs(ctx, "u2", "", models.PageRequest{Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, first.All(), 2)
+	assert.Equal(t, queue.Counts, first.Counts)
+	require.NotEmpty(t, first.NextCursor)
+
+	cursor, err := models.DecodeCursor(first.NextCursor)
+	require.NoError(t, err)
+	second, err := userService.GetReviewPRs(ctx, "u2", "", models.PageRequest{Limit: 2, Cursor: cursor})
+	require.NoError(t, err)
+	assert.Equal(t, "pr-3", second.NeedsReview[0].ID)
+	assert.Equal(t, queue.Counts, second.Counts)
+	assert.Empty(t, second.NextCursor)
+
+	_, err = userService.GetReviewPRs(ctx, "u404", "", models.PageRequest{})
+	assert.ErrorIs(t, err, models.ErrNotFound)
+}
+
+func TestPRServiceSubmitReviewErrors(t *testing.T) {
+	userRepo := &stubUserRepo{users: map[string]*models.User{}}
+	prRepo := &stubReviewRepo{
+		stubPRRepo: &stubPRRepo{prs: map[string]*models.PullRequest{
+			"pr-1": {ID: "pr-1", AuthorID: "u1", Status: models.StatusOpen, AssignedReviewers: []string{"u2"}},
+			"pr-2": {ID: "pr-2", AuthorID: "u1", Status: models.StatusMerged, AssignedReviewers: []string{"u2"}},
+		}},
+		reviews: make(map[[2]string]models.ReviewState),
+	}
+	svc := NewPRService(prRepo, userRepo, stubTeamRepo{}, NewReviewerSelector(userRepo), nil)
+
+	tests := []struct {
+		name string
+		req  models.PRReviewRequest
+		want error
+	}{
+		{"Invalid state", models.PRReviewRequest{ID: "pr-1", ReviewerID: "u2", State: models.ReviewPending}, models.ErrInvalidReviewState},
+		{"Unknown PR", models.PRReviewRequest{ID: "pr-404", ReviewerID: "u2", State: models.ReviewApproved}, models.ErrNotFound},
+		{"Merged PR", models.PRReviewRequest{ID: "pr-2", ReviewerID: "u2", State: models.ReviewApproved}, models.ErrPRMerged},
+		{"Not assigned", models.PRReviewRequest{ID: "pr-1", ReviewerID: "u3", State: models.ReviewApproved}, models.ErrNotAssigned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.SubmitReview(context.Background(), &tt.req)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+	assert.Empty(t, prRepo.reviews)
+}
+
+// TestPRServiceConcurrentReassign запускает переназначения одного PR параллельно:
+// каждое успешное переназначение должно остаться в итоговом PR
+func TestPRServiceConcurrentReassign(t *testing.T) {
+	userRepo := &stubUserRepo{users: map[string]*models.User{
+		"u1": {ID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		"u2": {ID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		"u3": {ID: "u3", Username: "Carol", TeamName: "backend", IsActive: true},
+		"u4": {ID: "u4", Username: "Dave", TeamName: "frontend", IsActive: true},
+		"u5": {ID: "u5", Username: "Eve", TeamName: "frontend", IsActive: true},
+		"u6": {ID: "u6", Username: "Frank", TeamName: "frontend", IsActive: true},
+	}}
+	prRepo := &stubPRRepo{prs: map[string]*models.PullRequest{
+		"pr-1": {ID: "pr-1", AuthorID: "u1", Status: models.StatusOpen, AssignedReviewers: []string{"u2", "u4"}, Version: 1},
+	}}
+	svc := NewPRService(prRepo, userRepo, stubTeamRepo{}, NewReviewerSelector(userRepo), nil)
+
+	const workers = 8
+	var (
+		wg      sync.WaitGroup
+		start   = make(chan struct{})
+		results = make(chan *models.PullRequest, workers)
+	)
+	for i := range workers {
+		oldReviewer := []string{"u2", "u4"}[i%2]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			pr, _, err := svc.ReassignReviewer(context.Background(), &models.PRReassignRequest{ID: "pr-1", OldReviewer: oldReviewer})
+			if err != nil {
+				// Ревьювера мог уже заменить другой запрос
+				if !errors.Is(err, models.ErrNotAssigned) {
+					assert.ErrorIs(t, err, models.ErrVersionConflict)
+				}
+				return
+			}
+			results <- pr
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(results)
+
+	final, err := prRepo.GetByID(context.Background(), "pr-1")
+	require.NoError(t, err)
+
+	// Каждая успешная запись получила свою версию, и последняя из них - текущий PR
+	versions := make(map[int]bool)
+	var last *models.PullRequest
+	for pr := range results {
+		assert.False(t, versions[pr.Version], "two reassigns applied on top of the same version")
+		versions[pr.Version] = true
+		if last == nil || pr.Version > last.Version {
+			last = pr
+		}
+	}
+	require.NotNil(t, last)
+	assert.Equal(t, 1+len(versions), final.Version)
+	assert.Equal(t, last.AssignedReviewers, final.AssignedReviewers)
+
+	// Устаревшая версия из If-Match отклоняется до записи
+	_, _, err = svc.ReassignReviewer(context.Background(), &models.PRReassignRequest{
+		ID: "pr-1", OldReviewer: final.AssignedReviewers[0], Version: 1,
+	})
+	assert.ErrorIs(t, err, models.ErrVersionConflict)
+	_, err = svc.Merge(context.Background(), &models.PRMergeRequest{ID: "pr-1", Version: 1})
+	assert.ErrorIs(t, err, models.ErrVersionConflict)
+}
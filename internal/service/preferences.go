@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/repository"
+)
+
+type PreferencesService interface {
+	Get(ctx context.Context, userID string) (*models.NotificationPreferences, error)
+	Update(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error)
+}
+
+type preferencesService struct {
+	preferencesRepo repository.PreferencesRepository
+	userRepo        repository.UserRepository
+}
+
+func NewPreferencesService(preferencesRepo repository.PreferencesRepository, userRepo repository.UserRepository) PreferencesService {
+	return &preferencesService{preferencesRepo: preferencesRepo, userRepo: userRepo}
+}
+
+// Get возвращает настройки пользователя; если он их не сохранял - настройки по умолчанию
+func (s *preferencesService) Get(ctx context.Context, userID string) (*models.NotificationPreferences, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	preferences, err := s.preferencesRepo.Get(ctx, userID)
+	if errors.Is(err, models.ErrNotFound) {
+		return models.DefaultNotificationPreferences(userID), nil
+	}
+	return preferences, err
+}
+
+func (s *preferencesService) Update(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error) {
+	if err := preferences.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, preferences.UserID); err != nil {
+		return nil, err
+	}
+
+	if err := s.preferencesRepo.Upsert(ctx, preferences); err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
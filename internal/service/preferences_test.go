@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubPreferencesRepo struct {
+	repository.PreferencesRepository
+	saved map[string]*models.NotificationPreferences
+}
+
+func (r *stubPreferencesRepo) Get(_ context.Context, userID string) (*models.NotificationPreferences, error) {
+	p, ok := r.saved[userID]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return p, nil
+}
+
+func (r *stubPreferencesRepo) Upsert(_ context.Context, p *models.NotificationPreferences) error {
+	r.saved[p.UserID] = p
+	return nil
+}
+
+func newTestPreferencesService() (PreferencesService, *stubPreferencesRepo) {
+	prefsRepo := &stubPreferencesRepo{saved: make(map[string]*models.NotificationPreferences)}
+	userRepo := &stubUserRepo{users: map[string]*models.User{"u1": {ID: "u1", Username: "alice", IsActive: true}}}
+	return NewPreferencesService(prefsRepo, userRepo), prefsRepo
+}
+
+func TestPreferencesDefaultsAndUpdate(t *testing.T) {
+	svc, repo := newTestPreferencesService()
+	ctx := context.Background()
+
+	p, err := svc.Get(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, models.DefaultNotificationPreferences("u1"), p)
+
+	p.Delivery = models.DeliveryDigest
+	p.QuietHours = &models.QuietHours{Start: "22:00", End: "07:30"}
+	p.Timezone = "Europe/Moscow"
+	_, err = svc.Update(ctx, p)
+	require.NoError(t, err)
+
+	saved, err := svc.Get(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, models.DeliveryDigest, saved.Delivery)
+	assert.Same(t, repo.saved["u1"], saved)
+}
+
+func TestPreferencesValidation(t *testing.T) {
+	svc, repo := newTestPreferencesService()
+	ctx := context.Background()
+
+	cases := []struct {
+		name   string
+		update func(p *models.NotificationPreferences)
+		err    error
+	}{
+		{"channel", func(p *models.NotificationPreferences) { p.Channels = []models.NotificationChannel{"sms"} }, models.ErrInvalidChannel},
+		{"event type", func(p *models.NotificationPreferences) { p.EventTypes = []models.NotificationKind{"merged"} }, models.ErrInvalidNotificationType},
+		{"quiet hours", func(p *models.NotificationPreferences) {
+			p.QuietHours = &models.QuietHours{Start: "25:00", End: "07:00"}
+		}, models.ErrInvalidQuietHours},
+		{"timezone", func(p *models.NotificationPreferences) { p.Timezone = "Mars/Olympus" }, models.ErrInvalidTimezone},
+		{"delivery", func(p *models.NotificationPreferences) { p.Delivery = "weekly" }, models.ErrInvalidDelivery},
+		{"email", func(p *models.NotificationPreferences) { p.Email = "Bob <bob@corp.example>" }, models.ErrInvalidEmail},
+		{"webhook", func(p *models.NotificationPreferences) { p.WebhookURL = "ftp://hooks.example" }, models.ErrInvalidWebhookURL},
+		{"webhook localhost", func(p *models.NotificationPreferences) { p.WebhookURL = "http://localhost:8080/hook" }, models.ErrInvalidWebhookURL},
+		{"webhook loopback", func(p *models.NotificationPreferences) { p.WebhookURL = "http://127.0.0.1/hook" }, models.ErrInvalidWebhookURL},
+		{"webhook private", func(p *models.NotificationPreferences) { p.WebhookURL = "https://10.0.0.5/hook" }, models.ErrInvalidWebhookURL},
+		{"webhook link-local", func(p *models.NotificationPreferences) { p.WebhookURL = "http://169.254.169.254/latest" }, models.ErrInvalidWebhookURL},
+		{"webhook ipv6 loopback", func(p *models.NotificationPreferences) { p.WebhookURL = "http://[::1]/hook" }, models.ErrInvalidWebhookURL},
+		{"webhook cgnat", func(p *models.NotificationPreferences) { p.WebhookURL = "http://100.64.1.1/hook" }, models.ErrInvalidWebhookURL},
+		{"webhook benchmark", func(p *models.NotificationPreferences) { p.WebhookURL = "http://198.18.0.1/hook" }, models.ErrInvalidWebhookURL},
+		{"webhook documentation", func(p *models.NotificationPreferences) { p.WebhookURL = "http://203.0.113.7/hook" }, models.ErrInvalidWebhookURL},
+		{"webhook ipv4-mapped", func(p *models.NotificationPreferences) { p.WebhookURL = "http://[::ffff:10.0.0.1]/hook" }, models.ErrInvalidWebhookURL},
+		{"webhook nat64", func(p *models.NotificationPreferences) { p.WebhookURL = "http://[64:ff9b::a00:1]/hook" }, models.ErrInvalidWebhookURL},
+		{"webhook 6to4", func(p *models.NotificationPreferences) { p.WebhookURL = "http://[2002:a00:1::1]/hook" }, models.ErrInvalidWebhookURL},
+		{"webhook ula", func(p *models.NotificationPreferences) { p.WebhookURL = "http://[fd00::1]/hook" }, models.ErrInvalidWebhookURL},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := models.DefaultNotificationPreferences("u1")
+			tc.update(p)
+			_, err := svc.Update(ctx, p)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+	assert.Empty(t, repo.saved)
+
+	p := models.DefaultNotificationPreferences("u1")
+	p.WebhookURL = "https://93.184.216.34/hook"
+	_, err := svc.Update(ctx, p)
+	assert.NoError(t, err, "public addresses are allowed")
+
+	_, err = svc.Update(ctx, models.DefaultNotificationPreferences("u9"))
+	assert.ErrorIs(t, err, models.ErrNotFound)
+	_, err = svc.Get(ctx, "u9")
+	assert.ErrorIs(t, err, models.ErrNotFound)
+}
+
+func TestQuietHours(t *testing.T) {
+	p := models.DefaultNotificationPreferences("u1")
+	p.QuietHours = &models.QuietHours{Start: "22:00", End: "07:00"}
+	p.Timezone = "Europe/Moscow"
+
+	// 19:30 UTC - 22:30 MSK, 04:00 UTC - 07:00 MSK
+	assert.True(t, p.InQuietHours(time.Date(2025, 3, 10, 19, 30, 0, 0, time.UTC)))
+	assert.False(t, p.InQuietHours(time.Date(2025, 3, 10, 4, 0, 0, 0, time.UTC)))
+	assert.False(t, p.Allows(models.ChannelChat, models.NotifyAssigned, time.Date(2025, 3, 10, 19, 30, 0, 0, time.UTC)))
+	assert.True(t, p.Allows(models.ChannelChat, models.NotifyAssigned, time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/repository"
+)
+
+// loadReviewQueue - общая реализация UserService.GetReviewPRs и PRService.GetByReviewer
+func loadReviewQueue(
+	ctx context.Context,
+	userRepo repository.UserRepository,
+	prRepo repository.PullRequestRepository,
+	userID string,
+	status models.PullRequestStatus,
+	page models.PageRequest,
+) (*models.ReviewQueue, error) {
+	if _, err := userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	result, err := prRepo.GetReviews(ctx, userID, status, page)
+	if err != nil {
+		return nil, err
+	}
+
+	queue := models.NewReviewQueue(result.PullRequests)
+	queue.Counts = result.Counts
+	queue.NextCursor = result.NextCursor
+	return queue, nil
+}
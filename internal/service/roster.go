@@ -0,0 +1,405 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/repository"
+)
+
+type RosterService interface {
+	Import(ctx context.Context, roster *models.Roster, dryRun bool) (*models.RosterDiff, error)
+	Export(ctx context.Context) (*models.Roster, error)
+	Reconcile(ctx context.Context, roster *models.Roster, dryRun bool) (*models.ReconcilePlan, error)
+	CreateTeam(ctx context.Context, teamName string, memberIDs []string) error
+	SyncTeams(ctx context.Context, roster *models.Roster) (*models.ReconcilePlan, error)
+}
+
+type rosterService struct {
+	rosterRepo repository.RosterRepository
+	events     eventEmitter
+}
+
+func NewRosterService(rosterRepo repository.RosterRepository, userRepo repository.UserRepository, publisher EventPublisher) RosterService {
+	return &rosterService{
+		rosterRepo: rosterRepo,
+		events:     eventEmitter{publisher: publisher, userRepo: userRepo},
+	}
+}
+
+func (s *rosterService) Import(ctx context.Context, roster *models.Roster, dryRun bool) (*models.RosterDiff, error) {
+	if err := roster.Validate(); err != nil {
+		return nil, err
+	}
+
+	var diff *models.RosterDiff
+	_, err := s.sync(ctx, dryRun, func(current *models.Roster, openPRs []*models.PullRequest) (*models.ReconcilePlan, error) {
+		diff = diffRoster(current, roster, openPRs)
+		return diff.Plan(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+func (s *rosterService) Export(ctx context.Context) (*models.Roster, error) {
+	return s.rosterRepo.Load(ctx)
+}
+
+func (s *rosterService) Reconcile(ctx context.Context, roster *models.Roster, dryRun bool) (*models.ReconcilePlan, error) {
+	if err := roster.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.sync(ctx, dryRun, func(current *models.Roster, openPRs []*models.PullRequest) (*models.ReconcilePlan, error) {
+		return planReconcile(current, roster, openPRs), nil
+	})
+}
+
+// SyncTeams приводит к переданному составу только перечисленные в нем команды: их участники,
+// отсутствующие в составе, деактивируются, а остальные команды и пользователи не затрагиваются
+// и команды не удаляются. Так применяются группы LDAP.
+func (s *rosterService) SyncTeams(ctx context.Context, roster *models.Roster) (*models.ReconcilePlan, error) {
+	if err := roster.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.sync(ctx, false, func(current *models.Roster, openPRs []*models.PullRequest) (*models.ReconcilePlan, error) {
+		return planSync(current, roster, openPRs, true), nil
+	})
+}
+
+// CreateTeam создает команду и переводит в нее перечисленных пользователей, активируя их.
+// Ревью участников, покинувших прежнюю команду, переназначаются; все изменения применяются
+// одной транзакцией, поэтому при ошибке команда не создается.
+func (s *rosterService) CreateTeam(ctx context.Context, teamName string, memberIDs []string) error {
+	if err := (&models.Team{Name: teamName}).Validate(); err != nil {
+		return err
+	}
+
+	_, err := s.sync(ctx, false, func(current *models.Roster, openPRs []*models.PullRequest) (*models.ReconcilePlan, error) {
+		currentTeams, currentUsers := indexRoster(current)
+		if currentTeams[teamName] {
+			return nil, models.ErrTeamExists
+		}
+
+		plan := &models.ReconcilePlan{TeamsCreated: []string{teamName}}
+		final := maps.Clone(currentUsers)
+
+		for _, userID := range memberIDs {
+			before, ok := currentUsers[userID]
+			if !ok {
+				return nil, fmt.Errorf("member %s: %w", userID, models.ErrNotFound)
+			}
+			if final[userID] != before {
+				continue
+			}
+
+			after := &models.RosterUserState{Username: before.Username, TeamName: teamName, IsActive: true}
+			final[userID] = after
+			plan.UsersMoved = append(plan.UsersMoved, &models.RosterUserChange{UserID: userID, Before: before, After: after})
+		}
+
+		plan.Reassignments = planReassignments(currentUsers, final, openPRs)
+
+		return plan, nil
+	})
+
+	return err
+}
+
+// sync составляет и применяет план в транзакции репозитория, а после применения публикует
+// те же события, что и изменения через сервис пользователей: смену активности и замены ревьюверов
+func (s *rosterService) sync(ctx context.Context, dryRun bool, planner repository.RosterPlanner) (*models.ReconcilePlan, error) {
+	openPRs := make(map[string]*models.PullRequest)
+	plan, err := s.rosterRepo.Sync(ctx, dryRun, func(current *models.Roster, prs []*models.PullRequest) (*models.ReconcilePlan, error) {
+		for _, pr := range prs {
+			openPRs[pr.ID] = pr
+		}
+		return planner(current, prs)
+	})
+	if err != nil || dryRun {
+		return plan, err
+	}
+
+	for _, change := range plan.UserChanges() {
+		if change.Before == nil || change.Before.IsActive == change.After.IsActive {
+			continue
+		}
+
+		eventType := models.EventUserDeactivated
+		if change.After.IsActive {
+			eventType = models.EventUserActivated
+		}
+		s.events.user(ctx, eventType, &models.User{
+			ID:       change.UserID,
+			Username: change.After.Username,
+			TeamName: change.After.TeamName,
+			IsActive: change.After.IsActive,
+		})
+	}
+
+	for _, change := range plan.Reassignments {
+		pr, ok := openPRs[change.PullRequestID]
+		if !ok {
+			continue
+		}
+
+		updated := *pr
+		updated.AssignedReviewers = change.AssignedReviewers
+		updated.Version++
+
+		for _, oldReviewer := range slices.Sorted(maps.Keys(change.Replaced)) {
+			s.events.pullRequest(ctx, models.EventPRReassigned, models.PREventData{
+				PullRequest: &updated,
+				OldReviewer: oldReviewer,
+				NewReviewer: change.Replaced[oldReviewer],
+			})
+		}
+	}
+
+	return plan, nil
+}
+
+// indexRoster возвращает множество команд и состояние каждого пользователя
+func indexRoster(roster *models.Roster) (map[string]bool, map[string]*models.RosterUserState) {
+	teams := make(map[string]bool, len(roster.Teams))
+	users := make(map[string]*models.RosterUserState)
+
+	for _, team := range roster.Teams {
+		teams[team.Name] = true
+		for _, member := range team.Members {
+			users[member.UserID] = &models.RosterUserState{
+				Username: member.Username,
+				TeamName: team.Name,
+				IsActive: member.IsActive,
+			}
+		}
+	}
+
+	return teams, users
+}
+
+// diffRoster сравнивает желаемый состав с текущим. Импорт только добавляет и обновляет,
+// пользователи и команды, отсутствующие в желаемом составе, не затрагиваются.
+// Ревью пользователей, которых импорт деактивирует или переводит в другую команду,
+// переназначаются так же, как при синхронизации.
+func diffRoster(current, desired *models.Roster, openPRs []*models.PullRequest) *models.RosterDiff {
+	currentTeams, currentUsers := indexRoster(current)
+
+	diff := &models.RosterDiff{
+		TeamsCreated: []string{},
+		UsersCreated: []*models.RosterUserChange{},
+		UsersUpdated: []*models.RosterUserChange{},
+	}
+
+	final := make(map[string]*models.RosterUserState, len(currentUsers))
+	for userID, state := range currentUsers {
+		final[userID] = state
+	}
+
+	for _, team := range desired.Teams {
+		if !currentTeams[team.Name] {
+			diff.TeamsCreated = append(diff.TeamsCreated, team.Name)
+		}
+
+		for _, member := range team.Members {
+			after := &models.RosterUserState{
+				Username: member.Username,
+				TeamName: team.Name,
+				IsActive: member.IsActive,
+			}
+			final[member.UserID] = after
+
+			before, exists := currentUsers[member.UserID]
+			switch {
+			case !exists:
+				diff.UsersCreated = append(diff.UsersCreated, &models.RosterUserChange{UserID: member.UserID, After: after})
+			case *before != *after:
+				diff.UsersUpdated = append(diff.UsersUpdated, &models.RosterUserChange{UserID: member.UserID, Before: before, After: after})
+			default:
+				diff.UsersUnchanged++
+			}
+		}
+	}
+
+	diff.Reassignments = planReassignments(currentUsers, final, openPRs)
+
+	return diff
+}
+
+// planReconcile приводит текущий состав к желаемому. В отличие от импорта, пользователи,
+// отсутствующие в желаемом составе, деактивируются, а опустевшие команды удаляются.
+// Деактивированные пользователи остаются в своей команде, поэтому такая команда не удаляется.
+func planReconcile(current, desired *models.Roster, openPRs []*models.PullRequest) *models.ReconcilePlan {
+	return planSync(current, desired, openPRs, false)
+}
+
+// planSync составляет план синхронизации. При partial желаемый состав описывает только
+// перечисленные команды: деактивируются лишь их участники, и команды не удаляются.
+func planSync(current, desired *models.Roster, openPRs []*models.PullRequest, partial bool) *models.ReconcilePlan {
+	currentTeams, currentUsers := indexRoster(current)
+	desiredTeams, desiredUsers := indexRoster(desired)
+
+	plan := &models.ReconcilePlan{
+		TeamsCreated:     []string{},
+		TeamsDeleted:     []string{},
+		UsersCreated:     []*models.RosterUserChange{},
+		UsersUpdated:     []*models.RosterUserChange{},
+		UsersMoved:       []*models.RosterUserChange{},
+		UsersDeactivated: []*models.RosterUserChange{},
+		Reassignments:    []*models.PRReviewerChange{},
+	}
+
+	// Итоговое состояние пользователей после применения плана
+	final := make(map[string]*models.RosterUserState, len(currentUsers))
+	for userID, state := range currentUsers {
+		final[userID] = state
+	}
+
+	for _, team := range desired.Teams {
+		if !currentTeams[team.Name] {
+			plan.TeamsCreated = append(plan.TeamsCreated, team.Name)
+		}
+
+		for _, member := range team.Members {
+			after := desiredUsers[member.UserID]
+			before, exists := currentUsers[member.UserID]
+			change := &models.RosterUserChange{UserID: member.UserID, Before: before, After: after}
+			final[member.UserID] = after
+
+			switch {
+			case !exists:
+				plan.UsersCreated = append(plan.UsersCreated, change)
+			case before.TeamName != after.TeamName:
+				plan.UsersMoved = append(plan.UsersMoved, change)
+			case before.IsActive && !after.IsActive:
+				plan.UsersDeactivated = append(plan.UsersDeactivated, change)
+			case *before != *after:
+				plan.UsersUpdated = append(plan.UsersUpdated, change)
+			default:
+				plan.UsersUnchanged++
+			}
+		}
+	}
+
+	for _, team := range current.Teams {
+		if partial && !desiredTeams[team.Name] {
+			continue
+		}
+
+		for _, member := range team.Members {
+			if _, kept := desiredUsers[member.UserID]; kept {
+				continue
+			}
+
+			before := currentUsers[member.UserID]
+			if !before.IsActive {
+				plan.UsersUnchanged++
+				continue
+			}
+
+			after := *before
+			after.IsActive = false
+			final[member.UserID] = &after
+			plan.UsersDeactivated = append(plan.UsersDeactivated, &models.RosterUserChange{
+				UserID: member.UserID,
+				Before: before,
+				After:  &after,
+			})
+		}
+	}
+
+	remaining := make(map[string]bool)
+	for _, state := range final {
+		remaining[state.TeamName] = true
+	}
+
+	for _, team := range current.Teams {
+		if !partial && !desiredTeams[team.Name] && !remaining[team.Name] {
+			plan.TeamsDeleted = append(plan.TeamsDeleted, team.Name)
+		}
+	}
+
+	plan.Reassignments = planReassignments(currentUsers, final, openPRs)
+
+	return plan
+}
+
+// planReassignments заменяет ревьюверов открытых PR, которые после синхронизации
+// стали неактивными или перешли в другую команду. Замена ищется среди активных
+// участников прежней команды ревьювера: выбирается наименее загруженный открытыми
+// ревью, при равенстве - с меньшим ID, поэтому dry_run и применение дают один план.
+func planReassignments(currentUsers, final map[string]*models.RosterUserState, openPRs []*models.PullRequest) []*models.PRReviewerChange {
+	changes := []*models.PRReviewerChange{}
+
+	load := make(map[string]int)
+	for _, pr := range openPRs {
+		for _, reviewerID := range pr.AssignedReviewers {
+			load[reviewerID]++
+		}
+	}
+
+	members := make(map[string][]string)
+	for userID, state := range final {
+		if state.IsActive {
+			members[state.TeamName] = append(members[state.TeamName], userID)
+		}
+	}
+	for teamName := range members {
+		slices.Sort(members[teamName])
+	}
+
+	affected := func(userID string) bool {
+		before, ok := currentUsers[userID]
+		if !ok {
+			return false
+		}
+		after := final[userID]
+		return !after.IsActive || after.TeamName != before.TeamName
+	}
+
+	for _, pr := range openPRs {
+		var change *models.PRReviewerChange
+		reviewers := make([]string, 0, len(pr.AssignedReviewers))
+
+		for _, reviewerID := range pr.AssignedReviewers {
+			if !affected(reviewerID) {
+				reviewers = append(reviewers, reviewerID)
+				continue
+			}
+
+			if change == nil {
+				change = &models.PRReviewerChange{PullRequestID: pr.ID, Version: pr.Version, Replaced: make(map[string]string)}
+			}
+
+			replacement := ""
+			for _, candidate := range members[currentUsers[reviewerID].TeamName] {
+				if candidate == pr.AuthorID || slices.Contains(pr.AssignedReviewers, candidate) || slices.Contains(reviewers, candidate) {
+					continue
+				}
+				if replacement == "" || load[candidate] < load[replacement] {
+					replacement = candidate
+				}
+			}
+
+			change.Replaced[reviewerID] = replacement
+			if replacement != "" {
+				reviewers = append(reviewers, replacement)
+				load[replacement]++
+			}
+		}
+
+		if change != nil {
+			change.AssignedReviewers = reviewers
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
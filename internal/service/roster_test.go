@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffRoster(t *testing.T) {
+	current := &models.Roster{Teams: []*models.RosterTeam{
+		{Name: "backend", Members: []*models.RosterMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+		}},
+	}}
+
+	desired := &models.Roster{Teams: []*models.RosterTeam{
+		{Name: "backend", Members: []*models.RosterMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: false},
+		}},
+		{Name: "frontend", Members: []*models.RosterMember{
+			{UserID: "u3", Username: "Carol", IsActive: true},
+			{UserID: "u4", Username: "Dave", IsActive: true},
+		}},
+	}}
+
+	openPRs := []*models.PullRequest{
+		{ID: "pr-1", AuthorID: "u4", AssignedReviewers: []string{"u2", "u3"}, Version: 2},
+		{ID: "pr-2", AuthorID: "u2", AssignedReviewers: []string{"u1"}, Version: 1},
+	}
+
+	diff := diffRoster(current, desired, openPRs)
+
+	assert.Equal(t, []string{"frontend"}, diff.TeamsCreated)
+	assert.Equal(t, 1, diff.UsersUnchanged)
+
+	require.Len(t, diff.UsersCreated, 1)
+	assert.Equal(t, "u4", diff.UsersCreated[0].UserID)
+	assert.Nil(t, diff.UsersCreated[0].Before)
+
+	require.Len(t, diff.UsersUpdated, 2)
+	assert.Equal(t, "u2", diff.UsersUpdated[0].UserID)
+	assert.False(t, diff.UsersUpdated[0].After.IsActive)
+	assert.Equal(t, "u3", diff.UsersUpdated[1].UserID)
+	assert.Equal(t, "backend", diff.UsersUpdated[1].Before.TeamName)
+	assert.Equal(t, "frontend", diff.UsersUpdated[1].After.TeamName)
+
+	// u2 деактивирован, u3 перешел в frontend: в backend остался только u1
+	assert.Equal(t, []*models.PRReviewerChange{{
+		PullRequestID:     "pr-1",
+		Version:           2,
+		Replaced:          map[string]string{"u2": "u1", "u3": ""},
+		AssignedReviewers: []string{"u1"},
+	}}, diff.Reassignments)
+
+	assert.True(t, diffRoster(current, current, openPRs).Empty())
+}
+
+func TestPlanReconcile(t *testing.T) {
+	current := &models.Roster{Teams: []*models.RosterTeam{
+		{Name: "backend", Members: []*models.RosterMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+			{UserID: "u4", Username: "Dave", IsActive: true},
+		}},
+		{Name: "legacy", Members: []*models.RosterMember{
+			{UserID: "u5", Username: "Eve", IsActive: true},
+		}},
+		{Name: "archive", Members: []*models.RosterMember{
+			{UserID: "u6", Username: "Frank", IsActive: false},
+		}},
+	}}
+
+	desired := &models.Roster{Teams: []*models.RosterTeam{
+		{Name: "backend", Members: []*models.RosterMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Robert", IsActive: true},
+			{UserID: "u5", Username: "Eve", IsActive: true},
+		}},
+		{Name: "frontend", Members: []*models.RosterMember{
+			{UserID: "u3", Username: "Carol", IsActive: true},
+			{UserID: "u7", Username: "Grace", IsActive: true},
+		}},
+	}}
+
+	openPRs := []*models.PullRequest{
+		{ID: "pr-1", AuthorID: "u1", AssignedReviewers: []string{"u3", "u4"}, Version: 4},
+		{ID: "pr-2", AuthorID: "u2", AssignedReviewers: []string{"u1"}, Version: 1},
+	}
+
+	plan := planReconcile(current, desired, openPRs)
+
+	assert.Equal(t, []string{"frontend"}, plan.TeamsCreated)
+	// legacy опустела после переезда Eve, archive остается с деактивированным Frank
+	assert.Equal(t, []string{"legacy"}, plan.TeamsDeleted)
+	assert.Equal(t, 2, plan.UsersUnchanged)
+
+	require.Len(t, plan.UsersCreated, 1)
+	assert.Equal(t, "u7", plan.UsersCreated[0].UserID)
+
+	require.Len(t, plan.UsersUpdated, 1)
+	assert.Equal(t, "u2", plan.UsersUpdated[0].UserID)
+	assert.Equal(t, "Robert", plan.UsersUpdated[0].After.Username)
+
+	require.Len(t, plan.UsersMoved, 2)
+	assert.Equal(t, "u5", plan.UsersMoved[0].UserID)
+	assert.Equal(t, "u3", plan.UsersMoved[1].UserID)
+
+	require.Len(t, plan.UsersDeactivated, 1)
+	assert.Equal(t, "u4", plan.UsersDeactivated[0].UserID)
+	assert.Equal(t, "backend", plan.UsersDeactivated[0].After.TeamName)
+	assert.False(t, plan.UsersDeactivated[0].After.IsActive)
+
+	// Оба ревьювера pr-1 выбывают из backend, свободен для замены только Eve (u5)
+	require.Len(t, plan.Reassignments, 1)
+	change := plan.Reassignments[0]
+	assert.Equal(t, "pr-1", change.PullRequestID)
+	assert.Equal(t, 4, change.Version, "replacement is applied only to the version it was planned for")
+	assert.Equal(t, map[string]string{"u3": "u2", "u4": "u5"}, change.Replaced)
+	assert.Equal(t, []string{"u2", "u5"}, change.AssignedReviewers)
+
+	assert.True(t, planReconcile(desired, desired, nil).Empty())
+}
+
+func TestPlanReconcileNoReplacement(t *testing.T) {
+	current := &models.Roster{Teams: []*models.RosterTeam{
+		{Name: "backend", Members: []*models.RosterMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+		}},
+	}}
+	desired := &models.Roster{Teams: []*models.RosterTeam{
+		{Name: "backend", Members: []*models.RosterMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: false},
+		}},
+	}}
+	openPRs := []*models.PullRequest{
+		{ID: "pr-1", AuthorID: "u1", AssignedReviewers: []string{"u2"}},
+	}
+
+	plan := planReconcile(current, desired, openPRs)
+
+	require.Len(t, plan.Reassignments, 1)
+	assert.Equal(t, map[string]string{"u2": ""}, plan.Reassignments[0].Replaced)
+	assert.Empty(t, plan.Reassignments[0].AssignedReviewers)
+}
+
+func TestPlanReconcileLeastLoaded(t *testing.T) {
+	current := &models.Roster{Teams: []*models.RosterTeam{
+		{Name: "backend", Members: []*models.RosterMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+			{UserID: "u4", Username: "Dave", IsActive: true},
+		}},
+	}}
+	desired := &models.Roster{Teams: []*models.RosterTeam{
+		{Name: "backend", Members: []*models.RosterMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+			{UserID: "u4", Username: "Dave", IsActive: false},
+		}},
+	}}
+	openPRs := []*models.PullRequest{
+		{ID: "pr-1", AuthorID: "u9", AssignedReviewers: []string{"u4"}},
+		{ID: "pr-2", AuthorID: "u9", AssignedReviewers: []string{"u1", "u2"}},
+		{ID: "pr-3", AuthorID: "u9", AssignedReviewers: []string{"u1"}},
+		{ID: "pr-4", AuthorID: "u9", AssignedReviewers: []string{"u4", "u3"}},
+	}
+
+	plan := planReconcile(current, desired, openPRs)
+
+	// Нагрузка до замены: u1 - 2, u2 - 1, u3 - 1. В pr-1 u2 и u3 равны, выбирается меньший ID;
+	// в pr-4 у u1 и u2 уже по два ревью, и снова выбирается меньший ID
+	require.Len(t, plan.Reassignments, 2)
+	assert.Equal(t, map[string]string{"u4": "u2"}, plan.Reassignments[0].Replaced)
+	assert.Equal(t, map[string]string{"u4": "u1"}, plan.Reassignments[1].Replaced)
+	assert.Equal(t, []string{"u1", "u3"}, plan.Reassignments[1].AssignedReviewers)
+
+	assert.Equal(t, plan, planReconcile(current, desired, openPRs), "plan must not depend on the run")
+}
+
+// stubRosterRepo передает планировщику заданное состояние и запоминает примененный план
+type stubRosterRepo struct {
+	repository.RosterRepository
+	current *models.Roster
+	openPRs []*models.PullRequest
+	applied *models.ReconcilePlan
+}
+
+func (r *stubRosterRepo) Sync(_ context.Context, dryRun bool, planner repository.RosterPlanner) (*models.ReconcilePlan, error) {
+	plan, err := planner(r.current, r.openPRs)
+	if err != nil {
+		return nil, err
+	}
+	if !dryRun {
+		r.applied = plan
+	}
+	return plan, nil
+}
+
+func TestCreateTeam(t *testing.T) {
+	repo := &stubRosterRepo{
+		current: &models.Roster{Teams: []*models.RosterTeam{
+			{Name: "backend", Members: []*models.RosterMember{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+				{UserID: "u2", Username: "Bob", IsActive: false},
+				{UserID: "u3", Username: "Carol", IsActive: true},
+			}},
+		}},
+		openPRs: []*models.PullRequest{
+			{ID: "pr-1", AuthorID: "u3", AssignedReviewers: []string{"u1"}},
+		},
+	}
+	roster := NewRosterService(repo, nil, nil)
+	ctx := context.Background()
+
+	require.ErrorIs(t, roster.CreateTeam(ctx, "backend", nil), models.ErrTeamExists)
+	require.ErrorIs(t, roster.CreateTeam(ctx, "frontend", []string{"u1", "ghost"}), models.ErrNotFound)
+	require.Nil(t, repo.applied)
+
+	require.NoError(t, roster.CreateTeam(ctx, "frontend", []string{"u1", "u2", "u1"}))
+	assert.Equal(t, []string{"frontend"}, repo.applied.TeamsCreated)
+	require.Len(t, repo.applied.UsersMoved, 2)
+	assert.Equal(t, &models.RosterUserState{Username: "Bob", TeamName: "frontend", IsActive: true}, repo.applied.UsersMoved[1].After)
+
+	// u1 ушел из backend, а заменить его некем: автор pr-1 - единственный активный участник
+	require.Len(t, repo.applied.Reassignments, 1)
+	assert.Equal(t, map[string]string{"u1": ""}, repo.applied.Reassignments[0].Replaced)
+}
+
+func TestSyncTeamsPublishesEvents(t *testing.T) {
+	repo := &stubRosterRepo{
+		current: &models.Roster{Teams: []*models.RosterTeam{
+			{Name: "backend", Members: []*models.RosterMember{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+				{UserID: "u2", Username: "Bob", IsActive: true},
+				{UserID: "u3", Username: "Carol", IsActive: true},
+			}},
+			{Name: "legacy", Members: []*models.RosterMember{
+				{UserID: "u4", Username: "Dave", IsActive: true},
+			}},
+		}},
+		openPRs: []*models.PullRequest{
+			{ID: "pr-1", AuthorID: "u1", AssignedReviewers: []string{"u2"}, Version: 2},
+		},
+	}
+	userRepo := &stubUserRepo{users: map[string]*models.User{
+		"u1": {ID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+	}}
+	publisher := &recordingPublisher{}
+
+	desired := &models.Roster{Teams: []*models.RosterTeam{
+		{Name: "backend", Members: []*models.RosterMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+		}},
+	}}
+
+	plan, err := NewRosterService(repo, userRepo, publisher).SyncTeams(context.Background(), desired)
+	require.NoError(t, err)
+
+	// legacy не описана в составе и не затрагивается
+	require.Len(t, plan.UsersDeactivated, 1)
+	assert.Equal(t, "u2", plan.UsersDeactivated[0].UserID)
+	assert.Empty(t, plan.TeamsDeleted)
+
+	require.Len(t, publisher.events, 2)
+	assert.Equal(t, models.EventUserDeactivated, publisher.events[0].Type)
+	assert.Equal(t, []string{"u2"}, publisher.events[0].UserIDs)
+	assert.Equal(t, models.EventPRReassigned, publisher.events[1].Type)
+	assert.Equal(t, "backend", publisher.events[1].TeamName)
+	assert.Equal(t, []string{"u1", "u3", "u2"}, publisher.events[1].UserIDs)
+}
@@ -5,19 +5,26 @@ import (
 )
 
 type Service struct {
-	User  UserService
-	Team  TeamService
-	PR    PRService
-	Stats StatsService
+	User   UserService
+	Team   TeamService
+	PR     PRService
+	Stats  StatsService
+	Roster RosterService
+
+	Preferences PreferencesService
 }
 
-func New(repo *repository.Repository) *Service {
+// New создает сервисы; publisher получает события PR и пользователей, nil - события не публикуются
+func New(repo *repository.Repository, publisher EventPublisher) *Service {
 	reviewerSelector := NewReviewerSelector(repo.User)
 
 	return &Service{
-		User:  NewUserService(repo.User),
-		Team:  NewTeamService(repo.Team, repo.User),
-		PR:    NewPRService(repo.PullRequest, repo.User, repo.Team, reviewerSelector),
-		Stats: NewStatsService(repo.Stats, repo.User),
+		User:   NewUserService(repo.User, repo.Team, repo.PullRequest, reviewerSelector, publisher),
+		Team:   newTracedTeamService(NewTeamService(repo.Team, repo.User)),
+		PR:     newTracedPRService(NewPRService(repo.PullRequest, repo.User, repo.Team, reviewerSelector, publisher)),
+		Stats:  NewStatsService(repo.Stats, repo.User),
+		Roster: NewRosterService(repo.Roster, repo.User, publisher),
+
+		Preferences: NewPreferencesService(repo.Preferences, repo.User),
 	}
 }
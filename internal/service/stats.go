@@ -4,11 +4,13 @@ import (
 	"github.com/vnchk1/pr-manager/internal/models"
 	"github.com/vnchk1/pr-manager/internal/repository"
 	"context"
+	"time"
 )
 
 type StatsService interface {
-	GetAssignmentStats(ctx context.Context) (*models.AssignmentStatsResponse, error)
+	GetAssignmentStats(ctx context.Context, filter models.AssignmentStatsFilter, page models.PageRequest) (*models.AssignmentStatsResponse, error)
 	GetUserStats(ctx context.Context, userID string) (*models.UserAssignmentStats, error)
+	GetFairnessReport(ctx context.Context, giniThreshold float64) (*models.FairnessReport, error)
 }
 
 type statsService struct {
@@ -23,24 +25,10 @@ func NewStatsService(statsRepo repository.StatsRepository, userRepo repository.U
 	}
 }
 
-func (s *statsService) GetAssignmentStats(ctx context.Context) (*models.AssignmentStatsResponse, error) {
-	userStats, err := s.statsRepo.GetAssignmentStats(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	prStats, err := s.statsRepo.GetPRAssignmentStats(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	summary := s.calculateSummary(userStats, prStats)
-
-	return &models.AssignmentStatsResponse{
-		UserStats: userStats,
-		PRStats:   prStats,
-		Summary:   summary,
-	}, nil
+// GetAssignmentStats возвращает страницу статистики по пользователям; сводка
+// считается по всем активным пользователям независимо от фильтра и страницы.
+func (s *statsService) GetAssignmentStats(ctx context.Context, filter models.AssignmentStatsFilter, page models.PageRequest) (*models.AssignmentStatsResponse, error) {
+	return s.statsRepo.GetAssignmentStats(ctx, filter, page)
 }
 
 func (s *statsService) GetUserStats(ctx context.Context, userID string) (*models.UserAssignmentStats, error) {
@@ -63,23 +51,11 @@ func (s *statsService) GetUserStats(ctx context.Context, userID string) (*models
 	}, nil
 }
 
-func (s *statsService) calculateSummary(userStats []*models.UserAssignmentStats, prStats *models.PRAssignmentStats) *models.StatsSummary {
-	summary := &models.StatsSummary{
-		TotalUsers:       len(userStats),
-		TotalAssignments: 0,
-	}
-
-	for _, stat := range userStats {
-		if stat.IsActive {
-			summary.ActiveUsers++
-		}
-		summary.TotalAssignments += stat.AssignmentCount
-
-		if stat.AssignmentCount > summary.MostAssignments {
-			summary.MostAssignments = stat.AssignmentCount
-			summary.MostAssignedUser = stat.Username
-		}
+func (s *statsService) GetFairnessReport(ctx context.Context, giniThreshold float64) (*models.FairnessReport, error) {
+	members, err := s.statsRepo.GetMemberAssignments(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return summary
+	return buildFairnessReport(members, time.Now(), giniThreshold), nil
 }
@@ -9,6 +9,10 @@ import (
 type TeamService interface {
 	Create(ctx context.Context, team *models.Team) (*models.Team, error)
 	Get(ctx context.Context, teamName string) (*models.Team, error)
+	GetByNames(ctx context.Context, teamNames []string) ([]*models.Team, error)
+	List(ctx context.Context) ([]*models.Team, error)
+	Find(ctx context.Context, teamName string, page models.OffsetPage) ([]*models.Team, int, error)
+	Delete(ctx context.Context, teamName string) error
 }
 
 type teamService struct {
@@ -38,3 +42,21 @@ func (s *teamService) Create(ctx context.Context, team *models.Team) (*models.Te
 func (s *teamService) Get(ctx context.Context, teamName string) (*models.Team, error) {
 	return s.teamRepo.GetByName(ctx, teamName)
 }
+
+// GetByNames возвращает найденные команды из списка одним запросом
+func (s *teamService) GetByNames(ctx context.Context, teamNames []string) ([]*models.Team, error) {
+	return s.teamRepo.GetByNames(ctx, teamNames)
+}
+
+func (s *teamService) List(ctx context.Context) ([]*models.Team, error) {
+	return s.teamRepo.List(ctx)
+}
+
+// Find возвращает страницу команд и их общее число; непустой teamName выбирает одну команду
+func (s *teamService) Find(ctx context.Context, teamName string, page models.OffsetPage) ([]*models.Team, int, error) {
+	return s.teamRepo.Find(ctx, teamName, page)
+}
+
+func (s *teamService) Delete(ctx context.Context, teamName string) error {
+	return s.teamRepo.Delete(ctx, teamName)
+}
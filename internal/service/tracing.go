@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/vnchk1/pr-manager/internal/service")
+
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// tracedPRService оборачивает каждый метод PRService в спан
+type tracedPRService struct {
+	next PRService
+}
+
+func newTracedPRService(next PRService) PRService {
+	return &tracedPRService{next: next}
+}
+
+func (s *tracedPRService) Create(ctx context.Context, req *models.PRCreateRequest) (*models.PullRequest, error) {
+	ctx, span := startSpan(ctx, "PRService.Create",
+		attribute.String("pr.id", req.ID),
+		attribute.String("pr.author_id", req.AuthorID),
+	)
+	pr, err := s.next.Create(ctx, req)
+	if pr != nil {
+		span.SetAttributes(attribute.StringSlice("pr.reviewers", pr.AssignedReviewers))
+	}
+	endSpan(span, err)
+	return pr, err
+}
+
+func (s *tracedPRService) Merge(ctx context.Context, req *models.PRMergeRequest) (*models.PullRequest, error) {
+	ctx, span := startSpan(ctx, "PRService.Merge", attribute.String("pr.id", req.ID))
+	pr, err := s.next.Merge(ctx, req)
+	endSpan(span, err)
+	return pr, err
+}
+
+func (s *tracedPRService) ReassignReviewer(
+	ctx context.Context,
+	req *models.PRReassignRequest,
+) (*models.PullRequest, string, error) {
+	ctx, span := startSpan(ctx, "PRService.ReassignReviewer",
+		attribute.String("pr.id", req.ID),
+		attribute.String("pr.old_reviewer", req.OldReviewer),
+	)
+	pr, newReviewerID, err := s.next.ReassignReviewer(ctx, req)
+	span.SetAttributes(attribute.String("pr.new_reviewer", newReviewerID))
+	endSpan(span, err)
+	return pr, newReviewerID, err
+}
+
+func (s *tracedPRService) GetByID(ctx context.Context, prID string) (*models.PullRequest, error) {
+	ctx, span := startSpan(ctx, "PRService.GetByID", attribute.String("pr.id", prID))
+	pr, err := s.next.GetByID(ctx, prID)
+	endSpan(span, err)
+	return pr, err
+}
+
+func (s *tracedPRService) GetByIDs(ctx context.Context, prIDs []string) ([]*models.PullRequest, error) {
+	ctx, span := startSpan(ctx, "PRService.GetByIDs", attribute.Int("pr.requested", len(prIDs)))
+	prs, err := s.next.GetByIDs(ctx, prIDs)
+	if err == nil {
+		span.SetAttributes(attribute.Int("pr.found", len(prs)))
+	}
+	endSpan(span, err)
+	return prs, err
+}
+
+func (s *tracedPRService) GetByReviewer(
+	ctx context.Context,
+	reviewerID string,
+	status models.PullRequestStatus,
+	page models.PageRequest,
+) (*models.ReviewQueue, error) {
+	ctx, span := startSpan(ctx, "PRService.GetByReviewer",
+		attribute.String("pr.reviewer_id", reviewerID),
+		attribute.String("pr.status", string(status)),
+		attribute.Int("page.limit", page.Limit),
+	)
+	queue, err := s.next.GetByReviewer(ctx, reviewerID, status, page)
+	endSpan(span, err)
+	return queue, err
+}
+
+func (s *tracedPRService) GetReviewsByReviewers(
+	ctx context.Context,
+	reviewerIDs []string,
+	status models.PullRequestStatus,
+) (map[string][]*models.ReviewPR, error) {
+	ctx, span := startSpan(ctx, "PRService.GetReviewsByReviewers",
+		attribute.StringSlice("pr.reviewer_ids", reviewerIDs),
+		attribute.String("pr.status", string(status)),
+	)
+	reviews, err := s.next.GetReviewsByReviewers(ctx, reviewerIDs, status)
+	endSpan(span, err)
+	return reviews, err
+}
+
+func (s *tracedPRService) SubmitReview(ctx context.Context, req *models.PRReviewRequest) (*models.PRReview, error) {
+	ctx, span := startSpan(ctx, "PRService.SubmitReview",
+		attribute.String("pr.id", req.ID),
+		attribute.String("pr.reviewer_id", req.ReviewerID),
+		attribute.String("pr.review_state", string(req.State)),
+	)
+	review, err := s.next.SubmitReview(ctx, req)
+	endSpan(span, err)
+	return review, err
+}
+
+func (s *tracedPRService) List(ctx context.Context, filter models.PRFilter, page models.PageRequest) (*models.PRPage, error) {
+	ctx, span := startSpan(ctx, "PRService.List",
+		attribute.String("pr.author_id", filter.AuthorID),
+		attribute.String("pr.reviewer_id", filter.ReviewerID),
+		attribute.String("pr.team_name", filter.TeamName),
+		attribute.Int("page.limit", page.Limit),
+	)
+	result, err := s.next.List(ctx, filter, page)
+	endSpan(span, err)
+	return result, err
+}
+
+func (s *tracedPRService) GetDetails(ctx context.Context, prID string) (*models.PullRequestDetails, error) {
+	ctx, span := startSpan(ctx, "PRService.GetDetails", attribute.String("pr.id", prID))
+	details, err := s.next.GetDetails(ctx, prID)
+	endSpan(span, err)
+	return details, err
+}
+
+func (s *tracedPRService) Search(ctx context.Context, search models.PRSearch) ([]*models.PullRequest, error) {
+	ctx, span := startSpan(ctx, "PRService.Search",
+		attribute.String("pr.reviewer_id", search.Filter.ReviewerID),
+		attribute.String("pr.team_name", search.Filter.TeamName),
+		attribute.Int("page.limit", search.Limit),
+	)
+	prs, err := s.next.Search(ctx, search)
+	if err == nil {
+		span.SetAttributes(attribute.Int("pr.found", len(prs)))
+	}
+	endSpan(span, err)
+	return prs, err
+}
+
+// tracedTeamService оборачивает каждый метод TeamService в спан
+type tracedTeamService struct {
+	next TeamService
+}
+
+func newTracedTeamService(next TeamService) TeamService {
+	return &tracedTeamService{next: next}
+}
+
+func (s *tracedTeamService) Create(ctx context.Context, team *models.Team) (*models.Team, error) {
+	ctx, span := startSpan(ctx, "TeamService.Create",
+		attribute.String("team.name", team.Name),
+		attribute.Int("team.members", len(team.Members)),
+	)
+	created, err := s.next.Create(ctx, team)
+	endSpan(span, err)
+	return created, err
+}
+
+func (s *tracedTeamService) Get(ctx context.Context, teamName string) (*models.Team, error) {
+	ctx, span := startSpan(ctx, "TeamService.Get", attribute.String("team.name", teamName))
+	team, err := s.next.Get(ctx, teamName)
+	endSpan(span, err)
+	return team, err
+}
+
+func (s *tracedTeamService) GetByNames(ctx context.Context, teamNames []string) ([]*models.Team, error) {
+	ctx, span := startSpan(ctx, "TeamService.GetByNames", attribute.StringSlice("team.names", teamNames))
+	teams, err := s.next.GetByNames(ctx, teamNames)
+	endSpan(span, err)
+	return teams, err
+}
+
+func (s *tracedTeamService) List(ctx context.Context) ([]*models.Team, error) {
+	ctx, span := startSpan(ctx, "TeamService.List")
+	teams, err := s.next.List(ctx)
+	endSpan(span, err)
+	return teams, err
+}
+
+func (s *tracedTeamService) Find(ctx context.Context, teamName string, page models.OffsetPage) ([]*models.Team, int, error) {
+	ctx, span := startSpan(ctx, "TeamService.Find",
+		attribute.String("team.name", teamName),
+		attribute.Int("page.offset", page.Offset),
+		attribute.Int("page.limit", page.Limit),
+	)
+	teams, total, err := s.next.Find(ctx, teamName, page)
+	endSpan(span, err)
+	return teams, total, err
+}
+
+func (s *tracedTeamService) Delete(ctx context.Context, teamName string) error {
+	ctx, span := startSpan(ctx, "TeamService.Delete", attribute.String("team.name", teamName))
+	err := s.next.Delete(ctx, teamName)
+	endSpan(span, err)
+	return err
+}
@@ -1,52 +1,288 @@
 package service
 
 import (
+	logpkg "github.com/vnchk1/pr-manager/internal/logger"
 	"github.com/vnchk1/pr-manager/internal/models"
 	"github.com/vnchk1/pr-manager/internal/repository"
 	"context"
+	"errors"
 )
 
+// updateAttempts ограничивает повторы снятия ревьювера с PR, который параллельно меняют
+const updateAttempts = 3
+
 type UserService interface {
+	Create(ctx context.Context, user *models.User) (*models.User, error)
+	Update(ctx context.Context, userID string, update *models.UserUpdate) (*models.User, error)
 	SetActive(ctx context.Context, userID string, isActive bool) (*models.User, error)
 	GetByID(ctx context.Context, userID string) (*models.User, error)
-	GetReviewPRs(ctx context.Context, userID string) ([]*models.PullRequestShort, error)
+	GetByIDs(ctx context.Context, userIDs []string) ([]*models.User, error)
+	List(ctx context.Context) ([]*models.User, error)
+	Find(ctx context.Context, filter models.UserFilter, page models.OffsetPage) ([]*models.User, int, error)
+	Provision(ctx context.Context, user *models.User) (*models.User, error)
+	Deprovision(ctx context.Context, userID string) error
+	GetReviewPRs(ctx context.Context, userID string, status models.PullRequestStatus, page models.PageRequest) (*models.ReviewQueue, error)
 }
 
 type userService struct {
-	userRepo repository.UserRepository
+	userRepo         repository.UserRepository
+	teamRepo         repository.TeamRepository
+	prRepo           repository.PullRequestRepository
+	reviewerSelector ReviewerSelector
+	events           eventEmitter
 }
 
-func NewUserService(userRepo repository.UserRepository) UserService {
-	return &userService{userRepo: userRepo}
+func NewUserService(
+	userRepo repository.UserRepository,
+	teamRepo repository.TeamRepository,
+	prRepo repository.PullRequestRepository,
+	reviewerSelector ReviewerSelector,
+	publisher EventPublisher,
+) UserService {
+	return &userService{
+		userRepo:         userRepo,
+		teamRepo:         teamRepo,
+		prRepo:           prRepo,
+		reviewerSelector: reviewerSelector,
+		events:           eventEmitter{publisher: publisher, userRepo: userRepo},
+	}
 }
 
-func (s *userService) SetActive(ctx context.Context, userID string, isActive bool) (*models.User, error) {
-	_, err := s.userRepo.GetByID(ctx, userID)
+func (s *userService) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, user.ID); err == nil {
+		return nil, models.ErrUserExists
+	} else if !errors.Is(err, models.ErrNotFound) {
+		return nil, err
+	}
+
+	if err := s.ensureTeam(ctx, user.TeamName); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return s.userRepo.GetByID(ctx, user.ID)
+}
+
+// Update меняет имя, команду или активность пользователя. Если пользователь
+// деактивирован или перешел в другую команду, его открытые ревью переназначаются.
+func (s *userService) Update(ctx context.Context, userID string, update *models.UserUpdate) (*models.User, error) {
+	before, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = s.userRepo.SetActive(ctx, userID, isActive); err != nil {
+	after := *before
+	if update.Username != nil {
+		after.Username = *update.Username
+	}
+	if update.TeamName != nil {
+		after.TeamName = *update.TeamName
+	}
+	if update.IsActive != nil {
+		after.IsActive = *update.IsActive
+	}
+
+	if err := after.Validate(); err != nil {
 		return nil, err
 	}
 
-	updatedUser, err := s.userRepo.GetByID(ctx, userID)
+	if after.TeamName != before.TeamName {
+		if err := s.ensureTeam(ctx, after.TeamName); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.userRepo.Update(ctx, &after); err != nil {
+		return nil, err
+	}
+
+	if before.IsActive && (!after.IsActive || after.TeamName != before.TeamName) {
+		if err := s.reassignReviews(ctx, before); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return updatedUser, nil
+	if updated.IsActive != before.IsActive {
+		eventType := models.EventUserDeactivated
+		if updated.IsActive {
+			eventType = models.EventUserActivated
+		}
+		s.events.user(ctx, eventType, updated)
+	}
+
+	return updated, nil
+}
+
+func (s *userService) SetActive(ctx context.Context, userID string, isActive bool) (*models.User, error) {
+	return s.Update(ctx, userID, &models.UserUpdate{IsActive: &isActive})
 }
 
 func (s *userService) GetByID(ctx context.Context, userID string) (*models.User, error) {
 	return s.userRepo.GetByID(ctx, userID)
 }
 
-func (s *userService) GetReviewPRs(ctx context.Context, userID string) ([]*models.PullRequestShort, error) {
+// GetByIDs возвращает найденных пользователей из списка одним запросом
+func (s *userService) GetByIDs(ctx context.Context, userIDs []string) ([]*models.User, error) {
+	return s.userRepo.GetByIDs(ctx, userIDs)
+}
+
+func (s *userService) List(ctx context.Context) ([]*models.User, error) {
+	return s.userRepo.List(ctx)
+}
+
+// Find возвращает страницу пользователей и их общее число; удаленные через Deprovision не выбираются
+func (s *userService) Find(ctx context.Context, filter models.UserFilter, page models.OffsetPage) ([]*models.User, int, error) {
+	return s.userRepo.Find(ctx, filter, page)
+}
+
+// Provision создает пользователя, а удаленного через Deprovision восстанавливает с новыми данными.
+// Для существующего пользователя возвращает models.ErrUserExists.
+func (s *userService) Provision(ctx context.Context, user *models.User) (*models.User, error) {
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, user.ID); errors.Is(err, models.ErrNotFound) {
+		return s.Create(ctx, user)
+	} else if err != nil {
+		return nil, err
+	}
 
-	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+	visible, _, err := s.userRepo.Find(ctx, models.UserFilter{ID: user.ID}, models.OffsetPage{Limit: 1})
+	if err != nil {
 		return nil, err
 	}
+	if len(visible) > 0 {
+		return nil, models.ErrUserExists
+	}
 
-	return []*models.PullRequestShort{}, nil
+	updated, err := s.Update(ctx, user.ID, &models.UserUpdate{
+		Username: &user.Username,
+		TeamName: &user.TeamName,
+		IsActive: &user.IsActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.SetDeprovisioned(ctx, user.ID, false); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Deprovision деактивирует пользователя с переназначением ревью и отмечает удаленным.
+// Запись остается, потому что на нее ссылаются PR.
+func (s *userService) Deprovision(ctx context.Context, userID string) error {
+	inactive := false
+	if _, err := s.Update(ctx, userID, &models.UserUpdate{IsActive: &inactive}); err != nil {
+		return err
+	}
+
+	return s.userRepo.SetDeprovisioned(ctx, userID, true)
+}
+
+// GetReviewPRs возвращает страницу PR, назначенных пользователю, сгруппированных по состоянию его ревью
+func (s *userService) GetReviewPRs(
+	ctx context.Context,
+	userID string,
+	status models.PullRequestStatus,
+	page models.PageRequest,
+) (*models.ReviewQueue, error) {
+	return loadReviewQueue(ctx, s.userRepo, s.prRepo, userID, status, page)
+}
+
+// ensureTeam создает команду без участников, если ее еще нет
+func (s *userService) ensureTeam(ctx context.Context, teamName string) error {
+	exists, err := s.teamRepo.Exists(ctx, teamName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	if err := s.teamRepo.Create(ctx, &models.Team{Name: teamName}); err != nil && !errors.Is(err, models.ErrTeamExists) {
+		return err
+	}
+
+	return nil
+}
+
+// reassignReviews заменяет пользователя во всех открытых PR активным участником его прежней
+// команды. Если замены нет, пользователь просто снимается с ревью.
+func (s *userService) reassignReviews(ctx context.Context, user *models.User) error {
+	prs, err := s.prRepo.GetOpenPRsWithReviewer(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, pr := range prs {
+		if err := s.removeReviewer(ctx, pr, user); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeReviewer снимает пользователя с ревью PR. Если PR изменили после чтения,
+// замена подбирается заново по свежей версии.
+func (s *userService) removeReviewer(ctx context.Context, pr *models.PullRequest, user *models.User) error {
+	for attempt := 1; ; attempt++ {
+		exclude := append([]string{pr.AuthorID}, pr.AssignedReviewers...)
+		newReviewerID, err := s.reviewerSelector.SelectReplacementReviewer(ctx, user.TeamName, exclude)
+		if err != nil && !errors.Is(err, models.ErrNoCandidate) {
+			return err
+		}
+
+		reviewers := make([]string, 0, len(pr.AssignedReviewers))
+		for _, reviewer := range pr.AssignedReviewers {
+			switch {
+			case reviewer != user.ID:
+				reviewers = append(reviewers, reviewer)
+			case newReviewerID != "":
+				reviewers = append(reviewers, newReviewerID)
+			}
+		}
+
+		updated := *pr
+		updated.AssignedReviewers = reviewers
+		err = s.prRepo.Update(ctx, &updated)
+		if errors.Is(err, models.ErrVersionConflict) && attempt < updateAttempts {
+			if pr, err = s.prRepo.GetByID(ctx, pr.ID); err != nil {
+				return err
+			}
+			if pr.Status != models.StatusOpen || !contains(pr.AssignedReviewers, user.ID) {
+				return nil
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		logpkg.FromContext(ctx).Debug("reviewer reassigned", "pr_id", pr.ID, "old_reviewer", user.ID, "new_reviewer", newReviewerID)
+
+		s.events.pullRequest(ctx, models.EventPRReassigned, models.PREventData{
+			PullRequest: &updated,
+			OldReviewer: user.ID,
+			NewReviewer: newReviewerID,
+		})
+
+		return nil
+	}
 }
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/vnchk1/pr-manager/internal/models"
+	"github.com/vnchk1/pr-manager/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Заглушки реализуют только методы, которые вызывает userService; остальные
+// методы встроенного интерфейса паникуют при вызове.
+type stubUserRepo struct {
+	repository.UserRepository
+	users map[string]*models.User
+}
+
+func (r *stubUserRepo) GetByID(_ context.Context, userID string) (*models.User, error) {
+	user, ok := r.users[userID]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	found := *user
+	return &found, nil
+}
+
+func (r *stubUserRepo) Update(_ context.Context, user *models.User) error {
+	updated := *user
+	r.users[user.ID] = &updated
+	return nil
+}
+
+func (r *stubUserRepo) GetActiveTeamMembersExcluding(_ context.Context, teamName string, exclude []string) ([]*models.User, error) {
+	var members []*models.User
+	for _, user := range r.users {
+		if user.TeamName == teamName && user.IsActive && !contains(exclude, user.ID) {
+			members = append(members, user)
+		}
+	}
+	return members, nil
+}
+
+type stubTeamRepo struct {
+	repository.TeamRepository
+}
+
+func (stubTeamRepo) Exists(context.Context, string) (bool, error) {
+	return true, nil
+}
+
+type stubPRRepo struct {
+	repository.PullRequestRepository
+	mu  sync.Mutex
+	prs map[string]*models.PullRequest
+}
+
+func (r *stubPRRepo) GetOpenPRsWithReviewer(_ context.Context, reviewerID string) ([]*models.PullRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var prs []*models.PullRequest
+	for _, pr := range r.prs {
+		if pr.Status == models.StatusOpen && contains(pr.AssignedReviewers, reviewerID) {
+			found := *pr
+			prs = append(prs, &found)
+		}
+	}
+	return prs, nil
+}
+
+// Update, как и репозиторий, применяется только к текущей версии PR
+func (r *stubPRRepo) Update(_ context.Context, pr *models.PullRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.prs[pr.ID]
+	if !ok {
+		return models.ErrNotFound
+	}
+	if current.Version != pr.Version {
+		return models.ErrVersionConflict
+	}
+
+	pr.Version++
+	updated := *pr
+	r.prs[pr.ID] = &updated
+	return nil
+}
+
+func newStubUserService(users []*models.User, prs []*models.PullRequest) (UserService, *stubPRRepo) {
+	userRepo := &stubUserRepo{users: make(map[string]*models.User)}
+	for _, user := range users {
+		userRepo.users[user.ID] = user
+	}
+	prRepo := &stubPRRepo{prs: make(map[string]*models.PullRequest)}
+	for _, pr := range prs {
+		prRepo.prs[pr.ID] = pr
+	}
+	return NewUserService(userRepo, stubTeamRepo{}, prRepo, NewReviewerSelector(userRepo), nil), prRepo
+}
+
+func TestUserServiceDeactivationReassignsReviews(t *testing.T) {
+	svc, prRepo := newStubUserService(
+		[]*models.User{
+			{ID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+			{ID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+			{ID: "u3", Username: "Carol", TeamName: "backend", IsActive: true},
+			{ID: "u4", Username: "Dave", TeamName: "backend", IsActive: true},
+		},
+		[]*models.PullRequest{
+			{ID: "pr-1", AuthorID: "u1", Status: models.StatusOpen, AssignedReviewers: []string{"u2", "u3"}},
+			{ID: "pr-2", AuthorID: "u4", Status: models.StatusMerged, AssignedReviewers: []string{"u2"}},
+		},
+	)
+
+	user, err := svc.SetActive(context.Background(), "u2", false)
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+
+	// u4 - единственный активный участник, не автор и не ревьювер pr-1
+	assert.Equal(t, []string{"u4", "u3"}, prRepo.prs["pr-1"].AssignedReviewers)
+	assert.Equal(t, []string{"u2"}, prRepo.prs["pr-2"].AssignedReviewers)
+}
+
+func TestUserServiceMoveDropsReviewerWithoutCandidate(t *testing.T) {
+	svc, prRepo := newStubUserService(
+		[]*models.User{
+			{ID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+			{ID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		},
+		[]*models.PullRequest{
+			{ID: "pr-1", AuthorID: "u1", Status: models.StatusOpen, AssignedReviewers: []string{"u2"}},
+		},
+	)
+
+	team := "frontend"
+	user, err := svc.Update(context.Background(), "u2", &models.UserUpdate{TeamName: &team})
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", user.TeamName)
+	assert.True(t, user.IsActive)
+
+	assert.Empty(t, prRepo.prs["pr-1"].AssignedReviewers)
+}
+
+type recordingPublisher struct {
+	events []*models.Event
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event *models.Event) {
+	p.events = append(p.events, event)
+}
+
+func TestUserServiceDeactivationPublishesEvents(t *testing.T) {
+	userRepo := &stubUserRepo{users: map[string]*models.User{
+		"u1": {ID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		"u2": {ID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		"u3": {ID: "u3", Username: "Carol", TeamName: "backend", IsActive: true},
+	}}
+	prRepo := &stubPRRepo{prs: map[string]*models.PullRequest{
+		"pr-1": {ID: "pr-1", AuthorID: "u1", Status: models.StatusOpen, AssignedReviewers: []string{"u2"}},
+	}}
+	publisher := &recordingPublisher{}
+	svc := NewUserService(userRepo, stubTeamRepo{}, prRepo, NewReviewerSelector(userRepo), publisher)
+
+	_, err := svc.SetActive(context.Background(), "u2", false)
+	require.NoError(t, err)
+
+	require.Len(t, publisher.events, 2)
+	reassigned, deactivated := publisher.events[0], publisher.events[1]
+
+	assert.Equal(t, models.EventPRReassigned, reassigned.Type)
+	assert.Equal(t, "backend", reassigned.TeamName)
+	assert.Equal(t, []string{"u1", "u3", "u2"}, reassigned.UserIDs)
+	assert.JSONEq(t, `{
+		"pull_request": {"pull_request_id": "pr-1", "pull_request_name": "", "author_id": "u1", "status": "OPEN",
+			"assigned_reviewers": ["u3"], "created_at": "0001-01-01T00:00:00Z", "updated_at": "0001-01-01T00:00:00Z",
+			"version": 1},
+		"old_reviewer": "u2",
+		"new_reviewer": "u3"
+	}`, string(reassigned.Data))
+
+	assert.Equal(t, models.EventUserDeactivated, deactivated.Type)
+	assert.Equal(t, []string{"u2"}, deactivated.UserIDs)
+
+	// Повторная деактивация ничего не меняет и событий не публикует
+	_, err = svc.SetActive(context.Background(), "u2", false)
+	require.NoError(t, err)
+	assert.Len(t, publisher.events, 2)
+}
+
+// conflictingPRRepo меняет PR между чтением и записью, как параллельный запрос
+type conflictingPRRepo struct {
+	*stubPRRepo
+	conflicts int
+}
+
+func (r *conflictingPRRepo) Update(ctx context.Context, pr *models.PullRequest) error {
+	if r.conflicts > 0 {
+		r.conflicts--
+		r.mu.Lock()
+		r.prs[pr.ID].Version++
+		r.mu.Unlock()
+	}
+	return r.stubPRRepo.Update(ctx, pr)
+}
+
+func TestUserServiceDeactivationRetriesOnConflict(t *testing.T) {
+	userRepo := &stubUserRepo{users: map[string]*models.User{
+		"u1": {ID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		"u2": {ID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		"u3": {ID: "u3", Username: "Carol", TeamName: "backend", IsActive: true},
+	}}
+	prRepo := &conflictingPRRepo{
+		stubPRRepo: &stubPRRepo{prs: map[string]*models.PullRequest{
+			"pr-1": {ID: "pr-1", AuthorID: "u1", Status: models.StatusOpen, AssignedReviewers: []string{"u2"}, Version: 1},
+		}},
+		conflicts: 1,
+	}
+	svc := NewUserService(userRepo, stubTeamRepo{}, prRepo, NewReviewerSelector(userRepo), nil)
+
+	_, err := svc.SetActive(context.Background(), "u2", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"u3"}, prRepo.prs["pr-1"].AssignedReviewers)
+	assert.Equal(t, 3, prRepo.prs["pr-1"].Version)
+
+	// Если конфликты не прекращаются, ошибка возвращается вызывающему
+	prRepo.conflicts = updateAttempts
+	_, err = svc.SetActive(context.Background(), "u3", false)
+	assert.ErrorIs(t, err, models.ErrVersionConflict)
+}
@@ -0,0 +1,62 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestQueryTracer(t *testing.T) {
+	tests := []struct {
+		name       string
+		sql        string
+		err        error
+		wantName   string
+		wantStatus codes.Code
+	}{
+		{
+			name:       "Successful select",
+			sql:        "\n\t\tSELECT id FROM users WHERE id = $1",
+			wantName:   "postgres SELECT",
+			wantStatus: codes.Unset,
+		},
+		{
+			name:       "No rows is not an error",
+			sql:        "SELECT id FROM users WHERE id = $1",
+			err:        pgx.ErrNoRows,
+			wantName:   "postgres SELECT",
+			wantStatus: codes.Unset,
+		},
+		{
+			name:       "Failed insert",
+			sql:        "insert into users (id) values ($1)",
+			err:        errors.New("duplicate key"),
+			wantName:   "postgres INSERT",
+			wantStatus: codes.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+			qt := &queryTracer{tracer: provider.Tracer("test")}
+
+			ctx := qt.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: tt.sql})
+			qt.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 1"), Err: tt.err})
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tt.wantName, spans[0].Name())
+			assert.Equal(t, tt.wantStatus, spans[0].Status().Code)
+		})
+	}
+}
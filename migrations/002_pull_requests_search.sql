@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Индекс для полнотекстового поиска по названию PR (/pullRequest/search).
+-- Конфигурация simple не выполняет стемминг, поэтому подходит для названий на любом языке.
+CREATE INDEX IF NOT EXISTS idx_pull_requests_name_fts ON pull_requests USING GIN (to_tsvector('simple', name));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_pull_requests_name_fts;
+
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Ревью, оставленные назначенными ревьюверами. Отсутствие строки означает, что ревью еще не было.
+CREATE TABLE IF NOT EXISTS pr_reviews (
+    pull_request_id VARCHAR(255) REFERENCES pull_requests(id) ON DELETE CASCADE,
+    reviewer_id VARCHAR(255) REFERENCES users(id) ON DELETE CASCADE,
+    state VARCHAR(50) NOT NULL CHECK (state IN ('APPROVED', 'CHANGES_REQUESTED')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (pull_request_id, reviewer_id)
+    );
+
+CREATE INDEX IF NOT EXISTS idx_pr_reviews_reviewer_id ON pr_reviews(reviewer_id);
+
+CREATE TRIGGER update_pr_reviews_updated_at BEFORE UPDATE ON pr_reviews
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS update_pr_reviews_updated_at ON pr_reviews;
+DROP TABLE IF EXISTS pr_reviews;
+
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Журнал событий для SSE-потока: по нему клиент догоняет пропущенное после переподключения (Last-Event-ID)
+CREATE TABLE IF NOT EXISTS events (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(50) NOT NULL,
+    team_name VARCHAR(255),
+    user_ids JSONB NOT NULL DEFAULT '[]',
+    data JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+    );
+
+CREATE INDEX IF NOT EXISTS idx_events_team_name ON events(team_name, id);
+CREATE INDEX IF NOT EXISTS idx_events_user_ids ON events USING GIN (user_ids);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS events;
+
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Каналы, поводы, тихие часы и режим доставки уведомлений. Отписка от почты
+-- переносится в список каналов.
+ALTER TABLE notification_preferences
+    ADD COLUMN channels JSONB NOT NULL DEFAULT '["chat", "email", "webhook"]',
+    ADD COLUMN event_types JSONB NOT NULL DEFAULT '["assigned", "reassigned", "overdue"]',
+    ADD COLUMN quiet_hours_start VARCHAR(5),
+    ADD COLUMN quiet_hours_end VARCHAR(5),
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    ADD COLUMN delivery VARCHAR(20) NOT NULL DEFAULT 'immediate' CHECK (delivery IN ('immediate', 'digest')),
+    ADD COLUMN webhook_url TEXT;
+
+UPDATE notification_preferences SET channels = '["chat", "webhook"]' WHERE email_opt_out;
+
+ALTER TABLE notification_preferences DROP COLUMN email_opt_out;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE notification_preferences ADD COLUMN email_opt_out BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE notification_preferences SET email_opt_out = NOT (channels ? 'email');
+
+ALTER TABLE notification_preferences
+    DROP COLUMN channels,
+    DROP COLUMN event_types,
+    DROP COLUMN quiet_hours_start,
+    DROP COLUMN quiet_hours_end,
+    DROP COLUMN timezone,
+    DROP COLUMN delivery,
+    DROP COLUMN webhook_url;
+
+-- +goose StatementEnd
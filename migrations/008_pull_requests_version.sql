@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Версия для оптимистичной блокировки: изменение PR применяется, только если версия
+-- не изменилась с момента чтения
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS version;
+
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Пользователи, удаленные через SCIM. Удалить запись из users нельзя, пока на нее
+-- ссылаются PR, поэтому удаление отмечается здесь, а SCIM такие записи не показывает.
+CREATE TABLE IF NOT EXISTS deprovisioned_users (
+    user_id VARCHAR(255) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    deprovisioned_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS deprovisioned_users;
+
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- PR, о просрочке которых уже оповестили. Реплика, вставившая строку, забирает
+-- оповещение себе; остальные получают конфликт и PR пропускают.
+CREATE TABLE IF NOT EXISTS overdue_notifications (
+    pull_request_id VARCHAR(255) PRIMARY KEY REFERENCES pull_requests(id) ON DELETE CASCADE,
+    notified_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS overdue_notifications;
+
+-- +goose StatementEnd
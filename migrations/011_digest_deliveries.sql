@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- День последней отправленной пользователю сводки. Реплика, сдвинувшая день вперед,
+-- отправляет сводку; остальные видят, что за этот день она уже отправлена.
+CREATE TABLE IF NOT EXISTS digest_deliveries (
+    user_id VARCHAR(255) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS digest_deliveries;
+
+-- +goose StatementEnd